@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/httputil"
+)
+
+// Authenticate rejects requests without a valid bearer token with 401 and stores the
+// caller's principal in the request context.
+func Authenticate(verifier *Verifier) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		scheme, token, found := strings.Cut(ctx.GetHeader("Authorization"), " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			unauthorized(ctx, "missing bearer token")
+			return
+		}
+
+		principal, err := verifier.Verify(strings.TrimSpace(token))
+		if err != nil {
+			unauthorized(ctx, "invalid bearer token")
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(WithPrincipal(ctx.Request.Context(), principal))
+		ctx.Next()
+	}
+}
+
+// TokenFromQuery takes the bearer token from the given query parameter when the request has
+// no Authorization header. Browsers cannot set headers on EventSource and WebSocket requests,
+// so streaming routes accept the token this way; it must run before Authenticate.
+func TokenFromQuery(param string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if token := ctx.Query(param); token != "" && ctx.GetHeader("Authorization") == "" {
+			ctx.Request.Header.Set("Authorization", "Bearer "+token)
+		}
+		ctx.Next()
+	}
+}
+
+// RequireRoles lets the request through when the caller holds one of the roles, and
+// answers 403 otherwise. It must run after Authenticate.
+func RequireRoles(roles ...Role) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !PrincipalFrom(ctx).HasRole(roles...) {
+			httputil.ErrorResponse(ctx, http.StatusForbidden, "Forbidden",
+				"you do not have permission to perform this action")
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// PrincipalFrom returns the caller authenticated for this request, or nil.
+func PrincipalFrom(ctx *gin.Context) *Principal {
+	return PrincipalFromContext(ctx.Request.Context())
+}
+
+// SubjectFrom returns the subject of the authenticated caller, or "" when there is none.
+func SubjectFrom(ctx *gin.Context) string {
+	if principal := PrincipalFrom(ctx); principal != nil {
+		return principal.Subject
+	}
+	return ""
+}
+
+func unauthorized(ctx *gin.Context, message string) {
+	ctx.Header("WWW-Authenticate", `Bearer realm="api"`)
+	httputil.ErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized", message)
+	ctx.Abort()
+}
@@ -0,0 +1,53 @@
+package auth
+
+import "context"
+
+type Role string
+
+const (
+	// RoleHRAdmin manages starters.
+	RoleHRAdmin Role = "hr_admin"
+	// RoleOrgAdmin manages departments and their leaders.
+	RoleOrgAdmin Role = "org_admin"
+	// RoleLeader leads departments and may edit the departments and starters below them.
+	RoleLeader Role = "leader"
+	// RoleViewer has read-only access.
+	RoleViewer Role = "viewer"
+)
+
+// AllRoles lists every known role; routes open to any signed-in user require one of them.
+var AllRoles = []Role{RoleHRAdmin, RoleOrgAdmin, RoleLeader, RoleViewer}
+
+// Principal is the authenticated caller. Subject is the token's sub claim, the caller's
+// starter domain.
+type Principal struct {
+	Subject string
+	Roles   []Role
+}
+
+// HasRole reports whether the principal holds at least one of the given roles.
+func (p *Principal) HasRole(roles ...Role) bool {
+	if p == nil {
+		return false
+	}
+	for _, held := range p.Roles {
+		for _, role := range roles {
+			if held == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type principalContextKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal stored by Authenticate, or nil.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalContextKey{}).(*Principal)
+	return principal
+}
@@ -6,5 +6,9 @@ const (
 	EventTypeStarterDelete = "starter.delete"
 	EventTypeStarterIndex  = "starter.index"
 
-	EventTypeNotificationLeaderAssignment = "notification.leader_assignment"
+	EventTypeNotificationLeaderAssignment   = "notification.leader_assignment"
+	EventTypeNotificationStarterCreated     = "notification.starter_created"
+	EventTypeNotificationLineManagerChanged = "notification.line_manager_changed"
+	EventTypeNotificationDepartmentTransfer = "notification.department_transfer"
+	EventTypeNotificationStarterDeleted     = "notification.starter_deleted"
 )
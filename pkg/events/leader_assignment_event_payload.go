@@ -3,5 +3,11 @@ package events
 type LeaderAssignmentEventPayload struct {
 	FromStarter string `json:"from_starter"`
 	ToStarter   string `json:"to_starter"`
-	Message     string `json:"message"`
+	// Message is an English rendering for consumers that do not render their own text
+	Message string `json:"message"`
+
+	DepartmentID   int64  `json:"department_id,omitempty"`
+	DepartmentName string `json:"department_name,omitempty"`
+	// PreviousLeader is the domain of the department's leader before, empty when it had none
+	PreviousLeader string `json:"previous_leader,omitempty"`
 }
@@ -0,0 +1,50 @@
+package events
+
+// NotificationPayload addresses a notification event to one recipient.
+type NotificationPayload struct {
+	FromStarter string `json:"from_starter"`
+	ToStarter   string `json:"to_starter"`
+	// Message is an English rendering for consumers that do not render their own text
+	Message string `json:"message"`
+}
+
+// StarterCreatedEventPayload tells the new starter's line manager and department leader.
+type StarterCreatedEventPayload struct {
+	NotificationPayload
+	StarterDomain  string `json:"starter_domain"`
+	StarterName    string `json:"starter_name"`
+	DepartmentID   int64  `json:"department_id,omitempty"`
+	DepartmentName string `json:"department_name,omitempty"`
+	// LineManager is the domain of the starter's line manager, empty when they have none
+	LineManager string `json:"line_manager,omitempty"`
+}
+
+// LineManagerChangedEventPayload tells the starter's previous and new line manager.
+type LineManagerChangedEventPayload struct {
+	NotificationPayload
+	StarterDomain       string `json:"starter_domain"`
+	StarterName         string `json:"starter_name"`
+	LineManager         string `json:"line_manager,omitempty"`
+	PreviousLineManager string `json:"previous_line_manager,omitempty"`
+}
+
+// DepartmentTransferEventPayload tells the starter and the leaders of both departments.
+type DepartmentTransferEventPayload struct {
+	NotificationPayload
+	StarterDomain          string `json:"starter_domain"`
+	StarterName            string `json:"starter_name"`
+	DepartmentID           int64  `json:"department_id,omitempty"`
+	DepartmentName         string `json:"department_name,omitempty"`
+	PreviousDepartmentID   int64  `json:"previous_department_id,omitempty"`
+	PreviousDepartmentName string `json:"previous_department_name,omitempty"`
+}
+
+// StarterDeletedEventPayload tells the deleted starter's line manager and department leader.
+type StarterDeletedEventPayload struct {
+	NotificationPayload
+	StarterDomain  string `json:"starter_domain"`
+	StarterName    string `json:"starter_name"`
+	DepartmentID   int64  `json:"department_id,omitempty"`
+	DepartmentName string `json:"department_name,omitempty"`
+	LineManager    string `json:"line_manager,omitempty"`
+}
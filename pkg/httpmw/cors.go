@@ -0,0 +1,98 @@
+// Package httpmw holds the Gin middleware shared by the services' routers.
+package httpmw
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSConfig is the cross-origin policy. Only origins on the allowlist get CORS headers;
+// "*" allows every origin, but then credentials are never allowed.
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration
+}
+
+// DefaultCORSConfig allows no origin; callers fill in AllowedOrigins.
+func DefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowedMethods: []string{
+			http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+		},
+		AllowedHeaders: []string{"Authorization", "Content-Type", "X-Requested-With"},
+		MaxAge:         10 * time.Minute,
+	}
+}
+
+// CORS applies the policy. Preflight requests from allowed origins are answered with 204,
+// and from other origins with 403; every other request, HEAD included, goes on to its route,
+// with CORS headers only when its origin is allowed.
+func CORS(cfg CORSConfig) gin.HandlerFunc {
+	allowAny := false
+	allowed := make(map[string]bool, len(cfg.AllowedOrigins))
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			allowAny = true
+			continue
+		}
+		allowed[strings.ToLower(strings.TrimSuffix(origin, "/"))] = true
+	}
+	allowCredentials := cfg.AllowCredentials && !allowAny
+
+	allowMethods := strings.Join(cfg.AllowedMethods, ", ")
+	allowHeaders := strings.Join(cfg.AllowedHeaders, ", ")
+	exposeHeaders := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(ctx *gin.Context) {
+		origin := ctx.GetHeader("Origin")
+		preflight := ctx.Request.Method == http.MethodOptions && ctx.GetHeader("Access-Control-Request-Method") != ""
+
+		// Responses differ by origin, so caches must keep them apart
+		ctx.Writer.Header().Add("Vary", "Origin")
+		if origin == "" {
+			ctx.Next()
+			return
+		}
+
+		if !allowAny && !allowed[strings.ToLower(origin)] {
+			if preflight {
+				ctx.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			ctx.Next()
+			return
+		}
+
+		if allowAny {
+			ctx.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			ctx.Header("Access-Control-Allow-Origin", origin)
+		}
+		if allowCredentials {
+			ctx.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		if preflight {
+			ctx.Header("Access-Control-Allow-Methods", allowMethods)
+			ctx.Header("Access-Control-Allow-Headers", allowHeaders)
+			ctx.Header("Access-Control-Max-Age", maxAge)
+			ctx.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		if exposeHeaders != "" {
+			ctx.Header("Access-Control-Expose-Headers", exposeHeaders)
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,114 @@
+package httpmw
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
+	"github.com/kiin21/go-rest/pkg/httputil"
+)
+
+// RetryAfterHeader tells a rate limited client how many seconds to wait.
+const RetryAfterHeader = "Retry-After"
+
+// RateLimit allows Requests requests per Per, in bursts of up to Requests. The zero value
+// disables limiting.
+type RateLimit struct {
+	Requests int
+	Per      time.Duration
+}
+
+func (l RateLimit) Enabled() bool {
+	return l.Requests > 0 && l.Per > 0
+}
+
+// ParseRateLimit parses "<requests>/<duration>", e.g. "60/1m". An empty string or "0"
+// disables limiting.
+func ParseRateLimit(value string) (RateLimit, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0" {
+		return RateLimit{}, nil
+	}
+
+	requests, per, found := strings.Cut(value, "/")
+	if !found {
+		return RateLimit{}, fmt.Errorf("rate limit %q: expected <requests>/<duration>", value)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n < 0 {
+		return RateLimit{}, fmt.Errorf("rate limit %q: invalid request count", value)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(per))
+	if err != nil || d <= 0 {
+		return RateLimit{}, fmt.Errorf("rate limit %q: invalid duration", value)
+	}
+	return RateLimit{Requests: n, Per: d}, nil
+}
+
+// RateLimitConfig holds the limit every caller gets across all routes, and the routes with
+// their own, separate limit. Routes are keyed by method and route pattern, e.g.
+// "GET /api/v1/starters".
+type RateLimitConfig struct {
+	Default RateLimit
+	Routes  map[string]RateLimit
+}
+
+// RateLimitStore keeps the token buckets.
+type RateLimitStore interface {
+	// Take takes a token from the bucket under key, which refills at limit's rate. When the
+	// bucket is empty it reports how long until the next token.
+	Take(ctx context.Context, key string, limit RateLimit) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// RateLimiter answers callers that ran out of tokens with 429 and a Retry-After header. The
+// caller is the authenticated user (an integration's client credentials token carries its
+// client ID as subject), so it must run after auth.Authenticate; requests without a principal
+// are not limited. If the store fails, the request is let through.
+func RateLimiter(store RateLimitStore, cfg RateLimitConfig) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		principal := auth.PrincipalFrom(ctx)
+		if principal == nil {
+			ctx.Next()
+			return
+		}
+
+		route := ctx.Request.Method + " " + ctx.FullPath()
+		key := "user:" + principal.Subject
+		limit, ok := cfg.Routes[route]
+		if ok {
+			key = route + "|" + key
+		} else {
+			limit = cfg.Default
+		}
+		if !limit.Enabled() {
+			ctx.Next()
+			return
+		}
+
+		allowed, retryAfter, err := store.Take(ctx.Request.Context(), key, limit)
+		if err != nil {
+			log.Printf("Rate limit store failed for %q: %v", key, err)
+			ctx.Next()
+			return
+		}
+		if !allowed {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			if seconds < 1 {
+				seconds = 1
+			}
+			ctx.Header(RetryAfterHeader, strconv.Itoa(seconds))
+			httputil.ErrorResponse(ctx, http.StatusTooManyRequests, "Too many requests",
+				fmt.Sprintf("rate limit exceeded, retry after %d seconds", seconds))
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,72 @@
+package httpmw
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memorySweepInterval is how often buckets that have refilled completely are dropped.
+const memorySweepInterval = time.Minute
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	// fullAt is when the bucket will have refilled completely if left alone
+	fullAt time.Time
+}
+
+// MemoryRateLimitStore keeps token buckets in process memory, so every instance of a service
+// limits on its own.
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	sweptAt time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, limit RateLimit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	capacity := float64(limit.Requests)
+	interval := limit.Per / time.Duration(limit.Requests)
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: capacity, updatedAt: now}
+		s.buckets[key] = bucket
+	} else {
+		refill := float64(now.Sub(bucket.updatedAt)) / float64(interval)
+		bucket.tokens = min(capacity, bucket.tokens+refill)
+		bucket.updatedAt = now
+	}
+
+	if bucket.tokens < 1 {
+		return false, time.Duration((1 - bucket.tokens) * float64(interval)), nil
+	}
+	bucket.tokens--
+	bucket.fullAt = now.Add(time.Duration((capacity - bucket.tokens) * float64(interval)))
+	return true, 0, nil
+}
+
+// sweep drops the buckets that are full again; a missing bucket starts out full anyway.
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.sweptAt) < memorySweepInterval {
+		return
+	}
+	s.sweptAt = now
+	for key, bucket := range s.buckets {
+		if !now.Before(bucket.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package httpmw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
+)
+
+func TestMemoryRateLimitStoreBurst(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	limit := RateLimit{Requests: 3, Per: 3 * time.Second}
+
+	for i := 0; i < 3; i++ {
+		if allowed, _, err := store.Take(context.Background(), "user:thanhnt", limit); err != nil || !allowed {
+			t.Fatalf("request %d of the burst: allowed = %v, err = %v", i+1, allowed, err)
+		}
+	}
+
+	allowed, retryAfter, err := store.Take(context.Background(), "user:thanhnt", limit)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if allowed {
+		t.Fatal("request past the burst was allowed")
+	}
+	if retryAfter <= 0 || retryAfter > time.Second {
+		t.Errorf("retryAfter = %v; want up to one token interval of 1s", retryAfter)
+	}
+
+	// Buckets are kept per key
+	if allowed, _, _ := store.Take(context.Background(), "user:other", limit); !allowed {
+		t.Error("another caller's request was limited")
+	}
+}
+
+func TestMemoryRateLimitStoreRefill(t *testing.T) {
+	limit := RateLimit{Requests: 2, Per: 2 * time.Second}
+
+	tests := []struct {
+		name        string
+		elapsed     time.Duration
+		wantAllowed int
+	}{
+		{"no time passed", 0, 0},
+		{"half a token", 500 * time.Millisecond, 0},
+		{"one token", time.Second, 1},
+		{"refilled past capacity", time.Hour, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryRateLimitStore()
+			for i := 0; i < limit.Requests; i++ {
+				store.Take(context.Background(), "user:thanhnt", limit)
+			}
+			// Wind the bucket back instead of sleeping
+			bucket := store.buckets["user:thanhnt"]
+			bucket.updatedAt = bucket.updatedAt.Add(-tt.elapsed)
+
+			allowed := 0
+			for i := 0; i < limit.Requests+1; i++ {
+				if ok, _, _ := store.Take(context.Background(), "user:thanhnt", limit); ok {
+					allowed++
+				}
+			}
+			if allowed != tt.wantAllowed {
+				t.Errorf("allowed %d requests; want %d", allowed, tt.wantAllowed)
+			}
+		})
+	}
+}
+
+// stubRateLimitStore answers every Take the same way and records the keys it was asked for.
+type stubRateLimitStore struct {
+	allowed    bool
+	retryAfter time.Duration
+	err        error
+	keys       []string
+}
+
+func (s *stubRateLimitStore) Take(_ context.Context, key string, _ RateLimit) (bool, time.Duration, error) {
+	s.keys = append(s.keys, key)
+	return s.allowed, s.retryAfter, s.err
+}
+
+func TestRateLimiter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := RateLimitConfig{
+		Default: RateLimit{Requests: 60, Per: time.Minute},
+		Routes:  map[string]RateLimit{"GET /search": {Requests: 5, Per: time.Minute}},
+	}
+
+	tests := []struct {
+		name           string
+		store          *stubRateLimitStore
+		path           string
+		subject        string
+		wantStatus     int
+		wantRetryAfter string
+		wantKeys       []string
+	}{
+		{"allowed", &stubRateLimitStore{allowed: true}, "/starters", "thanhnt", http.StatusOK, "", []string{"user:thanhnt"}},
+		{"route with its own bucket", &stubRateLimitStore{allowed: true}, "/search", "thanhnt", http.StatusOK, "", []string{"GET /search|user:thanhnt"}},
+		{"limited", &stubRateLimitStore{retryAfter: 1500 * time.Millisecond}, "/starters", "thanhnt", http.StatusTooManyRequests, "2", []string{"user:thanhnt"}},
+		{"Retry-After is at least a second", &stubRateLimitStore{retryAfter: 10 * time.Millisecond}, "/starters", "thanhnt", http.StatusTooManyRequests, "1", []string{"user:thanhnt"}},
+		{"store failure lets the request through", &stubRateLimitStore{err: errors.New("store down")}, "/starters", "thanhnt", http.StatusOK, "", []string{"user:thanhnt"}},
+		{"no principal", &stubRateLimitStore{}, "/starters", "", http.StatusOK, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(RateLimiter(tt.store, cfg))
+			ok := func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+			r.GET("/starters", ok)
+			r.GET("/search", ok)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.subject != "" {
+				req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{Subject: tt.subject}))
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d; want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get(RetryAfterHeader); got != tt.wantRetryAfter {
+				t.Errorf("Retry-After = %q; want %q", got, tt.wantRetryAfter)
+			}
+			if len(tt.store.keys) != len(tt.wantKeys) || (len(tt.wantKeys) > 0 && tt.store.keys[0] != tt.wantKeys[0]) {
+				t.Errorf("bucket keys = %v; want %v", tt.store.keys, tt.wantKeys)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package httpmw
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/httputil"
+)
+
+// SecurityHeadersConfig selects the security headers added to every response.
+type SecurityHeadersConfig struct {
+	// ContentSecurityPolicy is omitted when empty
+	ContentSecurityPolicy string
+	// HSTSMaxAge enables Strict-Transport-Security on HTTPS requests; 0 disables it
+	HSTSMaxAge time.Duration
+}
+
+// DefaultSecurityHeadersConfig forbids framing and pins HTTPS for a year. The policy leaves
+// scripts and styles alone so that the Swagger UI keeps working.
+func DefaultSecurityHeadersConfig() SecurityHeadersConfig {
+	return SecurityHeadersConfig{
+		ContentSecurityPolicy: "frame-ancestors 'none'",
+		HSTSMaxAge:            365 * 24 * time.Hour,
+	}
+}
+
+// SecurityHeaders stops browsers from sniffing content types, framing responses and leaking
+// URLs in the Referer header, and keeps them on HTTPS once they reached the service over it.
+func SecurityHeaders(cfg SecurityHeadersConfig) gin.HandlerFunc {
+	hsts := "max-age=" + strconv.Itoa(int(cfg.HSTSMaxAge.Seconds())) + "; includeSubDomains"
+	resolver := httputil.NewRequestURLResolver()
+
+	return func(ctx *gin.Context) {
+		header := ctx.Writer.Header()
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set("X-Frame-Options", "DENY")
+		header.Set("Referrer-Policy", "no-referrer")
+		if cfg.ContentSecurityPolicy != "" {
+			header.Set("Content-Security-Policy", cfg.ContentSecurityPolicy)
+		}
+		// The services run behind a TLS terminating proxy, so X-Forwarded-Proto counts too
+		if cfg.HSTSMaxAge > 0 && resolver.Scheme(ctx) == "https" {
+			header.Set("Strict-Transport-Security", hsts)
+		}
+		ctx.Next()
+	}
+}
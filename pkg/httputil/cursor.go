@@ -0,0 +1,100 @@
+package httputil
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type PaginationMode int
+
+const (
+	PaginationModePage PaginationMode = iota
+	PaginationModeCursor
+)
+
+// Cursor marks a position in a keyset-paginated list: the sort key and id of the last
+// item returned. Clients receive it as an opaque token and send it back with ?cursor=.
+type Cursor struct {
+	SortBy    string `json:"sb,omitempty"`
+	SortOrder string `json:"so,omitempty"`
+	Value     string `json:"v,omitempty"`
+	ID        string `json:"id,omitempty"`
+}
+
+// IsFirstPage reports whether the cursor starts a new listing rather than continuing one.
+func (c *Cursor) IsFirstPage() bool {
+	return c.ID == ""
+}
+
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func (c *Cursor) Int64ID() (int64, error) {
+	return strconv.ParseInt(c.ID, 10, 64)
+}
+
+func (c *Cursor) TimeValue() (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, c.Value)
+}
+
+// ParseCursor decodes the cursor query parameter; an empty value starts from the first page.
+// A cursor only makes sense for the sort it was issued for, so any other sort is rejected.
+func ParseCursor(raw, sortBy, sortOrder string) (*Cursor, error) {
+	if raw == "" {
+		return &Cursor{SortBy: sortBy, SortOrder: sortOrder}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, InvalidCursorError("cursor is malformed")
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, InvalidCursorError("cursor is malformed")
+	}
+	if cursor.SortBy != sortBy || cursor.SortOrder != sortOrder {
+		return nil, InvalidCursorError("cursor was issued for a different sort")
+	}
+
+	return &cursor, nil
+}
+
+// InvalidCursorError is the validation error returned for a cursor the server cannot use.
+func InvalidCursorError(message string) error {
+	return NewAPIError(http.StatusBadRequest, "Validation failed", map[string]string{
+		"field":   "cursor",
+		"message": message,
+	})
+}
+
+// FormatCursorTime formats a time sort key so that TimeValue can read it back without loss.
+func FormatCursorTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// NewCursorPage trims a page fetched with limit+1 items back to limit and, when the extra
+// item proves there is more, issues the cursor of the last item kept as the next page.
+func NewCursorPage[T any](
+	items []T,
+	limit int,
+	current *Cursor,
+	keyOf func(item T) (value, id string),
+) ([]T, RespPagination) {
+	pagination := RespPagination{Limit: limit, Mode: PaginationModeCursor}
+	if len(items) <= limit {
+		return items, pagination
+	}
+
+	items = items[:limit]
+	value, id := keyOf(items[len(items)-1])
+	next := (&Cursor{SortBy: current.SortBy, SortOrder: current.SortOrder, Value: value, ID: id}).Encode()
+	pagination.Next = &next
+
+	return items, pagination
+}
@@ -0,0 +1,185 @@
+package httputil
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	idempotencyMaxKeyLength = 255
+	// DefaultIdempotencyTTL is how long a stored response is replayed before the key can be reused.
+	DefaultIdempotencyTTL = 24 * time.Hour
+	// IdempotencyLease is how long a key stays reserved for a request that is still being
+	// processed. A reservation left behind by a crashed instance is taken over once it lapses.
+	IdempotencyLease = 2 * time.Minute
+)
+
+// IdempotencyRecord is the stored outcome of the first request made with an idempotency key.
+// Keys belong to the caller that sent them, identified by Subject. StatusCode is zero while
+// that request is still being processed, which it is assumed to be until LockedUntil.
+type IdempotencyRecord struct {
+	Subject     string
+	Key         string
+	Scope       string
+	RequestHash string
+	StatusCode  int
+	Body        []byte
+	ExpiresAt   time.Time
+	LockedUntil time.Time
+}
+
+func (r *IdempotencyRecord) IsCompleted() bool {
+	return r.StatusCode != 0
+}
+
+// IdempotencyStore persists idempotency records. Every record is looked up by subject and key
+// together, so that callers cannot see or collide with each other's keys.
+type IdempotencyStore interface {
+	// Reserve stores record as in progress. When an unexpired record with the same subject and
+	// key already exists it is returned instead and nothing is stored; expired records and
+	// reservations whose lease has lapsed are replaced.
+	Reserve(ctx context.Context, record *IdempotencyRecord) (existing *IdempotencyRecord, err error)
+	// Complete stores the response of a reserved key.
+	Complete(ctx context.Context, subject, key string, statusCode int, body []byte) error
+	// Release drops a reservation so that the request can be retried with the same key.
+	Release(ctx context.Context, subject, key string) error
+}
+
+// Idempotency makes a route safe to retry: the first request with a given Idempotency-Key
+// header runs normally and its response is stored, later requests with the same key and
+// payload get that response replayed. Reusing a key with another payload or on another route
+// is rejected with 422, and a retry that arrives while the first request is running gets 409.
+// Server errors and panics are not stored, so the client can retry them with the same key.
+// A reservation is only held for IdempotencyLease, so that a request lost with its instance
+// does not block the key until it expires.
+//
+// subject names the caller of a request; keys of different callers never meet, so it must run
+// after the caller has been authenticated.
+func Idempotency(store IdempotencyStore, ttl time.Duration, subject func(ctx *gin.Context) string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			ctx.Next()
+			return
+		}
+		if len(key) > idempotencyMaxKeyLength {
+			abortWithError(ctx, http.StatusBadRequest, "Validation failed", map[string]string{
+				"field":   IdempotencyKeyHeader,
+				"message": "idempotency key must not exceed 255 characters",
+			})
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			abortWithError(ctx, http.StatusBadRequest, "Invalid request body", err.Error())
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		record := &IdempotencyRecord{
+			Subject:     subject(ctx),
+			Key:         key,
+			Scope:       ctx.Request.Method + " " + ctx.FullPath(),
+			RequestHash: hashRequest(ctx.Request.URL.Path, body),
+			ExpiresAt:   time.Now().Add(ttl),
+			LockedUntil: time.Now().Add(IdempotencyLease),
+		}
+
+		existing, err := store.Reserve(ctx.Request.Context(), record)
+		if err != nil {
+			abortWithError(ctx, http.StatusInternalServerError, "Internal server error", err.Error())
+			return
+		}
+		if existing != nil {
+			replayIdempotentResponse(ctx, record, existing)
+			return
+		}
+
+		// The request context may be cancelled by the time the handler returns; the outcome must
+		// still be recorded
+		storeCtx := context.WithoutCancel(ctx.Request.Context())
+		defer func() {
+			if r := recover(); r != nil {
+				releaseIdempotencyKey(storeCtx, store, record.Subject, key)
+				panic(r)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		if status >= http.StatusInternalServerError {
+			releaseIdempotencyKey(storeCtx, store, record.Subject, key)
+			return
+		}
+		if err := store.Complete(storeCtx, record.Subject, key, status, recorder.body.Bytes()); err != nil {
+			log.Printf("Failed to store response for idempotency key %q: %v", key, err)
+		}
+	}
+}
+
+func releaseIdempotencyKey(ctx context.Context, store IdempotencyStore, subject, key string) {
+	if err := store.Release(ctx, subject, key); err != nil {
+		log.Printf("Failed to release idempotency key %q: %v", key, err)
+	}
+}
+
+func replayIdempotentResponse(ctx *gin.Context, record, existing *IdempotencyRecord) {
+	if existing.Scope != record.Scope || existing.RequestHash != record.RequestHash {
+		abortWithError(ctx, http.StatusUnprocessableEntity, "Idempotency key reused",
+			"the idempotency key was already used with a different request")
+		return
+	}
+	if !existing.IsCompleted() {
+		abortWithError(ctx, http.StatusConflict, "Request in progress",
+			"a request with this idempotency key is still being processed")
+		return
+	}
+
+	ctx.Header(IdempotencyReplayedHeader, "true")
+	ctx.Data(existing.StatusCode, "application/json; charset=utf-8", existing.Body)
+	ctx.Abort()
+}
+
+func hashRequest(path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func abortWithError(ctx *gin.Context, code int, message string, err interface{}) {
+	ErrorResponse(ctx, code, message, err)
+	ctx.Abort()
+}
+
+// responseRecorder keeps a copy of the response body while it is written to the client.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
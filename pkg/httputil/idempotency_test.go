@@ -0,0 +1,79 @@
+package httputil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// memoryIdempotencyStore keeps records in a map and never expires them.
+type memoryIdempotencyStore struct {
+	records  map[string]*IdempotencyRecord
+	released []string
+}
+
+func (s *memoryIdempotencyStore) Reserve(ctx context.Context, record *IdempotencyRecord) (*IdempotencyRecord, error) {
+	if existing, ok := s.records[record.Subject+"/"+record.Key]; ok {
+		return existing, nil
+	}
+	s.records[record.Subject+"/"+record.Key] = record
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, subject, key string, statusCode int, body []byte) error {
+	record := s.records[subject+"/"+key]
+	record.StatusCode = statusCode
+	record.Body = body
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, subject, key string) error {
+	delete(s.records, subject+"/"+key)
+	s.released = append(s.released, key)
+	return nil
+}
+
+func TestIdempotencyReleasesKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		handler      gin.HandlerFunc
+		wantStatus   int
+		wantReleased bool
+	}{
+		{"stored response", func(ctx *gin.Context) { ctx.JSON(http.StatusCreated, gin.H{"id": 1}) }, http.StatusCreated, false},
+		{"server error", func(ctx *gin.Context) { ctx.JSON(http.StatusServiceUnavailable, gin.H{}) }, http.StatusServiceUnavailable, true},
+		{"panic", func(ctx *gin.Context) { panic("handler crashed") }, http.StatusInternalServerError, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memoryIdempotencyStore{records: map[string]*IdempotencyRecord{}}
+			r := gin.New()
+			r.Use(gin.CustomRecovery(func(ctx *gin.Context, err any) {
+				ctx.AbortWithStatus(http.StatusInternalServerError)
+			}))
+			r.POST("/starters", Idempotency(store, DefaultIdempotencyTTL, func(ctx *gin.Context) string { return "thanhnt" }), tt.handler)
+
+			req := httptest.NewRequest(http.MethodPost, "/starters", strings.NewReader(`{"domain":"thanhnt"}`))
+			req.Header.Set(IdempotencyKeyHeader, "create-1")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d; want %d", w.Code, tt.wantStatus)
+			}
+			if released := len(store.released) == 1; released != tt.wantReleased {
+				t.Errorf("released = %v; want %v", released, tt.wantReleased)
+			}
+			if _, kept := store.records["thanhnt/create-1"]; kept == tt.wantReleased {
+				t.Errorf("key kept = %v; want %v", kept, !tt.wantReleased)
+			}
+		})
+	}
+}
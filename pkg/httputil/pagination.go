@@ -5,6 +5,9 @@ import "github.com/gin-gonic/gin"
 type ReqPagination struct {
 	Page  *int `json:"page" form:"page"`
 	Limit *int `json:"limit" form:"limit"`
+
+	// Cursor switches the listing to keyset pagination; Page is ignored when it is set.
+	Cursor *Cursor `json:"-" form:"-"`
 }
 
 const (
@@ -37,11 +40,18 @@ func (p *ReqPagination) GetOffset() int {
 	return (p.GetPage() - 1) * p.GetLimit()
 }
 
+func (p *ReqPagination) IsCursorMode() bool {
+	return p.Cursor != nil
+}
+
 type RespPagination struct {
 	Limit      int     `json:"limit"`
 	TotalItems int64   `json:"total_items,omitempty"`
 	Prev       *string `json:"prev"`
 	Next       *string `json:"next"`
+
+	// Mode tells CursorPagination whether Prev and Next are page numbers or cursors.
+	Mode PaginationMode `json:"-"`
 }
 
 type PaginatedResult[T any] struct {
@@ -50,13 +60,18 @@ type PaginatedResult[T any] struct {
 }
 
 func CursorPagination(ctx *gin.Context, pagination RespPagination) RespPagination {
+	toURL := AbsoluteURLWithPage
+	if pagination.Mode == PaginationModeCursor {
+		toURL = AbsoluteURLWithCursor
+	}
+
 	updated := pagination
 	if pagination.Prev != nil {
-		prevURL := AbsoluteURLWithPage(ctx, *pagination.Prev)
+		prevURL := toURL(ctx, *pagination.Prev)
 		updated.Prev = &prevURL
 	}
 	if pagination.Next != nil {
-		nextURL := AbsoluteURLWithPage(ctx, *pagination.Next)
+		nextURL := toURL(ctx, *pagination.Next)
 		updated.Next = &nextURL
 	}
 	return updated
@@ -69,3 +84,12 @@ func AbsoluteURLWithPage(ctx *gin.Context, page string) string {
 	resolver := NewRequestURLResolver()
 	return resolver.AbsoluteURL(ctx, ctx.Request.URL.Path, query)
 }
+
+func AbsoluteURLWithCursor(ctx *gin.Context, cursor string) string {
+	query := ctx.Request.URL.Query()
+	query.Set("cursor", cursor)
+	query.Del("page")
+
+	resolver := NewRequestURLResolver()
+	return resolver.AbsoluteURL(ctx, ctx.Request.URL.Path, query)
+}
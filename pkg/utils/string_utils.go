@@ -2,6 +2,11 @@ package utils
 
 import (
 	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
 func ParseString(input string, sep string) []string {
@@ -17,3 +22,38 @@ func ParseString(input string, sep string) []string {
 	}
 	return result
 }
+
+// NormalizeSearchText folds text for accent-insensitive matching: it lowercases,
+// strips diacritics ("Nguyễn" -> "nguyen", "Đặng" -> "dang") and collapses whitespace.
+func NormalizeSearchText(input string) string {
+	// đ/Đ is a distinct letter, not d + combining mark, so NFD leaves it untouched
+	replaced := strings.NewReplacer("đ", "d", "Đ", "D").Replace(input)
+
+	folder := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(folder, replaced)
+	if err != nil {
+		folded = replaced
+	}
+
+	return strings.Join(strings.Fields(strings.ToLower(folded)), " ")
+}
+
+// NormalizePhone reduces a phone number to its digits so that "0912 345 678",
+// "0912-345-678" and "+84 912 345 678" compare equal. The Vietnamese country code
+// is rewritten to the national trunk prefix 0.
+func NormalizePhone(input string) string {
+	trimmed := strings.TrimSpace(input)
+
+	var b strings.Builder
+	for _, r := range trimmed {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+
+	if strings.HasPrefix(trimmed, "+84") {
+		digits = "0" + strings.TrimPrefix(digits, "84")
+	}
+	return digits
+}
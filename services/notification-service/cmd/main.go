@@ -8,6 +8,8 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	// Recipients' timezones must load where the image has no zoneinfo
+	_ "time/tzdata"
 
 	"github.com/go-errors/errors"
 	"github.com/kiin21/go-rest/services/notification-service/docs"
@@ -18,8 +20,12 @@ import (
 // @version 1.0
 // @description REST APIs for managing notifications.
 // @BasePath /api/v1
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT bearer token, sent as "Bearer <token>"
 func main() {
-	router, port, consumer := initialize.Run()
+	router, port, consumer, publisher, deliveryConsumer, hub, deliveries, webhooks, digests, archiver := initialize.Run()
 
 	docs.SwaggerInfo.Schemes = []string{"http", "https"}
 
@@ -28,6 +34,10 @@ func main() {
 		Addr:    ":" + port,
 		Handler: router,
 	}
+	// Open streams would otherwise keep Shutdown waiting until its deadline
+	srv.RegisterOnShutdown(func() {
+		_ = hub.Close()
+	})
 
 	// Start server in goroutine
 	go func() {
@@ -58,5 +68,21 @@ func main() {
 		log.Println("Kafka consumer stopped")
 	}
 
+	if err := publisher.Close(); err != nil {
+		log.Printf("Error closing delivery publisher: %v", err)
+	}
+	if deliveryConsumer != nil {
+		deliveryConsumer.Stop()
+	}
+
+	log.Println("Stopping notification delivery...")
+	deliveries.Stop()
+	digests.Stop()
+
+	log.Println("Stopping webhook delivery...")
+	webhooks.Stop()
+
+	archiver.Stop()
+
 	log.Println("Server exited")
 }
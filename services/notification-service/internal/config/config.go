@@ -3,7 +3,11 @@ package config
 import (
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/kiin21/go-rest/pkg/auth"
+	"github.com/kiin21/go-rest/pkg/httpmw"
+	"github.com/kiin21/go-rest/pkg/utils"
 	"github.com/spf13/viper"
 )
 
@@ -15,10 +19,79 @@ type Config struct {
 	KafkaBrokers            string `mapstructure:"KAFKA_BROKERS"`
 	KafkaTopicNotifications string `mapstructure:"KAFKA_TOPIC_NOTIFICATIONS"`
 	KafkaConsumerGroup      string `mapstructure:"KAFKA_CONSUMER_GROUP"`
+	// Stored notifications are announced here so that every instance can push them to its streams
+	KafkaTopicDelivery string `mapstructure:"KAFKA_TOPIC_NOTIFICATION_DELIVERY"`
+	// Starter change events of the starter service, forwarded to webhooks; not read without it
+	KafkaTopicSyncEvents string `mapstructure:"KAFKA_TOPIC_SYNC_EVENTS"`
 
 	MongoURI        string `mapstructure:"MONGODB_URI"`
 	MongoDatabase   string `mapstructure:"MONGODB_DATABASE"`
 	MongoCollection string `mapstructure:"MONGODB_COLLECTION"`
+
+	// Read notifications are deleted once older than NOTIFICATION_READ_RETENTION, and every
+	// notification older than NOTIFICATION_ARCHIVE_AFTER is moved to the archive collection,
+	// MONGODB_COLLECTION with an "_archive" suffix by default. Zero turns either off
+	NotificationReadRetention   time.Duration `mapstructure:"NOTIFICATION_READ_RETENTION"`
+	NotificationArchiveAfter    time.Duration `mapstructure:"NOTIFICATION_ARCHIVE_AFTER"`
+	NotificationArchiveInterval time.Duration `mapstructure:"NOTIFICATION_ARCHIVE_INTERVAL"`
+	MongoArchiveCollection      string        `mapstructure:"MONGODB_ARCHIVE_COLLECTION"`
+
+	// Notifications of one type to one recipient about one subject are stored once within
+	// NOTIFICATION_DEDUPE_WINDOW; zero stores every one
+	NotificationDedupeWindow time.Duration `mapstructure:"NOTIFICATION_DEDUPE_WINDOW"`
+
+	// Locale notification messages are stored and emailed in, and read in unless the reader
+	// asks for another: en (default) or vi
+	NotificationDefaultLocale string `mapstructure:"NOTIFICATION_DEFAULT_LOCALE"`
+
+	// Email delivery, disabled without SMTP_HOST. Addresses are looked up in the starter
+	// service when its URL is set, and derived from the starter domain otherwise
+	SMTPHost                 string        `mapstructure:"SMTP_HOST"`
+	SMTPPort                 int           `mapstructure:"SMTP_PORT"`
+	SMTPUsername             string        `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword             string        `mapstructure:"SMTP_PASSWORD"`
+	SMTPFrom                 string        `mapstructure:"SMTP_FROM"`
+	EmailStarterServiceURL   string        `mapstructure:"EMAIL_STARTER_SERVICE_URL"`
+	EmailStarterServiceToken string        `mapstructure:"EMAIL_STARTER_SERVICE_TOKEN"`
+	EmailMailDomain          string        `mapstructure:"EMAIL_MAIL_DOMAIN"`
+	DeliveryMaxAttempts      int           `mapstructure:"DELIVERY_MAX_ATTEMPTS"`
+	DeliveryRetryBackoff     time.Duration `mapstructure:"DELIVERY_RETRY_BACKOFF"`
+
+	// Webhook deliveries that fail this many times become dead letters
+	WebhookMaxAttempts  int           `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookRetryBackoff time.Duration `mapstructure:"WEBHOOK_RETRY_BACKOFF"`
+
+	// Bearer tokens: HMAC secret or JWKS file, plus optional issuer and audience checks
+	AuthJWTSecret   string `mapstructure:"AUTH_JWT_SECRET"`
+	AuthJWKSFile    string `mapstructure:"AUTH_JWKS_FILE"`
+	AuthJWTIssuer   string `mapstructure:"AUTH_JWT_ISSUER"`
+	AuthJWTAudience string `mapstructure:"AUTH_JWT_AUDIENCE"`
+
+	// Browser origins allowed by CORS, comma separated
+	CORSAllowedOrigins   string        `mapstructure:"CORS_ALLOWED_ORIGINS"`
+	CORSAllowCredentials bool          `mapstructure:"CORS_ALLOW_CREDENTIALS"`
+	CORSMaxAge           time.Duration `mapstructure:"CORS_MAX_AGE"`
+}
+
+// AuthConfig returns the bearer token verification settings.
+func (c Config) AuthConfig() auth.Config {
+	return auth.Config{
+		HMACSecret: c.AuthJWTSecret,
+		JWKSFile:   c.AuthJWKSFile,
+		Issuer:     c.AuthJWTIssuer,
+		Audience:   c.AuthJWTAudience,
+	}
+}
+
+// CORSConfig returns the cross-origin policy; no origin is allowed unless configured.
+func (c Config) CORSConfig() httpmw.CORSConfig {
+	cors := httpmw.DefaultCORSConfig()
+	cors.AllowedOrigins = utils.ParseString(c.CORSAllowedOrigins, ",")
+	cors.AllowCredentials = c.CORSAllowCredentials
+	if c.CORSMaxAge > 0 {
+		cors.MaxAge = c.CORSMaxAge
+	}
+	return cors
 }
 
 func LoadConfig() (config Config, err error) {
@@ -0,0 +1,92 @@
+package initialize
+
+import (
+	"context"
+	"log"
+
+	"github.com/kiin21/go-rest/services/notification-service/internal/config"
+	notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+	domainDelivery "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/delivery"
+	domainRepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+	infraDelivery "github.com/kiin21/go-rest/services/notification-service/internal/notification/infrastructure/delivery"
+	notinfra "github.com/kiin21/go-rest/services/notification-service/internal/notification/infrastructure/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// InitDeliveryService sets up the channels notifications are sent out through, and the
+// scheduler of the digests they support. Without any channel, the service only stores and
+// streams notifications.
+func InitDeliveryService(
+	cfg config.Config,
+	repo domainRepo.NotificationRepository,
+	collection *mongo.Collection,
+	preferences domainRepo.PreferenceRepository,
+) (*notiapp.DeliveryService, *notiapp.DigestScheduler) {
+	var channels []domainDelivery.Channel
+	if email := initEmailChannel(cfg); email != nil {
+		channels = append(channels, email)
+	}
+
+	for _, channel := range channels {
+		if err := notinfra.EnsureDeliveryIndexes(context.Background(), collection, channel.Name()); err != nil {
+			log.Fatalf("Could not create %s delivery indexes: %v", channel.Name(), err)
+		}
+	}
+
+	policy := notiapp.DefaultRetryPolicy()
+	if cfg.DeliveryMaxAttempts > 0 {
+		policy.MaxAttempts = cfg.DeliveryMaxAttempts
+	}
+	if cfg.DeliveryRetryBackoff > 0 {
+		policy.Backoff = cfg.DeliveryRetryBackoff
+	}
+
+	var digestSender domainDelivery.DigestSender
+	for _, channel := range channels {
+		if sender, ok := channel.(domainDelivery.DigestSender); ok {
+			digestSender = sender
+			if err := notinfra.EnsureDigestIndexes(context.Background(), collection, channel.Name()); err != nil {
+				log.Fatalf("Could not create %s digest indexes: %v", channel.Name(), err)
+			}
+		}
+	}
+
+	return notiapp.NewDeliveryService(repo, channels, policy),
+		notiapp.NewDigestScheduler(repo, preferences, digestSender, policy)
+}
+
+func initEmailChannel(cfg config.Config) domainDelivery.Channel {
+	if cfg.SMTPHost == "" {
+		log.Printf("Warning: SMTP not configured, email delivery disabled")
+		return nil
+	}
+
+	var resolver domainDelivery.AddressResolver
+	switch {
+	case cfg.EmailStarterServiceURL != "":
+		resolver = infraDelivery.NewStarterServiceResolver(cfg.EmailStarterServiceURL, cfg.EmailStarterServiceToken)
+	case cfg.EmailMailDomain != "":
+		resolver = infraDelivery.NewMailDomainResolver(cfg.EmailMailDomain)
+	default:
+		log.Printf("Warning: neither EMAIL_STARTER_SERVICE_URL nor EMAIL_MAIL_DOMAIN set, email delivery disabled")
+		return nil
+	}
+
+	port := cfg.SMTPPort
+	if port == 0 {
+		port = 25
+	}
+	channel, err := infraDelivery.NewSMTPChannel(infraDelivery.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     port,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.SMTPFrom,
+	}, resolver)
+	if err != nil {
+		log.Fatalf("Could not initialize email delivery: %v", err)
+	}
+
+	log.Printf("Email delivery enabled through %s:%d", cfg.SMTPHost, port)
+	return channel
+}
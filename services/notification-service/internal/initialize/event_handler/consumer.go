@@ -6,13 +6,29 @@ import (
 	"github.com/IBM/sarama"
 	"github.com/kiin21/go-rest/pkg/utils"
 	"github.com/kiin21/go-rest/services/notification-service/internal/config"
+	notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
 	domainMq "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/messaging"
 	domainRepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
 	infraMq "github.com/kiin21/go-rest/services/notification-service/internal/notification/infrastructure/messaging"
 )
 
-func InitEventHandler(repo domainRepo.NotificationRepository) *EventHandler {
-	return NewEventHandler(repo)
+func InitEventHandler(
+	cfg config.Config,
+	repo domainRepo.NotificationRepository,
+	dedupe domainRepo.NotificationDedupeRepository,
+	publisher domainMq.NotificationPublisher,
+	deliveries *notiapp.DeliveryService,
+	webhooks *notiapp.WebhookService,
+	templates *notiapp.TemplateService,
+	preferences *notiapp.PreferenceService,
+	stats *notiapp.IngestStats,
+) *EventHandler {
+	if cfg.NotificationDedupeWindow > 0 {
+		log.Printf("Notifications saying the same within %s are stored once", cfg.NotificationDedupeWindow)
+	}
+	return NewEventHandler(
+		repo, dedupe, publisher, deliveries, webhooks, templates, preferences, stats, cfg.NotificationDedupeWindow,
+	)
 }
 
 func InitGroupConsumer(cfg config.Config, handler *EventHandler) domainMq.NotificationConsumer {
@@ -20,6 +36,8 @@ func InitGroupConsumer(cfg config.Config, handler *EventHandler) domainMq.Notifi
 	saramaConfig := sarama.NewConfig()
 	saramaConfig.Consumer.Group.Rebalance.Strategy = sarama.NewBalanceStrategyRoundRobin()
 	saramaConfig.Consumer.Offsets.Initial = sarama.OffsetNewest
+	// The handler commits each offset once the event is written
+	saramaConfig.Consumer.Offsets.AutoCommit.Enable = false
 	saramaConfig.Version = sarama.V2_8_0_0
 
 	// 2. Create a consumer group
@@ -34,6 +52,10 @@ func InitGroupConsumer(cfg config.Config, handler *EventHandler) domainMq.Notifi
 
 	// 3. Create a NotificationConsumer
 	topics := []string{cfg.KafkaTopicNotifications} // Add more topics if needed
+	if cfg.KafkaTopicSyncEvents != "" {
+		// Starter changes, read only to be forwarded to webhooks
+		topics = append(topics, cfg.KafkaTopicSyncEvents)
+	}
 	consumer := infraMq.NewKafkaNotificationConsumer(consumerGroup, topics, handler)
 
 	// 4. Start consuming
@@ -0,0 +1,66 @@
+package event_handler
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/kiin21/go-rest/pkg/utils"
+	"github.com/kiin21/go-rest/services/notification-service/internal/config"
+	notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+	domainMq "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/messaging"
+	infraMq "github.com/kiin21/go-rest/services/notification-service/internal/notification/infrastructure/messaging"
+)
+
+// InitDelivery connects the hub to the other instances of the service: stored notifications
+// are published to the delivery topic, which every instance consumes in full. Without a
+// delivery topic notifications are only pushed to streams on this instance.
+func InitDelivery(cfg config.Config, hub *notiapp.NotificationHub) (domainMq.NotificationPublisher, domainMq.NotificationConsumer) {
+	brokers := utils.ParseString(cfg.KafkaBrokers, ",")
+	if len(brokers) == 0 || cfg.KafkaTopicDelivery == "" {
+		log.Printf("Warning: Kafka delivery topic not configured, notifications are only streamed from the instance that stores them")
+		return hub, nil
+	}
+
+	producerConfig := sarama.NewConfig()
+	producerConfig.Producer.Return.Successes = true
+	producerConfig.Producer.Return.Errors = true
+	producerConfig.Producer.RequiredAcks = sarama.WaitForLocal
+	producerConfig.Producer.Retry.Max = 3
+	producerConfig.Producer.Retry.Backoff = 100 * time.Millisecond
+	producerConfig.Version = sarama.V2_8_0_0
+	producerConfig.ClientID = "notification-service-delivery-producer"
+
+	producer, err := sarama.NewSyncProducer(brokers, producerConfig)
+	if err != nil {
+		log.Fatalf("Error creating delivery producer: %v", err)
+	}
+
+	// Every instance needs every notification, so each consumes in a group of its own. Offsets
+	// are never committed: a restarted instance has no streams to catch up, its clients replay
+	// from MongoDB when they reconnect.
+	consumerConfig := sarama.NewConfig()
+	consumerConfig.Consumer.Offsets.Initial = sarama.OffsetNewest
+	consumerConfig.Consumer.Offsets.AutoCommit.Enable = false
+	consumerConfig.Version = sarama.V2_8_0_0
+
+	consumerGroup, err := sarama.NewConsumerGroup(brokers, deliveryGroupID(cfg.KafkaConsumerGroup), consumerConfig)
+	if err != nil {
+		log.Fatalf("Error creating delivery consumer group: %v", err)
+	}
+
+	consumer := infraMq.NewKafkaNotificationConsumer(consumerGroup, []string{cfg.KafkaTopicDelivery}, NewDeliveryHandler(hub))
+	consumer.Start()
+
+	return infraMq.NewKafkaNotificationPublisher(producer, cfg.KafkaTopicDelivery), consumer
+}
+
+func deliveryGroupID(group string) string {
+	instance, err := os.Hostname()
+	if err != nil || instance == "" {
+		instance = strconv.Itoa(os.Getpid())
+	}
+	return group + "-delivery-" + instance
+}
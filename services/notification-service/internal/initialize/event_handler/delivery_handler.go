@@ -0,0 +1,34 @@
+package event_handler
+
+import (
+	"log"
+
+	"github.com/IBM/sarama"
+	notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+	infraMq "github.com/kiin21/go-rest/services/notification-service/internal/notification/infrastructure/messaging"
+)
+
+// DeliveryHandler pushes the notifications announced on the delivery topic to the streams
+// connected to this instance.
+type DeliveryHandler struct {
+	hub *notiapp.NotificationHub
+}
+
+func NewDeliveryHandler(hub *notiapp.NotificationHub) *DeliveryHandler {
+	return &DeliveryHandler{hub: hub}
+}
+
+func (h *DeliveryHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (h *DeliveryHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+func (h *DeliveryHandler) ConsumeClaim(_ sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		notification, err := infraMq.DecodeDelivery(msg.Value)
+		if err != nil {
+			log.Printf("Failed to decode delivery message at offset %d: %v", msg.Offset, err)
+			continue
+		}
+		h.hub.Deliver(notification)
+	}
+	return nil
+}
@@ -3,20 +3,61 @@ package event_handler
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/kiin21/go-rest/pkg/events"
+	notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+	domainMq "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/messaging"
 	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
 	domainRepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
 )
 
-// EventHandler handle events from Kafka
+// Failed writes are retried, waiting retryBackoff at first and twice as long after each
+// further failure, up to maxRetryBackoff
+const (
+	retryBackoff    = time.Second
+	maxRetryBackoff = 30 * time.Second
+)
+
+// EventHandler handle events from Kafka. An event's offset is committed only once what it
+// asks for is written, so events are read again rather than lost when writes fail or the
+// instance stops; writes are idempotent, so reading an event again does no harm.
 type EventHandler struct {
-	repo domainRepo.NotificationRepository
+	repo        domainRepo.NotificationRepository
+	dedupe      domainRepo.NotificationDedupeRepository
+	publisher   domainMq.NotificationPublisher
+	deliveries  *notiapp.DeliveryService
+	webhooks    *notiapp.WebhookService
+	templates   *notiapp.TemplateService
+	preferences *notiapp.PreferenceService
+	stats       *notiapp.IngestStats
+	// dedupeWindow is how long a notification keeps others saying the same out; zero keeps none out
+	dedupeWindow time.Duration
 }
 
-func NewEventHandler(repo domainRepo.NotificationRepository) *EventHandler {
-	return &EventHandler{repo: repo}
+func NewEventHandler(
+	repo domainRepo.NotificationRepository,
+	dedupe domainRepo.NotificationDedupeRepository,
+	publisher domainMq.NotificationPublisher,
+	deliveries *notiapp.DeliveryService,
+	webhooks *notiapp.WebhookService,
+	templates *notiapp.TemplateService,
+	preferences *notiapp.PreferenceService,
+	stats *notiapp.IngestStats,
+	dedupeWindow time.Duration,
+) *EventHandler {
+	return &EventHandler{
+		repo:         repo,
+		dedupe:       dedupe,
+		publisher:    publisher,
+		deliveries:   deliveries,
+		webhooks:     webhooks,
+		templates:    templates,
+		preferences:  preferences,
+		stats:        stats,
+		dedupeWindow: dedupeWindow,
+	}
 }
 
 func (h *EventHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
@@ -29,57 +70,141 @@ func (h *EventHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim s
 			msg.Topic, msg.Partition, msg.Offset, string(msg.Value),
 		)
 
-		// Parse event
-		event, err := events.BytesToEvent(msg.Value)
-		if err != nil {
-			log.Printf("Failed to parse event: %v", err)
-			session.MarkMessage(msg, "")
-			continue
+		if !h.handleMessage(session.Context(), msg) {
+			// The session ended before the event was written; whoever takes the partition over
+			// reads it again from the last committed offset
+			return nil
 		}
 
-		log.Printf("Processing event: Type=%s, ID=%s", event.Type, event.ID)
+		session.MarkMessage(msg, "")
+		session.Commit()
+		h.stats.Processed()
+	}
+	return nil
+}
 
-		// Get context from session
-		ctx := session.Context()
+// handleMessage writes what the message asks for and reports whether it is done with it:
+// written, or skipped because it cannot be read. It returns false when ctx ends first.
+func (h *EventHandler) handleMessage(ctx context.Context, msg *sarama.ConsumerMessage) bool {
+	// Parse event
+	event, err := events.BytesToEvent(msg.Value)
+	if err != nil {
+		log.Printf("Failed to parse event: %v", err)
+		h.stats.Malformed()
+		return true
+	}
 
-		switch event.Type {
-		case events.EventTypeNotificationLeaderAssignment:
-			if err := h.handleLeaderAssignment(ctx, event); err != nil {
-				log.Printf("Failed to handle leader assignment: %v", err)
-			}
-		default:
-			log.Printf("Unknown event type: %s", event.Type)
+	log.Printf("Processing event: Type=%s, ID=%s", event.Type, event.ID)
+
+	// Recipients may turn webhooks off for the notifications they get
+	forward := true
+	switch event.Type {
+	case events.EventTypeNotificationLeaderAssignment, events.EventTypeNotificationStarterCreated,
+		events.EventTypeNotificationLineManagerChanged, events.EventTypeNotificationDepartmentTransfer,
+		events.EventTypeNotificationStarterDeleted:
+		notification, err := notificationFromEvent(event)
+		if err != nil {
+			log.Printf("Failed to read %s event: %v", event.Type, err)
+			h.stats.Malformed()
+			return true
 		}
+		stored := h.retry(ctx, "store notification "+notification.ID, func() (err error) {
+			forward, err = h.handleNotification(ctx, notification)
+			return err
+		})
+		if !stored {
+			return false
+		}
+	case events.EventTypeStarterInsert, events.EventTypeStarterUpdate,
+		events.EventTypeStarterIndex, events.EventTypeStarterDelete:
+		// Starter changes are only forwarded to webhooks
+	default:
+		log.Printf("Unknown event type: %s", event.Type)
+	}
 
-		session.MarkMessage(msg, "")
+	// Subscribers get the envelope exactly as it was published
+	if !forward {
+		return true
 	}
-	return nil
+	return h.retry(ctx, "queue webhook deliveries of event "+event.ID.String(), func() error {
+		return h.webhooks.Dispatch(ctx, event, msg.Value)
+	})
 }
-func (h *EventHandler) handleLeaderAssignment(ctx context.Context, event *events.Event) error {
-	// BytesToEvent event payload
-	var payload events.LeaderAssignmentEventPayload
 
-	if err := event.UnmarshalPayload(&payload); err != nil {
-		log.Printf("Failed to unmarshal leader assignment event: %v", err)
-		return err
+// retry runs write until it succeeds, backing off after each failure, and reports whether it
+// succeeded before ctx ended.
+func (h *EventHandler) retry(ctx context.Context, what string, write func() error) bool {
+	backoff := retryBackoff
+	for {
+		err := write()
+		if err == nil {
+			return true
+		}
+		log.Printf("Failed to %s, retrying in %s: %v", what, backoff, err)
+		h.stats.Retried()
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxRetryBackoff)
+	}
+}
+
+// handleNotification stores the notification an event addresses to its recipient, unless
+// it is stored already or another notification has just said the same, and reports whether
+// the recipient wants the event forwarded to webhooks.
+func (h *EventHandler) handleNotification(ctx context.Context, notification *model.Notification) (bool, error) {
+	if notification.Data != nil {
+		notification.Message = h.templates.Render(ctx, notification, "")
+	}
+
+	preferences := h.preferences.For(ctx, notification.ToStarter)
+	settings := preferences.Settings(notification.Type)
+
+	if h.dedupeWindow > 0 {
+		first, err := h.dedupe.Claim(ctx, notification.DedupeKey(), notification.ID, notification.Timestamp, h.dedupeWindow)
+		if err != nil {
+			return settings.Webhook, err
+		}
+		if !first {
+			log.Printf("%s notification %s for %s dropped as a duplicate", notification.Type, notification.ID, notification.ToStarter)
+			h.stats.Duplicate()
+			return settings.Webhook, nil
+		}
 	}
 
-	// Create model
-	notification := &model.Notification{
-		ID:          event.ID.String(),
-		FromStarter: payload.FromStarter,
-		ToStarter:   payload.ToStarter,
-		Message:     payload.Message,
-		Type:        event.Type,
-		Timestamp:   event.Timestamp,
+	if !settings.InApp {
+		// Kept for the record, but neither unread nor pushed to the recipient's streams
+		readAt := notification.Timestamp
+		notification.ReadAt = &readAt
 	}
 
+	// Deliveries are stored with the notification, so they are sent even if this instance stops
+	h.deliveries.Schedule(notification, preferences)
+
 	// Save to db
-	if err := h.repo.Create(ctx, notification); err != nil {
-		log.Printf("Failed to create notification: %v", err)
-		return err
+	created, err := h.repo.Create(ctx, notification)
+	if err != nil {
+		return settings.Webhook, err
+	}
+	if !created {
+		log.Printf("%s notification %s was stored before", notification.Type, notification.ID)
+		h.stats.Redelivered()
+		return settings.Webhook, nil
 	}
 
-	log.Printf("Leader assignment notification created for user %s", notification.ID)
-	return nil
+	log.Printf("%s notification %s created for %s", notification.Type, notification.ID, notification.ToStarter)
+	h.stats.Stored()
+
+	// The notification is stored, so a failed push only delays it until the recipient
+	// reconnects or polls
+	if settings.InApp {
+		if err := h.publisher.Publish(ctx, notification); err != nil {
+			log.Printf("Failed to publish notification %s for delivery: %v", notification.ID, err)
+		}
+	}
+	h.deliveries.Wake()
+	return settings.Webhook, nil
 }
@@ -0,0 +1,314 @@
+package event_handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/kiin21/go-rest/pkg/events"
+	notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository/mocks"
+)
+
+// testPublisher publishes nothing.
+type testPublisher struct{}
+
+func (testPublisher) Publish(context.Context, *model.Notification) error { return nil }
+func (testPublisher) Close() error                                       { return nil }
+
+// testDedupe keeps keys in memory the way NotificationDedupeRepository describes claims.
+func testDedupe() *mocks.MockNotificationDedupeRepository {
+	type holder struct {
+		id      string
+		expires time.Time
+	}
+	var mu sync.Mutex
+	keys := map[string]holder{}
+
+	return &mocks.MockNotificationDedupeRepository{
+		ClaimFunc: func(ctx context.Context, key, id string, at time.Time, window time.Duration) (bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if held, ok := keys[key]; ok && held.id != id && held.expires.After(at) {
+				return false, nil
+			}
+			keys[key] = holder{id: id, expires: at.Add(window)}
+			return true, nil
+		},
+	}
+}
+
+// newTestHandler builds a handler without delivery channels or webhook subscriptions.
+func newTestHandler(
+	repo *mocks.MockNotificationRepository,
+	dedupe *mocks.MockNotificationDedupeRepository,
+	window time.Duration,
+) (*EventHandler, *notiapp.IngestStats) {
+	stats := notiapp.NewIngestStats()
+	handler := NewEventHandler(
+		repo,
+		dedupe,
+		testPublisher{},
+		notiapp.NewDeliveryService(repo, nil, notiapp.DefaultRetryPolicy()),
+		notiapp.NewWebhookService(&mocks.MockWebhookRepository{}, nil, notiapp.DefaultRetryPolicy()),
+		notiapp.NewTemplateService(&mocks.MockTemplateRepository{}, model.LocaleEnglish),
+		notiapp.NewPreferenceService(&mocks.MockPreferenceRepository{}),
+		stats,
+		window,
+	)
+	return handler, stats
+}
+
+func TestHandleNotificationDedupe(t *testing.T) {
+	start := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	created := func(id, recipient, starter string, after time.Duration) *model.Notification {
+		return &model.Notification{
+			ID:        id,
+			Type:      events.EventTypeNotificationStarterCreated,
+			ToStarter: recipient,
+			Timestamp: start.Add(after),
+			Data:      map[string]string{"starter_domain": starter, "starter_name": "New Starter"},
+		}
+	}
+
+	type step struct {
+		notification *model.Notification
+		want         string
+	}
+	tests := []struct {
+		name   string
+		window time.Duration
+		steps  []step
+	}{
+		{
+			name:   "same message from another producer",
+			window: 10 * time.Minute,
+			steps: []step{
+				{created("a", "manager", "new.starter", 0), "stored"},
+				{created("b", "manager", "new.starter", time.Minute), "duplicate"},
+			},
+		},
+		{
+			name:   "redelivered event is not a duplicate",
+			window: 10 * time.Minute,
+			steps: []step{
+				{created("a", "manager", "new.starter", 0), "stored"},
+				{created("a", "manager", "new.starter", 0), "redelivered"},
+			},
+		},
+		{
+			name:   "after the window",
+			window: 10 * time.Minute,
+			steps: []step{
+				{created("a", "manager", "new.starter", 0), "stored"},
+				{created("b", "manager", "new.starter", 10*time.Minute), "stored"},
+				{created("c", "manager", "new.starter", 15*time.Minute), "duplicate"},
+			},
+		},
+		{
+			name:   "other recipient or starter",
+			window: 10 * time.Minute,
+			steps: []step{
+				{created("a", "manager", "new.starter", 0), "stored"},
+				{created("b", "leader", "new.starter", 0), "stored"},
+				{created("c", "manager", "other.starter", 0), "stored"},
+			},
+		},
+		{
+			name: "without a window",
+			steps: []step{
+				{created("a", "manager", "new.starter", 0), "stored"},
+				{created("b", "manager", "new.starter", 0), "stored"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := map[string]bool{}
+			repo := &mocks.MockNotificationRepository{
+				CreateFunc: func(ctx context.Context, notification *model.Notification) (bool, error) {
+					if stored[notification.ID] {
+						return false, nil
+					}
+					stored[notification.ID] = true
+					return true, nil
+				},
+			}
+			dedupe := testDedupe()
+			if tt.window == 0 {
+				dedupe.ClaimFunc = func(context.Context, string, string, time.Time, time.Duration) (bool, error) {
+					t.Error("claimed a key without a window")
+					return true, nil
+				}
+			}
+			handler, stats := newTestHandler(repo, dedupe, tt.window)
+
+			for i, step := range tt.steps {
+				before := stats.Snapshot()
+				if _, err := handler.handleNotification(context.Background(), step.notification); err != nil {
+					t.Fatalf("step %d: unexpected error: %v", i, err)
+				}
+				after := stats.Snapshot()
+
+				var got string
+				switch {
+				case after.Stored > before.Stored:
+					got = "stored"
+				case after.Duplicates > before.Duplicates:
+					got = "duplicate"
+				case after.Redelivered > before.Redelivered:
+					got = "redelivered"
+				}
+				if got != step.want {
+					t.Errorf("step %d: notification %s was %q; want %q", i, step.notification.ID, got, step.want)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleNotificationClaimError(t *testing.T) {
+	repo := &mocks.MockNotificationRepository{
+		CreateFunc: func(context.Context, *model.Notification) (bool, error) {
+			t.Error("stored a notification whose claim failed")
+			return true, nil
+		},
+	}
+	dedupe := &mocks.MockNotificationDedupeRepository{
+		ClaimFunc: func(context.Context, string, string, time.Time, time.Duration) (bool, error) {
+			return false, errors.New("connection refused")
+		},
+	}
+	handler, _ := newTestHandler(repo, dedupe, time.Minute)
+
+	notification := &model.Notification{ID: "a", Type: events.EventTypeNotificationStarterCreated, ToStarter: "manager"}
+	if _, err := handler.handleNotification(context.Background(), notification); err == nil {
+		t.Error("expected the claim error, so the write is retried")
+	}
+}
+
+// testSession records marked and committed offsets in the order they happen.
+type testSession struct {
+	sarama.ConsumerGroupSession
+	ctx context.Context
+	log *[]string
+}
+
+func (s *testSession) Context() context.Context { return s.ctx }
+
+func (s *testSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	*s.log = append(*s.log, fmt.Sprintf("mark %d", msg.Offset))
+}
+
+func (s *testSession) Commit() { *s.log = append(*s.log, "commit") }
+
+type testClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *testClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+// newClaim returns a claim holding the messages, from offset 0 on.
+func newClaim(t *testing.T, values ...[]byte) *testClaim {
+	t.Helper()
+	claim := &testClaim{messages: make(chan *sarama.ConsumerMessage, len(values))}
+	for offset, value := range values {
+		claim.messages <- &sarama.ConsumerMessage{Topic: "notifications", Offset: int64(offset), Value: value}
+	}
+	close(claim.messages)
+	return claim
+}
+
+func starterCreatedEvent(t *testing.T, recipient string) []byte {
+	t.Helper()
+	event, err := events.NewEvent(events.EventTypeNotificationStarterCreated, events.StarterCreatedEventPayload{
+		NotificationPayload: events.NotificationPayload{FromStarter: "system", ToStarter: recipient, Message: "New Starter has joined"},
+		StarterDomain:       "new.starter",
+		StarterName:         "New Starter",
+	})
+	if err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+	value, err := event.ToBytes()
+	if err != nil {
+		t.Fatalf("failed to encode event: %v", err)
+	}
+	return value
+}
+
+func TestConsumeClaimCommitsAfterWrite(t *testing.T) {
+	var log []string
+	repo := &mocks.MockNotificationRepository{
+		CreateFunc: func(ctx context.Context, notification *model.Notification) (bool, error) {
+			log = append(log, "create "+notification.ToStarter)
+			return true, nil
+		},
+	}
+	handler, stats := newTestHandler(repo, testDedupe(), 0)
+
+	claim := newClaim(t,
+		starterCreatedEvent(t, "first"),
+		[]byte("not an event"),
+		starterCreatedEvent(t, "second"),
+	)
+	if err := handler.ConsumeClaim(&testSession{ctx: context.Background(), log: &log}, claim); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"create first", "mark 0", "commit",
+		// Malformed events cannot be written; they are committed so they do not block the partition
+		"mark 1", "commit",
+		"create second", "mark 2", "commit",
+	}
+	if fmt.Sprint(log) != fmt.Sprint(want) {
+		t.Errorf("got %q; want %q", log, want)
+	}
+	if counts := stats.Snapshot(); counts.Processed != 3 || counts.Stored != 2 || counts.Malformed != 1 {
+		t.Errorf("unexpected counts %+v", counts)
+	}
+}
+
+func TestConsumeClaimLeavesUnwrittenEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var log []string
+	repo := &mocks.MockNotificationRepository{
+		CreateFunc: func(ctx context.Context, notification *model.Notification) (bool, error) {
+			log = append(log, "create "+notification.ToStarter)
+			if notification.ToStarter == "second" {
+				// The session ends while the write keeps failing
+				cancel()
+				return false, errors.New("connection refused")
+			}
+			return true, nil
+		},
+	}
+	handler, stats := newTestHandler(repo, testDedupe(), 0)
+
+	claim := newClaim(t,
+		starterCreatedEvent(t, "first"),
+		starterCreatedEvent(t, "second"),
+		starterCreatedEvent(t, "third"),
+	)
+	if err := handler.ConsumeClaim(&testSession{ctx: ctx, log: &log}, claim); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Offset 1 stays uncommitted, so whoever takes the partition over reads it again
+	want := []string{"create first", "mark 0", "commit", "create second"}
+	if fmt.Sprint(log) != fmt.Sprint(want) {
+		t.Errorf("got %q; want %q", log, want)
+	}
+	if counts := stats.Snapshot(); counts.Processed != 1 || counts.Retries != 1 {
+		t.Errorf("unexpected counts %+v", counts)
+	}
+}
@@ -0,0 +1,125 @@
+package event_handler
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kiin21/go-rest/pkg/events"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+// notificationFromEvent builds the notification a notification event addresses to its
+// recipient. Data holds the event's variables for the message templates; it is nil for
+// leader assignments from before the payload was structured, which only carry the English
+// message.
+func notificationFromEvent(event *events.Event) (*model.Notification, error) {
+	var (
+		recipient events.NotificationPayload
+		data      map[string]string
+	)
+
+	switch event.Type {
+	case events.EventTypeNotificationLeaderAssignment:
+		var payload events.LeaderAssignmentEventPayload
+		if err := event.UnmarshalPayload(&payload); err != nil {
+			return nil, err
+		}
+		recipient = events.NotificationPayload{
+			FromStarter: payload.FromStarter,
+			ToStarter:   payload.ToStarter,
+			Message:     payload.Message,
+		}
+		if payload.DepartmentName != "" {
+			data = map[string]string{
+				"department_id":   strconv.FormatInt(payload.DepartmentID, 10),
+				"department_name": payload.DepartmentName,
+				"previous_leader": payload.PreviousLeader,
+			}
+		}
+
+	case events.EventTypeNotificationStarterCreated:
+		var payload events.StarterCreatedEventPayload
+		if err := event.UnmarshalPayload(&payload); err != nil {
+			return nil, err
+		}
+		recipient = payload.NotificationPayload
+		data = map[string]string{
+			"starter_domain":  payload.StarterDomain,
+			"starter_name":    payload.StarterName,
+			"department_id":   formatID(payload.DepartmentID),
+			"department_name": payload.DepartmentName,
+			"line_manager":    payload.LineManager,
+		}
+
+	case events.EventTypeNotificationLineManagerChanged:
+		var payload events.LineManagerChangedEventPayload
+		if err := event.UnmarshalPayload(&payload); err != nil {
+			return nil, err
+		}
+		recipient = payload.NotificationPayload
+		data = map[string]string{
+			"starter_domain":        payload.StarterDomain,
+			"starter_name":          payload.StarterName,
+			"line_manager":          payload.LineManager,
+			"previous_line_manager": payload.PreviousLineManager,
+		}
+
+	case events.EventTypeNotificationDepartmentTransfer:
+		var payload events.DepartmentTransferEventPayload
+		if err := event.UnmarshalPayload(&payload); err != nil {
+			return nil, err
+		}
+		recipient = payload.NotificationPayload
+		data = map[string]string{
+			"starter_domain":           payload.StarterDomain,
+			"starter_name":             payload.StarterName,
+			"department_id":            formatID(payload.DepartmentID),
+			"department_name":          payload.DepartmentName,
+			"previous_department_id":   formatID(payload.PreviousDepartmentID),
+			"previous_department_name": payload.PreviousDepartmentName,
+		}
+
+	case events.EventTypeNotificationStarterDeleted:
+		var payload events.StarterDeletedEventPayload
+		if err := event.UnmarshalPayload(&payload); err != nil {
+			return nil, err
+		}
+		recipient = payload.NotificationPayload
+		data = map[string]string{
+			"starter_domain":  payload.StarterDomain,
+			"starter_name":    payload.StarterName,
+			"department_id":   formatID(payload.DepartmentID),
+			"department_name": payload.DepartmentName,
+			"line_manager":    payload.LineManager,
+		}
+
+	default:
+		return nil, fmt.Errorf("event type %s is not a notification", event.Type)
+	}
+
+	if recipient.ToStarter == "" {
+		return nil, fmt.Errorf("notification event %s has no recipient", event.ID)
+	}
+	if data != nil {
+		data["from_starter"] = recipient.FromStarter
+		data["to_starter"] = recipient.ToStarter
+	}
+
+	return &model.Notification{
+		ID:          event.ID.String(),
+		FromStarter: recipient.FromStarter,
+		ToStarter:   recipient.ToStarter,
+		Message:     recipient.Message,
+		Type:        event.Type,
+		Timestamp:   event.Timestamp,
+		Data:        data,
+	}, nil
+}
+
+// formatID leaves IDs the event left out empty rather than "0".
+func formatID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
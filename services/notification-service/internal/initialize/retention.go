@@ -0,0 +1,48 @@
+package initialize
+
+import (
+	"context"
+	"log"
+
+	"github.com/kiin21/go-rest/pkg/mongodb"
+	"github.com/kiin21/go-rest/services/notification-service/internal/config"
+	notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+	notinfra "github.com/kiin21/go-rest/services/notification-service/internal/notification/infrastructure/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// InitRetention applies the retention of read notifications and sets up the archiving of old
+// ones. Notifications still waiting for delivery through channels are not archived.
+func InitRetention(
+	cfg config.Config,
+	client *mongodb.Client,
+	collection *mongo.Collection,
+	channels []string,
+) *notiapp.NotificationArchiver {
+	if err := notinfra.EnsureRetentionIndexes(context.Background(), collection, cfg.NotificationReadRetention); err != nil {
+		log.Fatalf("Could not apply notification retention: %v", err)
+	}
+	if cfg.NotificationReadRetention > 0 {
+		log.Printf("Read notifications are deleted %s after they were sent", cfg.NotificationReadRetention)
+	}
+
+	policy := notiapp.DefaultArchivePolicy()
+	policy.After = cfg.NotificationArchiveAfter
+	if cfg.NotificationArchiveInterval > 0 {
+		policy.Interval = cfg.NotificationArchiveInterval
+	}
+
+	archiveName := cfg.MongoArchiveCollection
+	if archiveName == "" {
+		archiveName = cfg.MongoCollection + notinfra.ArchiveCollectionSuffix
+	}
+	archive := client.Collection(archiveName)
+	if policy.After > 0 {
+		if err := notinfra.EnsureArchiveIndexes(context.Background(), collection, archive); err != nil {
+			log.Fatalf("Could not create notification archive indexes: %v", err)
+		}
+	}
+
+	repo := notinfra.NewNotificationArchiveMongoRepository(collection, archive)
+	return notiapp.NewNotificationArchiver(repo, channels, policy)
+}
@@ -4,15 +4,27 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
+	"github.com/kiin21/go-rest/pkg/httpmw"
 	"github.com/kiin21/go-rest/pkg/httputil"
 	"github.com/kiin21/go-rest/services/notification-service/docs"
-	"github.com/kiin21/go-rest/services/notification-service/internal/middleware"
 	notihttp "github.com/kiin21/go-rest/services/notification-service/internal/notification/presentation/http"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func InitRouter(logLevel string, requestURLResolver *httputil.RequestURLResolver, handler *notihttp.NotiHandler) *gin.Engine {
+func InitRouter(
+	logLevel string,
+	requestURLResolver *httputil.RequestURLResolver,
+	handler *notihttp.NotiHandler,
+	streamHandler *notihttp.StreamHandler,
+	templateHandler *notihttp.TemplateHandler,
+	preferenceHandler *notihttp.PreferenceHandler,
+	webhookHandler *notihttp.WebhookHandler,
+	ingestHandler *notihttp.IngestHandler,
+	verifier *auth.Verifier,
+	cors httpmw.CORSConfig,
+) *gin.Engine {
 	var r *gin.Engine
 	// Set the mode based on the environment
 	if logLevel == "debug" {
@@ -24,13 +36,14 @@ func InitRouter(logLevel string, requestURLResolver *httputil.RequestURLResolver
 		r = gin.New()
 	}
 	// middlewares
-	r.Use(middleware.CORS)
+	cors.AllowedHeaders = append(cors.AllowedHeaders, "Last-Event-ID")
+	r.Use(httpmw.SecurityHeaders(httpmw.DefaultSecurityHeadersConfig()), httpmw.CORS(cors))
 	// Health check endpoint
 	r.GET("/health", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, gin.H{"status": "healthy"})
 	})
 
-	v1 := r.Group("/api/v1")
+	v1 := r.Group("/api/v1", auth.Authenticate(verifier))
 
 	// Swagger UI
 	swaggerHandler := ginSwagger.WrapHandler(swaggerFiles.Handler)
@@ -43,6 +56,14 @@ func InitRouter(logLevel string, requestURLResolver *httputil.RequestURLResolver
 	})
 
 	notihttp.RegisterNotificationRoutes(v1, handler)
+	notihttp.RegisterTemplateRoutes(v1, templateHandler)
+	notihttp.RegisterPreferenceRoutes(v1, preferenceHandler)
+	notihttp.RegisterWebhookRoutes(v1, webhookHandler)
+	notihttp.RegisterIngestRoutes(v1, ingestHandler)
+
+	// EventSource and WebSocket clients cannot set an Authorization header
+	stream := r.Group("/api/v1", auth.TokenFromQuery("access_token"), auth.Authenticate(verifier))
+	notihttp.RegisterNotificationStreamRoutes(stream, streamHandler)
 
 	return r
 }
@@ -1,9 +1,11 @@
 package initialize
 
 import (
+	"context"
 	"log"
 
 	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
 	"github.com/kiin21/go-rest/pkg/httputil"
 	"github.com/kiin21/go-rest/services/notification-service/internal/config"
 	initDB "github.com/kiin21/go-rest/services/notification-service/internal/initialize/db"
@@ -14,13 +16,29 @@ import (
 	notihttp "github.com/kiin21/go-rest/services/notification-service/internal/notification/presentation/http"
 )
 
-func Run() (*gin.Engine, string, domainmessaging.NotificationConsumer) {
+func Run() (
+	*gin.Engine,
+	string,
+	domainmessaging.NotificationConsumer,
+	domainmessaging.NotificationPublisher,
+	domainmessaging.NotificationConsumer,
+	*notiapp.NotificationHub,
+	*notiapp.DeliveryService,
+	*notiapp.WebhookService,
+	*notiapp.DigestScheduler,
+	*notiapp.NotificationArchiver,
+) {
 	// 1> Read config -> environment variables
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("Could not load config: %v", err)
 	}
 
+	verifier, err := auth.NewVerifier(cfg.AuthConfig())
+	if err != nil {
+		log.Fatalf("Could not initialize authentication: %v", err)
+	}
+
 	// 2> Initialize database connection
 	client, err := initDB.InitDB(&cfg)
 	if err != nil {
@@ -31,17 +49,56 @@ func Run() (*gin.Engine, string, domainmessaging.NotificationConsumer) {
 
 	// 3> Setup repository, service, handler
 	collection := client.Collection(collectionName)
+	if err := notinfra.EnsureNotificationIndexes(context.Background(), collection); err != nil {
+		log.Fatalf("Could not create notification indexes: %v", err)
+	}
 	repo := notinfra.NewNotificationMongoRepository(collection)
 	service := notiapp.NewNotiApplicationService(repo)
+	templates := notiapp.NewTemplateService(
+		notinfra.NewTemplateMongoRepository(client.Collection(notinfra.TemplatesCollection)),
+		cfg.NotificationDefaultLocale,
+	)
 	requestURLResolver := httputil.NewRequestURLResolver()
-	handler := notihttp.NewNotiHandler(service, requestURLResolver)
+	handler := notihttp.NewNotiHandler(service, templates, requestURLResolver)
+	templateHandler := notihttp.NewTemplateHandler(templates)
+	preferencesCollection := client.Collection(notinfra.PreferencesCollection)
+	if err := notinfra.EnsurePreferenceIndexes(context.Background(), preferencesCollection); err != nil {
+		log.Fatalf("Could not create notification preference indexes: %v", err)
+	}
+	preferenceRepo := notinfra.NewPreferenceMongoRepository(preferencesCollection)
+	preferences := notiapp.NewPreferenceService(preferenceRepo)
+	preferenceHandler := notihttp.NewPreferenceHandler(preferences)
+	hub := notiapp.NewNotificationHub()
+	streamHandler := notihttp.NewStreamHandler(service, templates, hub)
 
-	// 4> Initialize Kafka consumer
-	eventHandler := initmessagebroker.InitEventHandler(repo)
+	// 4> Initialize Kafka consumers: stored events are published for delivery to the streams
+	// of every instance, and events are forwarded to webhook subscriptions
+	publisher, deliveryConsumer := initmessagebroker.InitDelivery(cfg, hub)
+	deliveries, digests := InitDeliveryService(cfg, repo, collection, preferenceRepo)
+	deliveries.Start()
+	digests.Start()
+	archiver := InitRetention(cfg, client, collection, deliveries.ChannelNames())
+	archiver.Start()
+	webhooks := InitWebhookService(cfg, client)
+	webhooks.Start()
+	dedupeCollection := client.Collection(notinfra.DedupeCollection)
+	if err := notinfra.EnsureDedupeIndexes(context.Background(), dedupeCollection); err != nil {
+		log.Fatalf("Could not create notification dedupe indexes: %v", err)
+	}
+	dedupe := notinfra.NewDedupeMongoRepository(dedupeCollection)
+	ingestStats := notiapp.NewIngestStats()
+	eventHandler := initmessagebroker.InitEventHandler(
+		cfg, repo, dedupe, publisher, deliveries, webhooks, templates, preferences, ingestStats,
+	)
 	consumer := initmessagebroker.InitGroupConsumer(cfg, eventHandler)
+	webhookHandler := notihttp.NewWebhookHandler(webhooks)
+	ingestHandler := notihttp.NewIngestHandler(ingestStats)
 
 	// 5> Setup router
-	r := InitRouter(cfg.LogLevel, requestURLResolver, handler)
+	r := InitRouter(
+		cfg.LogLevel, requestURLResolver, handler, streamHandler, templateHandler, preferenceHandler, webhookHandler,
+		ingestHandler, verifier, cfg.CORSConfig(),
+	)
 
-	return r, cfg.ServerPort, consumer
+	return r, cfg.ServerPort, consumer, publisher, deliveryConsumer, hub, deliveries, webhooks, digests, archiver
 }
@@ -0,0 +1,33 @@
+package initialize
+
+import (
+	"context"
+	"log"
+
+	"github.com/kiin21/go-rest/pkg/mongodb"
+	"github.com/kiin21/go-rest/services/notification-service/internal/config"
+	notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+	infraDelivery "github.com/kiin21/go-rest/services/notification-service/internal/notification/infrastructure/delivery"
+	notinfra "github.com/kiin21/go-rest/services/notification-service/internal/notification/infrastructure/repository"
+)
+
+// InitWebhookService sets up the delivery of events to webhook subscriptions.
+func InitWebhookService(cfg config.Config, client *mongodb.Client) *notiapp.WebhookService {
+	subscriptions := client.Collection(notinfra.WebhookSubscriptionsCollection)
+	deliveries := client.Collection(notinfra.WebhookDeliveriesCollection)
+	deadLetters := client.Collection(notinfra.WebhookDeadLettersCollection)
+	if err := notinfra.EnsureWebhookIndexes(context.Background(), subscriptions, deliveries, deadLetters); err != nil {
+		log.Fatalf("Could not create webhook indexes: %v", err)
+	}
+
+	policy := notiapp.DefaultRetryPolicy()
+	if cfg.WebhookMaxAttempts > 0 {
+		policy.MaxAttempts = cfg.WebhookMaxAttempts
+	}
+	if cfg.WebhookRetryBackoff > 0 {
+		policy.Backoff = cfg.WebhookRetryBackoff
+	}
+
+	repo := notinfra.NewWebhookMongoRepository(subscriptions, deliveries, deadLetters)
+	return notiapp.NewWebhookService(repo, infraDelivery.NewHTTPWebhookSender(), policy)
+}
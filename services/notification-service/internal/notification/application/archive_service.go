@@ -0,0 +1,102 @@
+package application
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	domainrepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+)
+
+// ArchivePolicy says which notifications are moved to the archive, and how often.
+type ArchivePolicy struct {
+	// After is the age from which notifications are archived; zero keeps them in place
+	After    time.Duration
+	Interval time.Duration
+	// BatchSize bounds the notifications moved at once
+	BatchSize int
+}
+
+func DefaultArchivePolicy() ArchivePolicy {
+	return ArchivePolicy{
+		Interval:  time.Hour,
+		BatchSize: 500,
+	}
+}
+
+// NotificationArchiver moves old notifications out of the notifications collection, so the
+// inbox queries stay fast while the history is kept. Notifications still waiting to be sent
+// through a channel stay until they are sent or given up.
+type NotificationArchiver struct {
+	repo     domainrepo.NotificationArchiveRepository
+	channels []string
+	policy   ArchivePolicy
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewNotificationArchiver(
+	repo domainrepo.NotificationArchiveRepository,
+	channels []string,
+	policy ArchivePolicy,
+) *NotificationArchiver {
+	return &NotificationArchiver{
+		repo:     repo,
+		channels: channels,
+		policy:   policy,
+	}
+}
+
+func (a *NotificationArchiver) Start() {
+	if a.policy.After <= 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		ticker := time.NewTicker(a.policy.Interval)
+		defer ticker.Stop()
+		for {
+			a.archive(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	log.Printf("Notification archiver started for notifications older than %s", a.policy.After)
+}
+
+// Stop waits for the batch in progress to finish.
+func (a *NotificationArchiver) Stop() {
+	if a.cancel == nil {
+		return
+	}
+	a.cancel()
+	a.wg.Wait()
+}
+
+func (a *NotificationArchiver) archive(ctx context.Context) {
+	cutoff := time.Now().Add(-a.policy.After)
+	archived := 0
+	for ctx.Err() == nil {
+		moved, err := a.repo.Archive(ctx, cutoff, a.channels, a.policy.BatchSize)
+		if err != nil {
+			log.Printf("Failed to archive notifications: %v", err)
+			break
+		}
+		archived += moved
+		if moved < a.policy.BatchSize {
+			break
+		}
+	}
+	if archived > 0 {
+		log.Printf("Archived %d notifications from before %s", archived, cutoff.Format(time.RFC3339))
+	}
+}
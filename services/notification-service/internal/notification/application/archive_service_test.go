@@ -0,0 +1,101 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository/mocks"
+)
+
+func TestNotificationArchiverArchive(t *testing.T) {
+	tests := []struct {
+		name      string
+		moved     []int
+		err       error
+		wantCalls int
+	}{
+		{"nothing to archive", []int{0}, nil, 1},
+		{"less than a batch", []int{12}, nil, 1},
+		{"until a short batch", []int{100, 100, 12}, nil, 3},
+		{"until an empty batch", []int{100, 100, 0}, nil, 3},
+		{"stops on error", []int{100}, errors.New("connection refused"), 2},
+	}
+
+	policy := ArchivePolicy{After: 90 * 24 * time.Hour, Interval: time.Hour, BatchSize: 100}
+	channels := []string{"email", "webhook"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cutoffs []time.Time
+			repo := &mocks.MockNotificationArchiveRepository{
+				ArchiveFunc: func(ctx context.Context, cutoff time.Time, got []string, limit int) (int, error) {
+					cutoffs = append(cutoffs, cutoff)
+					if !slices.Equal(got, channels) || limit != policy.BatchSize {
+						t.Errorf("Archive(%v, %d); want %v, %d", got, limit, channels, policy.BatchSize)
+					}
+					if len(cutoffs) > len(tt.moved) {
+						return 0, tt.err
+					}
+					return tt.moved[len(cutoffs)-1], nil
+				},
+			}
+			archiver := NewNotificationArchiver(repo, channels, policy)
+
+			before := time.Now()
+			archiver.archive(context.Background())
+
+			if len(cutoffs) != tt.wantCalls {
+				t.Fatalf("archived in %d batches; want %d", len(cutoffs), tt.wantCalls)
+			}
+			for _, cutoff := range cutoffs {
+				if cutoff != cutoffs[0] {
+					t.Errorf("cutoff moved from %v to %v within a run", cutoffs[0], cutoff)
+				}
+			}
+			if age := before.Sub(cutoffs[0]); age < policy.After-time.Second || age > policy.After {
+				t.Errorf("cutoff is %v old; want %v", age, policy.After)
+			}
+		})
+	}
+}
+
+func TestNotificationArchiverStart(t *testing.T) {
+	t.Run("kept in place without an age", func(t *testing.T) {
+		repo := &mocks.MockNotificationArchiveRepository{
+			ArchiveFunc: func(ctx context.Context, cutoff time.Time, channels []string, limit int) (int, error) {
+				t.Error("archived without an age")
+				return 0, nil
+			},
+		}
+		archiver := NewNotificationArchiver(repo, nil, DefaultArchivePolicy())
+		archiver.Start()
+		archiver.Stop()
+	})
+
+	t.Run("archives on start", func(t *testing.T) {
+		called := make(chan struct{}, 1)
+		repo := &mocks.MockNotificationArchiveRepository{
+			ArchiveFunc: func(ctx context.Context, cutoff time.Time, channels []string, limit int) (int, error) {
+				select {
+				case called <- struct{}{}:
+				default:
+				}
+				return 0, nil
+			},
+		}
+		policy := DefaultArchivePolicy()
+		policy.After = 24 * time.Hour
+		archiver := NewNotificationArchiver(repo, nil, policy)
+
+		archiver.Start()
+		select {
+		case <-called:
+		case <-time.After(2 * time.Second):
+			t.Error("nothing was archived on start")
+		}
+		archiver.Stop()
+	})
+}
@@ -0,0 +1,199 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	domainDelivery "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/delivery"
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+	domainmodel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	domainrepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+)
+
+// RetryPolicy says how often and how soon a failed delivery is tried again.
+type RetryPolicy struct {
+	MaxAttempts int
+	// Backoff is the wait after the first failure; it doubles after each further one
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// PollInterval is how often due deliveries are looked for when nothing wakes the worker
+	PollInterval time.Duration
+	// SendTimeout bounds one attempt; the claim on a delivery lasts a little longer
+	SendTimeout time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:  5,
+		Backoff:      30 * time.Second,
+		MaxBackoff:   time.Hour,
+		PollInterval: 15 * time.Second,
+		SendTimeout:  30 * time.Second,
+	}
+}
+
+// retryAfter returns the wait before the next attempt, or false when attempts ran out.
+func (p RetryPolicy) retryAfter(attempts int) (time.Duration, bool) {
+	if attempts >= p.MaxAttempts {
+		return 0, false
+	}
+	wait := p.Backoff
+	for i := 1; i < attempts && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, p.MaxBackoff), true
+}
+
+// DeliveryService sends stored notifications out through the configured channels. New
+// notifications get a pending delivery per channel; a background worker claims due
+// deliveries, sends them and schedules retries, so deliveries survive restarts and every
+// instance can share the work.
+type DeliveryService struct {
+	repo     domainrepo.NotificationRepository
+	channels []domainDelivery.Channel
+	policy   RetryPolicy
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewDeliveryService(
+	repo domainrepo.NotificationRepository,
+	channels []domainDelivery.Channel,
+	policy RetryPolicy,
+) *DeliveryService {
+	return &DeliveryService{
+		repo:     repo,
+		channels: channels,
+		policy:   policy,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Schedule adds a delivery per channel to a notification that is about to be stored, as
+// the recipient's preferences allow: channels they turned off for the notification's type are
+// skipped, digest channels keep it for the next digest, and deliveries are held until quiet
+// hours end.
+func (s *DeliveryService) Schedule(notification *domainmodel.Notification, preferences *domainmodel.Preferences) {
+	if len(s.channels) == 0 {
+		return
+	}
+	now := time.Now()
+	settings := preferences.Settings(notification.Type)
+	quietUntil, quiet := preferences.QuietUntil(now)
+	if notification.Deliveries == nil {
+		notification.Deliveries = make(map[string]*domainmodel.Delivery, len(s.channels))
+	}
+	for _, channel := range s.channels {
+		if !settings.Enabled(channel.Name()) {
+			continue
+		}
+		if _, ok := channel.(domainDelivery.DigestSender); ok && preferences.DigestEnabled() {
+			notification.Deliveries[channel.Name()] = domainmodel.NewDigestDelivery(now)
+			continue
+		}
+		delivery := domainmodel.NewPendingDelivery(now)
+		if quiet {
+			delivery.NextAttemptAt = &quietUntil
+		}
+		notification.Deliveries[channel.Name()] = delivery
+	}
+}
+
+// ChannelNames returns the names of the channels notifications are sent out through.
+func (s *DeliveryService) ChannelNames() []string {
+	names := make([]string, 0, len(s.channels))
+	for _, channel := range s.channels {
+		names = append(names, channel.Name())
+	}
+	return names
+}
+
+// Wake makes the worker look for due deliveries now rather than at its next poll.
+func (s *DeliveryService) Wake() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *DeliveryService) Start() {
+	if len(s.channels) == 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.policy.PollInterval)
+		defer ticker.Stop()
+		for {
+			s.deliverDue(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-s.wake:
+			}
+		}
+	}()
+	log.Printf("Notification delivery worker started for %d channel(s)", len(s.channels))
+}
+
+// Stop waits for the attempt in progress to finish.
+func (s *DeliveryService) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *DeliveryService) deliverDue(ctx context.Context) {
+	for _, channel := range s.channels {
+		for ctx.Err() == nil {
+			notification, err := s.repo.ClaimDueDelivery(ctx, channel.Name(), time.Now(), 2*s.policy.SendTimeout)
+			if errors.Is(err, domainErr.ErrNotFound) {
+				break
+			}
+			if err != nil {
+				log.Printf("Failed to claim %s deliveries: %v", channel.Name(), err)
+				break
+			}
+			s.deliver(ctx, channel, notification)
+		}
+	}
+}
+
+func (s *DeliveryService) deliver(ctx context.Context, channel domainDelivery.Channel, notification *domainmodel.Notification) {
+	delivery := notification.Deliveries[channel.Name()]
+
+	sendCtx, cancel := context.WithTimeout(ctx, s.policy.SendTimeout)
+	err := channel.Send(sendCtx, notification)
+	cancel()
+
+	now := time.Now()
+	if err == nil {
+		delivery.MarkSent(now)
+	} else {
+		var retryAt *time.Time
+		if wait, ok := s.policy.retryAfter(delivery.Attempts); ok && !domainDelivery.IsPermanent(err) {
+			at := now.Add(wait)
+			retryAt = &at
+		}
+		delivery.MarkFailed(err, retryAt, now)
+		log.Printf("Failed to deliver notification %s by %s (attempt %d, status %s): %v",
+			notification.ID, channel.Name(), delivery.Attempts, delivery.Status, err)
+	}
+
+	// The outcome must be kept even when shutting down, or the notification is sent again
+	if err := s.repo.SaveDelivery(context.WithoutCancel(ctx), notification.ID, channel.Name(), delivery); err != nil {
+		log.Printf("Failed to save %s delivery of notification %s: %v", channel.Name(), notification.ID, err)
+	}
+}
@@ -0,0 +1,151 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kiin21/go-rest/pkg/events"
+	domainDelivery "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/delivery"
+	domainmodel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+// testChannel is a channel that sends nothing; testDigestChannel can also send digests.
+type testChannel struct{ name string }
+
+func (c testChannel) Name() string { return c.name }
+
+func (c testChannel) Send(context.Context, *domainmodel.Notification) error { return nil }
+
+type testDigestChannel struct{ testChannel }
+
+func (c testDigestChannel) SendDigest(context.Context, string, []*domainmodel.Notification) error {
+	return nil
+}
+
+func TestRetryPolicyRetryAfter(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 8, Backoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}
+
+	tests := []struct {
+		attempts  int
+		wantWait  time.Duration
+		wantRetry bool
+	}{
+		{1, 30 * time.Second, true},
+		{2, time.Minute, true},
+		{3, 2 * time.Minute, true},
+		{4, 4 * time.Minute, true},
+		{5, 5 * time.Minute, true},
+		{7, 5 * time.Minute, true},
+		{8, 0, false},
+		{9, 0, false},
+	}
+
+	for _, tt := range tests {
+		wait, retry := policy.retryAfter(tt.attempts)
+		if wait != tt.wantWait || retry != tt.wantRetry {
+			t.Errorf("retryAfter(%d) = %v, %v; want %v, %v", tt.attempts, wait, retry, tt.wantWait, tt.wantRetry)
+		}
+	}
+}
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	var total time.Duration
+	for attempts := 1; ; attempts++ {
+		wait, retry := policy.retryAfter(attempts)
+		if !retry {
+			if attempts != policy.MaxAttempts {
+				t.Errorf("gave up after %d attempts; want %d", attempts, policy.MaxAttempts)
+			}
+			break
+		}
+		if wait > policy.MaxBackoff {
+			t.Errorf("retryAfter(%d) = %v exceeds the cap %v", attempts, wait, policy.MaxBackoff)
+		}
+		total += wait
+	}
+	// 30s + 1m + 2m + 4m between the five attempts
+	if want := 7*time.Minute + 30*time.Second; total != want {
+		t.Errorf("total wait = %v; want %v", total, want)
+	}
+}
+
+func TestDeliveryServiceSchedule(t *testing.T) {
+	now := time.Now().UTC()
+	clock := func(d time.Duration) string { return now.Add(d).Format("15:04") }
+	// Windows around now may cross midnight, which QuietUntil handles
+	quietNow := &domainmodel.QuietHours{Start: clock(-time.Hour), End: clock(time.Hour)}
+	quietLater := &domainmodel.QuietHours{Start: clock(2 * time.Hour), End: clock(3 * time.Hour)}
+
+	tests := []struct {
+		name        string
+		preferences func(p *domainmodel.Preferences)
+		want        map[string]domainmodel.DeliveryStatus
+		wantHeld    bool
+	}{
+		{
+			name:        "defaults send on every channel",
+			preferences: func(p *domainmodel.Preferences) {},
+			want:        map[string]domainmodel.DeliveryStatus{"email": domainmodel.DeliveryPending, "webhook": domainmodel.DeliveryPending},
+		},
+		{
+			name: "channel turned off for the type",
+			preferences: func(p *domainmodel.Preferences) {
+				p.EventTypes[events.EventTypeNotificationStarterCreated] = domainmodel.ChannelSettings{InApp: true, Webhook: true}
+			},
+			want: map[string]domainmodel.DeliveryStatus{"webhook": domainmodel.DeliveryPending},
+		},
+		{
+			name:        "digest keeps email for the digest",
+			preferences: func(p *domainmodel.Preferences) { p.Digest = domainmodel.DigestDaily },
+			want:        map[string]domainmodel.DeliveryStatus{"email": domainmodel.DeliveryDigest, "webhook": domainmodel.DeliveryPending},
+		},
+		{
+			name:        "quiet hours hold deliveries until they end",
+			preferences: func(p *domainmodel.Preferences) { p.QuietHours = quietNow },
+			want:        map[string]domainmodel.DeliveryStatus{"email": domainmodel.DeliveryPending, "webhook": domainmodel.DeliveryPending},
+			wantHeld:    true,
+		},
+		{
+			name:        "quiet hours later today",
+			preferences: func(p *domainmodel.Preferences) { p.QuietHours = quietLater },
+			want:        map[string]domainmodel.DeliveryStatus{"email": domainmodel.DeliveryPending, "webhook": domainmodel.DeliveryPending},
+		},
+	}
+
+	channels := []domainDelivery.Channel{testDigestChannel{testChannel{"email"}}, testChannel{"webhook"}}
+	service := NewDeliveryService(nil, channels, DefaultRetryPolicy())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preferences := domainmodel.DefaultPreferences("thanhnt")
+			preferences.Timezone = "UTC"
+			tt.preferences(preferences)
+			notification := &domainmodel.Notification{Type: events.EventTypeNotificationStarterCreated}
+
+			service.Schedule(notification, preferences)
+
+			if len(notification.Deliveries) != len(tt.want) {
+				t.Fatalf("got %d deliveries; want %d", len(notification.Deliveries), len(tt.want))
+			}
+			for channel, status := range tt.want {
+				delivery, ok := notification.Deliveries[channel]
+				if !ok || delivery.Status != status {
+					t.Fatalf("%s delivery = %+v; want %s", channel, delivery, status)
+				}
+				if status != domainmodel.DeliveryPending {
+					continue
+				}
+				held := delivery.NextAttemptAt.Sub(now) > time.Minute
+				if held != tt.wantHeld {
+					t.Errorf("%s next attempt at %v; held = %v, want %v", channel, delivery.NextAttemptAt, held, tt.wantHeld)
+				}
+				if held && delivery.NextAttemptAt.Sub(now) > time.Hour {
+					t.Errorf("%s held until %v, after the quiet hours end", channel, delivery.NextAttemptAt)
+				}
+			}
+		})
+	}
+}
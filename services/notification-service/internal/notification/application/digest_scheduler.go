@@ -0,0 +1,151 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	domainDelivery "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/delivery"
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+	domainmodel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	domainrepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+)
+
+// digestBatchSize bounds the notifications of one digest; the rest follow in another right away.
+const digestBatchSize = 200
+
+// DigestScheduler sends the notifications held for recipients' digests. A background worker
+// claims the preferences whose digest is due, so every instance can share the work, sends the
+// held notifications in one message and schedules the next digest.
+type DigestScheduler struct {
+	repo        domainrepo.NotificationRepository
+	preferences domainrepo.PreferenceRepository
+	// sender is nil when no channel supports digests
+	sender domainDelivery.DigestSender
+	policy RetryPolicy
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewDigestScheduler(
+	repo domainrepo.NotificationRepository,
+	preferences domainrepo.PreferenceRepository,
+	sender domainDelivery.DigestSender,
+	policy RetryPolicy,
+) *DigestScheduler {
+	return &DigestScheduler{
+		repo:        repo,
+		preferences: preferences,
+		sender:      sender,
+		policy:      policy,
+	}
+}
+
+func (s *DigestScheduler) Start() {
+	if s.sender == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.policy.PollInterval)
+		defer ticker.Stop()
+		for {
+			s.sendDue(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	log.Printf("Notification digest scheduler started for %s", s.sender.Name())
+}
+
+// Stop waits for the digest in progress to finish.
+func (s *DigestScheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *DigestScheduler) sendDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		preferences, err := s.preferences.ClaimDueDigest(ctx, time.Now(), 2*s.policy.SendTimeout)
+		if errors.Is(err, domainErr.ErrNotFound) {
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to claim due digests: %v", err)
+			return
+		}
+		s.send(ctx, preferences)
+	}
+}
+
+func (s *DigestScheduler) send(ctx context.Context, preferences *domainmodel.Preferences) {
+	// The next digest must be scheduled even when shutting down, or it waits for the lease
+	saveCtx := context.WithoutCancel(ctx)
+	now := time.Now()
+
+	if quietUntil, quiet := preferences.QuietUntil(now); quiet {
+		s.scheduleNext(saveCtx, preferences.Recipient, &quietUntil)
+		return
+	}
+
+	channel := s.sender.Name()
+	notifications, err := s.repo.ListDigest(ctx, channel, preferences.Recipient, digestBatchSize)
+	if err == nil && len(notifications) > 0 {
+		sendCtx, cancel := context.WithTimeout(ctx, s.policy.SendTimeout)
+		err = s.sender.SendDigest(sendCtx, preferences.Recipient, notifications)
+		cancel()
+		if err == nil {
+			ids := make([]string, 0, len(notifications))
+			for _, notification := range notifications {
+				ids = append(ids, notification.ID)
+			}
+			err = s.repo.MarkDigestSent(saveCtx, channel, ids, time.Now())
+		}
+	}
+	if err != nil {
+		log.Printf("Failed to send the %s digest of %s: %v", channel, preferences.Recipient, err)
+		if domainDelivery.IsPermanent(err) {
+			// Retrying the same notifications cannot succeed; they wait for the next digest
+			// in case the recipient's address is fixed meanwhile
+			var next *time.Time
+			if preferences.DigestEnabled() {
+				at := preferences.NextDigest(now)
+				next = &at
+			}
+			s.scheduleNext(saveCtx, preferences.Recipient, next)
+			return
+		}
+		retryAt := now.Add(s.policy.Backoff)
+		s.scheduleNext(saveCtx, preferences.Recipient, &retryAt)
+		return
+	}
+
+	var next *time.Time
+	switch {
+	case len(notifications) == digestBatchSize:
+		next = &now
+	case preferences.DigestEnabled():
+		at := preferences.NextDigest(now)
+		next = &at
+	}
+	s.scheduleNext(saveCtx, preferences.Recipient, next)
+}
+
+func (s *DigestScheduler) scheduleNext(ctx context.Context, recipient string, at *time.Time) {
+	if err := s.preferences.SetNextDigest(ctx, recipient, at); err != nil {
+		log.Printf("Failed to schedule the next digest of %s: %v", recipient, err)
+	}
+}
@@ -1,9 +1,25 @@
 package application
 
-import "github.com/kiin21/go-rest/pkg/httputil"
+import (
+	"time"
+
+	"github.com/kiin21/go-rest/pkg/httputil"
+)
 
 type ListNotificationsQuery struct {
-	Pagination httputil.ReqPagination
-	SortBy     string
-	SortOrder  string
+	// Recipient is the starter domain whose notifications are listed; empty lists everyone's
+	Recipient string
+	// Read keeps only read (true) or unread (false) notifications; nil keeps both
+	Read *bool
+	// Types, Sender, DepartmentID, Since, Until and Search narrow the list as in
+	// ListNotificationsFilter
+	Types        []string
+	Sender       string
+	DepartmentID string
+	Since        *time.Time
+	Until        *time.Time
+	Search       string
+	Pagination   httputil.ReqPagination
+	SortBy       string
+	SortOrder    string
 }
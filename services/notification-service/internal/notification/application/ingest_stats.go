@@ -0,0 +1,51 @@
+package application
+
+import "sync/atomic"
+
+// IngestStats counts what became of the events this instance read since it started.
+type IngestStats struct {
+	processed   atomic.Int64
+	stored      atomic.Int64
+	redelivered atomic.Int64
+	duplicates  atomic.Int64
+	malformed   atomic.Int64
+	retries     atomic.Int64
+}
+
+// IngestCounts is a snapshot of IngestStats.
+type IngestCounts struct {
+	// Processed counts the events handled and committed, whatever became of them
+	Processed int64
+	// Stored counts the notifications stored
+	Stored int64
+	// Redelivered counts the notification events read again after they were stored
+	Redelivered int64
+	// Duplicates counts the notifications dropped for saying what another had just said
+	Duplicates int64
+	// Malformed counts the events skipped because they could not be read
+	Malformed int64
+	// Retries counts the writes that failed and were tried again
+	Retries int64
+}
+
+func NewIngestStats() *IngestStats {
+	return &IngestStats{}
+}
+
+func (s *IngestStats) Processed()   { s.processed.Add(1) }
+func (s *IngestStats) Stored()      { s.stored.Add(1) }
+func (s *IngestStats) Redelivered() { s.redelivered.Add(1) }
+func (s *IngestStats) Duplicate()   { s.duplicates.Add(1) }
+func (s *IngestStats) Malformed()   { s.malformed.Add(1) }
+func (s *IngestStats) Retried()     { s.retries.Add(1) }
+
+func (s *IngestStats) Snapshot() IngestCounts {
+	return IngestCounts{
+		Processed:   s.processed.Load(),
+		Stored:      s.stored.Load(),
+		Redelivered: s.redelivered.Load(),
+		Duplicates:  s.duplicates.Load(),
+		Malformed:   s.malformed.Load(),
+		Retries:     s.retries.Load(),
+	}
+}
@@ -0,0 +1,110 @@
+package application
+
+import (
+	"context"
+	"sync"
+
+	domainmodel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+// subscriptionBuffer is how many notifications a subscriber may fall behind before it is
+// dropped; a dropped client reconnects and catches up by replay.
+const subscriptionBuffer = 32
+
+// Subscription receives the notifications pushed to one connected recipient. The channel is
+// closed when the subscriber is dropped or the hub shuts down.
+type Subscription struct {
+	recipient     string
+	notifications chan *domainmodel.Notification
+	closeOnce     sync.Once
+}
+
+func (s *Subscription) Notifications() <-chan *domainmodel.Notification {
+	return s.notifications
+}
+
+func (s *Subscription) close() {
+	s.closeOnce.Do(func() { close(s.notifications) })
+}
+
+// NotificationHub pushes notifications to the recipients streaming from this instance. Used
+// as the NotificationPublisher it delivers locally, which is enough for a single instance.
+type NotificationHub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[*Subscription]struct{}
+	closed      bool
+}
+
+func NewNotificationHub() *NotificationHub {
+	return &NotificationHub{subscribers: make(map[string]map[*Subscription]struct{})}
+}
+
+// Subscribe registers a stream for recipient. Callers must Unsubscribe when the stream ends.
+func (h *NotificationHub) Subscribe(recipient string) *Subscription {
+	sub := &Subscription{
+		recipient:     recipient,
+		notifications: make(chan *domainmodel.Notification, subscriptionBuffer),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		sub.close()
+		return sub
+	}
+	if h.subscribers[recipient] == nil {
+		h.subscribers[recipient] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[recipient][sub] = struct{}{}
+	return sub
+}
+
+func (h *NotificationHub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(sub)
+}
+
+// Deliver pushes a notification to its recipient's streams without blocking; streams that
+// are too far behind are dropped.
+func (h *NotificationHub) Deliver(notification *domainmodel.Notification) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subscribers[notification.ToStarter] {
+		select {
+		case sub.notifications <- notification:
+		default:
+			h.remove(sub)
+		}
+	}
+}
+
+func (h *NotificationHub) Publish(_ context.Context, notification *domainmodel.Notification) error {
+	h.Deliver(notification)
+	return nil
+}
+
+// Close ends every stream; later subscriptions are closed right away.
+func (h *NotificationHub) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for _, subs := range h.subscribers {
+		for sub := range subs {
+			h.remove(sub)
+		}
+	}
+	return nil
+}
+
+func (h *NotificationHub) remove(sub *Subscription) {
+	subs := h.subscribers[sub.recipient]
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.subscribers, sub.recipient)
+	}
+	sub.close()
+}
@@ -0,0 +1,76 @@
+package application
+
+import (
+	"testing"
+
+	domainmodel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+func TestNotificationHubDeliver(t *testing.T) {
+	hub := NewNotificationHub()
+	mine := hub.Subscribe("thanhnt")
+	alsoMine := hub.Subscribe("thanhnt")
+	theirs := hub.Subscribe("someone")
+
+	hub.Deliver(&domainmodel.Notification{ID: "n-1", ToStarter: "thanhnt"})
+
+	for name, sub := range map[string]*Subscription{"first stream": mine, "second stream": alsoMine} {
+		select {
+		case notification := <-sub.Notifications():
+			if notification.ID != "n-1" {
+				t.Errorf("%s got %s; want n-1", name, notification.ID)
+			}
+		default:
+			t.Errorf("%s got nothing", name)
+		}
+	}
+	select {
+	case notification := <-theirs.Notifications():
+		t.Errorf("another recipient got %s", notification.ID)
+	default:
+	}
+
+	hub.Unsubscribe(alsoMine)
+	if _, ok := <-alsoMine.Notifications(); ok {
+		t.Error("expected an unsubscribed stream to be closed")
+	}
+	hub.Unsubscribe(alsoMine)
+}
+
+func TestNotificationHubDropsSlowSubscribers(t *testing.T) {
+	hub := NewNotificationHub()
+	sub := hub.Subscribe("thanhnt")
+
+	for i := 0; i <= subscriptionBuffer; i++ {
+		hub.Deliver(&domainmodel.Notification{ID: "n", ToStarter: "thanhnt"})
+	}
+
+	received := 0
+	for range sub.Notifications() {
+		received++
+	}
+	if received != subscriptionBuffer {
+		t.Errorf("received %d before the stream was dropped; want %d", received, subscriptionBuffer)
+	}
+	if _, ok := hub.subscribers["thanhnt"]; ok {
+		t.Error("expected the dropped stream to be forgotten")
+	}
+}
+
+func TestNotificationHubClose(t *testing.T) {
+	hub := NewNotificationHub()
+	before := hub.Subscribe("thanhnt")
+
+	if err := hub.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	after := hub.Subscribe("thanhnt")
+
+	for name, sub := range map[string]*Subscription{"open stream": before, "later stream": after} {
+		if _, ok := <-sub.Notifications(); ok {
+			t.Errorf("expected the %s to be closed", name)
+		}
+	}
+	// Delivering after close reaches nobody and does not panic
+	hub.Deliver(&domainmodel.Notification{ID: "n-1", ToStarter: "thanhnt"})
+}
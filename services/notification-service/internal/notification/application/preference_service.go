@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+	domainmodel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	domainrepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+)
+
+// PreferenceService keeps the recipients' notification preferences.
+type PreferenceService struct {
+	repo domainrepo.PreferenceRepository
+}
+
+func NewPreferenceService(repo domainrepo.PreferenceRepository) *PreferenceService {
+	return &PreferenceService{repo: repo}
+}
+
+// Get returns recipient's preferences, or the defaults when they saved none.
+func (s *PreferenceService) Get(ctx context.Context, recipient string) (*domainmodel.Preferences, error) {
+	preferences, err := s.repo.Get(ctx, recipient)
+	if errors.Is(err, domainErr.ErrNotFound) {
+		return domainmodel.DefaultPreferences(recipient), nil
+	}
+	return preferences, err
+}
+
+// Save replaces the recipient's preferences and schedules their next digest. Turning the
+// digest off sends what it still holds right away.
+func (s *PreferenceService) Save(ctx context.Context, preferences *domainmodel.Preferences) (*domainmodel.Preferences, error) {
+	if err := preferences.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainErr.ErrInvalidPreferences, err)
+	}
+	previous, err := s.Get(ctx, preferences.Recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	preferences.NextDigestAt = nil
+	switch {
+	case preferences.DigestEnabled():
+		next := preferences.NextDigest(now)
+		preferences.NextDigestAt = &next
+	case previous.DigestEnabled():
+		preferences.NextDigestAt = &now
+	}
+	preferences.UpdatedAt = now
+
+	if err := s.repo.Save(ctx, preferences); err != nil {
+		return nil, err
+	}
+	return preferences, nil
+}
+
+// For returns the preferences notifications to recipient are sent with. They fall back to
+// the defaults when they cannot be loaded, so that a notification is never lost over them.
+func (s *PreferenceService) For(ctx context.Context, recipient string) *domainmodel.Preferences {
+	preferences, err := s.Get(ctx, recipient)
+	if err != nil {
+		log.Printf("Failed to load notification preferences of %s, using defaults: %v", recipient, err)
+		return domainmodel.DefaultPreferences(recipient)
+	}
+	return preferences
+}
@@ -2,14 +2,20 @@ package application
 
 import (
 	"context"
+	"errors"
 	"math"
 	"strconv"
+	"time"
 
 	"github.com/kiin21/go-rest/pkg/httputil"
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
 	domainmodel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
 	domainrepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
 )
 
+// replayBatchSize is how many missed notifications are read from the store at a time
+const replayBatchSize = 100
+
 type NotiApplicationService struct {
 	repo domainrepo.NotificationRepository
 }
@@ -25,8 +31,16 @@ func (s *NotiApplicationService) ListNotifications(
 	pagination := query.Pagination
 
 	filter := domainrepo.ListNotificationsFilter{
-		SortBy:    query.SortBy,
-		SortOrder: query.SortOrder,
+		ToStarter:    query.Recipient,
+		Read:         query.Read,
+		Types:        query.Types,
+		FromStarter:  query.Sender,
+		DepartmentID: query.DepartmentID,
+		Since:        query.Since,
+		Until:        query.Until,
+		Search:       query.Search,
+		SortBy:       query.SortBy,
+		SortOrder:    query.SortOrder,
 	}
 
 	data, total, err := s.repo.List(ctx, filter, pagination)
@@ -34,6 +48,14 @@ func (s *NotiApplicationService) ListNotifications(
 		return nil, err
 	}
 
+	if pagination.IsCursorMode() {
+		data, respPagination := httputil.NewCursorPage(data, pagination.GetLimit(), pagination.Cursor,
+			func(notification *domainmodel.Notification) (string, string) {
+				return notification.CursorKey(query.SortBy)
+			})
+		return &httputil.PaginatedResult[*domainmodel.Notification]{Data: data, Pagination: respPagination}, nil
+	}
+
 	var (
 		prev *string
 		next *string
@@ -60,3 +82,42 @@ func (s *NotiApplicationService) ListNotifications(
 		},
 	}, nil
 }
+
+// MarkRead marks a notification sent to recipient as read.
+func (s *NotiApplicationService) MarkRead(ctx context.Context, id, recipient string) (*domainmodel.Notification, error) {
+	return s.repo.MarkRead(ctx, id, recipient, time.Now())
+}
+
+// MarkAllRead marks all of recipient's unread notifications as read and returns how many
+// there were.
+func (s *NotiApplicationService) MarkAllRead(ctx context.Context, recipient string) (int64, error) {
+	return s.repo.MarkAllRead(ctx, recipient, time.Now())
+}
+
+func (s *NotiApplicationService) CountUnread(ctx context.Context, recipient string) (int64, error) {
+	return s.repo.CountUnread(ctx, recipient)
+}
+
+// Replay returns the notifications sent to recipient after the one with lastEventID, oldest
+// first, so that a reconnecting stream can catch up. An unknown lastEventID replays nothing.
+func (s *NotiApplicationService) Replay(
+	ctx context.Context,
+	recipient, lastEventID string,
+) ([]*domainmodel.Notification, error) {
+	var missed []*domainmodel.Notification
+	for afterID := lastEventID; ; {
+		batch, err := s.repo.ListAfter(ctx, recipient, afterID, replayBatchSize)
+		if errors.Is(err, domainErr.ErrNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		missed = append(missed, batch...)
+		if len(batch) < replayBatchSize {
+			return missed, nil
+		}
+		afterID = batch[len(batch)-1].ID
+	}
+}
@@ -0,0 +1,156 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kiin21/go-rest/pkg/httputil"
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+	domainmodel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	domainrepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository/mocks"
+)
+
+func TestListNotifications(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     int
+		total    int64
+		wantPrev string
+		// wantNext is empty when there is no next page
+		wantNext string
+	}{
+		{"first page of three", 1, 45, "0", "2"},
+		{"middle page", 2, 45, "1", "3"},
+		{"last page", 3, 45, "2", ""},
+		{"nothing to list", 1, 0, "0", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unread := false
+			var got domainrepo.ListNotificationsFilter
+			repo := &mocks.MockNotificationRepository{
+				ListFunc: func(ctx context.Context, filter domainrepo.ListNotificationsFilter, pagination httputil.ReqPagination) ([]*domainmodel.Notification, int64, error) {
+					got = filter
+					return []*domainmodel.Notification{{ID: "n-1"}}, tt.total, nil
+				},
+			}
+
+			page, limit := tt.page, 20
+			result, err := NewNotiApplicationService(repo).ListNotifications(context.Background(), ListNotificationsQuery{
+				Recipient:  "thanhnt",
+				Read:       &unread,
+				Types:      []string{"notification.starter_created"},
+				Pagination: httputil.ReqPagination{Page: &page, Limit: &limit},
+				SortBy:     "timestamp",
+				SortOrder:  "desc",
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.ToStarter != "thanhnt" || got.Read == nil || *got.Read || len(got.Types) != 1 {
+				t.Errorf("the query was not passed on to the repository: %+v", got)
+			}
+			pagination := result.Pagination
+			if pagination.TotalItems != tt.total {
+				t.Errorf("total = %d; want %d", pagination.TotalItems, tt.total)
+			}
+			if pagination.Prev == nil || *pagination.Prev != tt.wantPrev {
+				t.Errorf("prev = %v; want %s", pagination.Prev, tt.wantPrev)
+			}
+			if tt.wantNext == "" {
+				if pagination.Next != nil {
+					t.Errorf("next = %s; want none", *pagination.Next)
+				}
+			} else if pagination.Next == nil || *pagination.Next != tt.wantNext {
+				t.Errorf("next = %v; want %s", pagination.Next, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestMarkRead(t *testing.T) {
+	tests := []struct {
+		name      string
+		recipient string
+		wantErr   error
+	}{
+		{"own notification", "thanhnt", nil},
+		{"someone else's notification", "someone", domainErr.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockNotificationRepository{
+				MarkReadFunc: func(ctx context.Context, id, toStarter string, readAt time.Time) (*domainmodel.Notification, error) {
+					// As the store does, the notification is only found for its recipient
+					if id != "n-1" || toStarter != "thanhnt" {
+						return nil, domainErr.ErrNotFound
+					}
+					return &domainmodel.Notification{ID: id, ToStarter: toStarter, ReadAt: &readAt}, nil
+				},
+			}
+
+			notification, err := NewNotiApplicationService(repo).MarkRead(context.Background(), "n-1", tt.recipient)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v; want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (notification.ReadAt == nil || !notification.IsRead()) {
+				t.Errorf("expected the notification to be read, got %+v", notification)
+			}
+		})
+	}
+}
+
+func TestReplay(t *testing.T) {
+	// Stored notifications n-1 ... n-250 of one recipient, oldest first
+	stored := make([]*domainmodel.Notification, 250)
+	for i := range stored {
+		stored[i] = &domainmodel.Notification{ID: fmt.Sprintf("n-%d", i+1), ToStarter: "thanhnt"}
+	}
+	repo := &mocks.MockNotificationRepository{
+		ListAfterFunc: func(ctx context.Context, toStarter, afterID string, limit int) ([]*domainmodel.Notification, error) {
+			for i, notification := range stored {
+				if notification.ID == afterID && toStarter == "thanhnt" {
+					return stored[i+1 : min(i+1+limit, len(stored))], nil
+				}
+			}
+			return nil, domainErr.ErrNotFound
+		},
+	}
+	service := NewNotiApplicationService(repo)
+
+	tests := []struct {
+		name        string
+		recipient   string
+		lastEventID string
+		wantFirst   string
+		wantCount   int
+	}{
+		{"missed more than a batch", "thanhnt", "n-10", "n-11", 240},
+		{"missed exactly a batch", "thanhnt", "n-150", "n-151", 100},
+		{"missed nothing", "thanhnt", "n-250", "", 0},
+		{"unknown event", "thanhnt", "n-999", "", 0},
+		{"someone else's event", "someone", "n-10", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missed, err := service.Replay(context.Background(), tt.recipient, tt.lastEventID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(missed) != tt.wantCount {
+				t.Fatalf("replayed %d; want %d", len(missed), tt.wantCount)
+			}
+			if tt.wantCount > 0 && missed[0].ID != tt.wantFirst {
+				t.Errorf("first replayed = %s; want %s", missed[0].ID, tt.wantFirst)
+			}
+		})
+	}
+}
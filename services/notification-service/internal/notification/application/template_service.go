@@ -0,0 +1,309 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/kiin21/go-rest/pkg/events"
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+	domainmodel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	domainrepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+)
+
+type templateKey struct {
+	eventType string
+	locale    string
+}
+
+// builtinTemplates apply until they are replaced through the API.
+var builtinTemplates = map[templateKey]string{
+	{events.EventTypeNotificationLeaderAssignment, domainmodel.LocaleEnglish}: "You have been assigned as leader of {{.department_name}}" +
+		"{{with .previous_leader}}, taking over from {{.}}{{end}}",
+	{events.EventTypeNotificationLeaderAssignment, domainmodel.LocaleVietnamese}: "Bạn đã được bổ nhiệm làm trưởng bộ phận {{.department_name}}" +
+		"{{with .previous_leader}}, thay cho {{.}}{{end}}",
+	{events.EventTypeNotificationStarterCreated, domainmodel.LocaleEnglish}: "{{.starter_name}} ({{.starter_domain}}) has joined" +
+		"{{with .department_name}} {{.}}{{end}}{{with .line_manager}}, reporting to {{.}}{{end}}",
+	{events.EventTypeNotificationStarterCreated, domainmodel.LocaleVietnamese}: "{{.starter_name}} ({{.starter_domain}}) đã gia nhập" +
+		"{{with .department_name}} {{.}}{{end}}{{with .line_manager}}, báo cáo cho {{.}}{{end}}",
+	{events.EventTypeNotificationLineManagerChanged, domainmodel.LocaleEnglish}: "{{if .line_manager}}{{.starter_name}} now reports to {{.line_manager}}" +
+		"{{with .previous_line_manager}} instead of {{.}}{{end}}{{else}}{{.starter_name}} no longer reports to {{.previous_line_manager}}{{end}}",
+	{events.EventTypeNotificationLineManagerChanged, domainmodel.LocaleVietnamese}: "{{if .line_manager}}{{.starter_name}} giờ báo cáo cho {{.line_manager}}" +
+		"{{with .previous_line_manager}} thay vì {{.}}{{end}}{{else}}{{.starter_name}} không còn báo cáo cho {{.previous_line_manager}}{{end}}",
+	{events.EventTypeNotificationDepartmentTransfer, domainmodel.LocaleEnglish}: "{{.starter_name}} has moved" +
+		"{{with .previous_department_name}} from {{.}}{{end}} to {{.department_name}}",
+	{events.EventTypeNotificationDepartmentTransfer, domainmodel.LocaleVietnamese}: "{{.starter_name}} đã chuyển" +
+		"{{with .previous_department_name}} từ {{.}}{{end}} sang {{.department_name}}",
+	{events.EventTypeNotificationStarterDeleted, domainmodel.LocaleEnglish}: "{{.starter_name}} ({{.starter_domain}}) has been removed" +
+		"{{with .department_name}} from {{.}}{{end}}",
+	{events.EventTypeNotificationStarterDeleted, domainmodel.LocaleVietnamese}: "{{.starter_name}} ({{.starter_domain}}) đã bị xóa" +
+		"{{with .department_name}} khỏi {{.}}{{end}}",
+}
+
+// sampleData lists the variables of each event type that has templates. Templates are
+// checked against it before they are saved, and previewed with it unless other data is given.
+var sampleData = map[string]map[string]string{
+	events.EventTypeNotificationLeaderAssignment: {
+		"from_starter":    "previous.leader",
+		"to_starter":      "new.leader",
+		"department_id":   "12",
+		"department_name": "Engineering",
+		"previous_leader": "previous.leader",
+	},
+	events.EventTypeNotificationStarterCreated: {
+		"from_starter":    "system",
+		"to_starter":      "line.manager",
+		"starter_domain":  "new.starter",
+		"starter_name":    "New Starter",
+		"department_id":   "12",
+		"department_name": "Engineering",
+		"line_manager":    "line.manager",
+	},
+	events.EventTypeNotificationLineManagerChanged: {
+		"from_starter":          "system",
+		"to_starter":            "new.manager",
+		"starter_domain":        "some.starter",
+		"starter_name":          "Some Starter",
+		"line_manager":          "new.manager",
+		"previous_line_manager": "previous.manager",
+	},
+	events.EventTypeNotificationDepartmentTransfer: {
+		"from_starter":             "system",
+		"to_starter":               "some.starter",
+		"starter_domain":           "some.starter",
+		"starter_name":             "Some Starter",
+		"department_id":            "12",
+		"department_name":          "Engineering",
+		"previous_department_id":   "7",
+		"previous_department_name": "Sales",
+	},
+	events.EventTypeNotificationStarterDeleted: {
+		"from_starter":    "system",
+		"to_starter":      "line.manager",
+		"starter_domain":  "former.starter",
+		"starter_name":    "Former Starter",
+		"department_id":   "12",
+		"department_name": "Engineering",
+		"line_manager":    "line.manager",
+	},
+}
+
+// TemplateService renders notification messages from templates keyed by event type and
+// locale. Notifications keep the variables of their event, so a message can be rendered in
+// whichever locale its reader asks for.
+type TemplateService struct {
+	repo          domainrepo.TemplateRepository
+	defaultLocale string
+}
+
+func NewTemplateService(repo domainrepo.TemplateRepository, defaultLocale string) *TemplateService {
+	if !slices.Contains(domainmodel.Locales, defaultLocale) {
+		defaultLocale = domainmodel.LocaleEnglish
+	}
+	return &TemplateService{repo: repo, defaultLocale: defaultLocale}
+}
+
+// ResolveLocale returns locale when it is supported and the default locale otherwise.
+func (s *TemplateService) ResolveLocale(locale string) string {
+	if slices.Contains(domainmodel.Locales, locale) {
+		return locale
+	}
+	return s.defaultLocale
+}
+
+// List returns every template in use: the replaced ones and the built-in ones left.
+func (s *TemplateService) List(ctx context.Context) ([]*domainmodel.NotificationTemplate, error) {
+	custom, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make(map[templateKey]*domainmodel.NotificationTemplate, len(builtinTemplates)+len(custom))
+	for key, body := range builtinTemplates {
+		templates[key] = builtinTemplate(key, body)
+	}
+	for _, t := range custom {
+		templates[templateKey{t.EventType, t.Locale}] = t
+	}
+
+	result := make([]*domainmodel.NotificationTemplate, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, t)
+	}
+	slices.SortFunc(result, func(a, b *domainmodel.NotificationTemplate) int {
+		if c := strings.Compare(a.EventType, b.EventType); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Locale, b.Locale)
+	})
+	return result, nil
+}
+
+// Get returns the template in use for eventType and locale, or ErrNotFound when there is none.
+func (s *TemplateService) Get(ctx context.Context, eventType, locale string) (*domainmodel.NotificationTemplate, error) {
+	t, err := s.repo.Get(ctx, eventType, locale)
+	if errors.Is(err, domainErr.ErrNotFound) {
+		key := templateKey{eventType, locale}
+		if body, ok := builtinTemplates[key]; ok {
+			return builtinTemplate(key, body), nil
+		}
+	}
+	return t, err
+}
+
+// Save replaces the template of eventType and locale, once it renders with the event's
+// variables.
+func (s *TemplateService) Save(
+	ctx context.Context,
+	eventType, locale, body, updatedBy string,
+) (*domainmodel.NotificationTemplate, error) {
+	data, err := templateSampleData(eventType, locale)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(body) == "" {
+		return nil, fmt.Errorf("%w: body is required", domainErr.ErrInvalidTemplate)
+	}
+	if _, err := renderTemplate(body, data); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	t := &domainmodel.NotificationTemplate{
+		EventType: eventType,
+		Locale:    locale,
+		Body:      body,
+		Custom:    true,
+		UpdatedBy: updatedBy,
+		UpdatedAt: &now,
+	}
+	if err := s.repo.Save(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Reset drops the replacement of a template, so the built-in one applies again. Returns
+// ErrNotFound when the template was not replaced.
+func (s *TemplateService) Reset(ctx context.Context, eventType, locale string) error {
+	return s.repo.Delete(ctx, eventType, locale)
+}
+
+// Preview renders body, or the template in use when body is empty, with data, or with
+// sample variables when data is nil.
+func (s *TemplateService) Preview(
+	ctx context.Context,
+	eventType, locale, body string,
+	data map[string]string,
+) (string, error) {
+	sample, err := templateSampleData(eventType, locale)
+	if err != nil {
+		return "", err
+	}
+	if data == nil {
+		data = sample
+	}
+	if body == "" {
+		t, err := s.Get(ctx, eventType, locale)
+		if err != nil {
+			return "", err
+		}
+		body = t.Body
+	}
+	return renderTemplate(body, data)
+}
+
+// Render returns the notification's message in locale, falling back to its stored message
+// when it has no variables or its template fails.
+func (s *TemplateService) Render(ctx context.Context, notification *domainmodel.Notification, locale string) string {
+	return s.newRenderer(locale).render(ctx, notification)
+}
+
+// Localize returns copies of the notifications with their messages rendered in locale.
+func (s *TemplateService) Localize(
+	ctx context.Context,
+	notifications []*domainmodel.Notification,
+	locale string,
+) []*domainmodel.Notification {
+	r := s.newRenderer(locale)
+	localized := make([]*domainmodel.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		copied := *notification
+		copied.Message = r.render(ctx, notification)
+		localized = append(localized, &copied)
+	}
+	return localized
+}
+
+// renderer looks each template up once per locale and event type.
+type renderer struct {
+	service *TemplateService
+	locale  string
+	bodies  map[string]string
+}
+
+func (s *TemplateService) newRenderer(locale string) *renderer {
+	return &renderer{service: s, locale: s.ResolveLocale(locale), bodies: make(map[string]string)}
+}
+
+func (r *renderer) render(ctx context.Context, notification *domainmodel.Notification) string {
+	if notification.Data == nil {
+		return notification.Message
+	}
+
+	body, ok := r.bodies[notification.Type]
+	if !ok {
+		t, err := r.service.Get(ctx, notification.Type, r.locale)
+		if err != nil && !errors.Is(err, domainErr.ErrNotFound) {
+			log.Printf("Failed to load %s template of %s: %v", r.locale, notification.Type, err)
+		}
+		if t != nil {
+			body = t.Body
+		}
+		r.bodies[notification.Type] = body
+	}
+	if body == "" {
+		return notification.Message
+	}
+
+	message, err := renderTemplate(body, notification.Data)
+	if err != nil {
+		log.Printf("Failed to render notification %s in %s: %v", notification.ID, r.locale, err)
+		return notification.Message
+	}
+	return message
+}
+
+func builtinTemplate(key templateKey, body string) *domainmodel.NotificationTemplate {
+	return &domainmodel.NotificationTemplate{EventType: key.eventType, Locale: key.locale, Body: body}
+}
+
+func templateSampleData(eventType, locale string) (map[string]string, error) {
+	data, ok := sampleData[eventType]
+	if !ok {
+		return nil, fmt.Errorf("%w: event type %q has no templates", domainErr.ErrInvalidTemplate, eventType)
+	}
+	if !slices.Contains(domainmodel.Locales, locale) {
+		return nil, fmt.Errorf("%w: unsupported locale %q, expected one of %s",
+			domainErr.ErrInvalidTemplate, locale, strings.Join(domainmodel.Locales, ", "))
+	}
+	return data, nil
+}
+
+// renderTemplate fails on variables the data does not have, rather than printing "<no value>".
+func renderTemplate(body string, data map[string]string) (string, error) {
+	t, err := template.New("notification").Option("missingkey=error").Parse(body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", domainErr.ErrInvalidTemplate, err)
+	}
+	var message strings.Builder
+	if err := t.Execute(&message, data); err != nil {
+		return "", fmt.Errorf("%w: %v", domainErr.ErrInvalidTemplate, err)
+	}
+	return message.String(), nil
+}
@@ -0,0 +1,182 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kiin21/go-rest/pkg/events"
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+	domainmodel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository/mocks"
+)
+
+func TestTemplateServiceRender(t *testing.T) {
+	transfer := map[string]string{
+		"starter_name":             "Nguyen Van A",
+		"department_name":          "Engineering",
+		"previous_department_name": "Sales",
+	}
+
+	tests := []struct {
+		name         string
+		notification *domainmodel.Notification
+		locale       string
+		stored       map[string]string
+		storeErr     error
+		want         string
+	}{
+		{
+			name:         "no variables keeps the stored message",
+			notification: &domainmodel.Notification{Type: events.EventTypeNotificationDepartmentTransfer, Message: "stored"},
+			locale:       domainmodel.LocaleVietnamese,
+			want:         "stored",
+		},
+		{
+			name:         "built-in English",
+			notification: &domainmodel.Notification{Type: events.EventTypeNotificationDepartmentTransfer, Message: "stored", Data: transfer},
+			locale:       domainmodel.LocaleEnglish,
+			want:         "Nguyen Van A has moved from Sales to Engineering",
+		},
+		{
+			name:         "built-in Vietnamese",
+			notification: &domainmodel.Notification{Type: events.EventTypeNotificationDepartmentTransfer, Message: "stored", Data: transfer},
+			locale:       domainmodel.LocaleVietnamese,
+			want:         "Nguyen Van A đã chuyển từ Sales sang Engineering",
+		},
+		{
+			name:         "unsupported locale uses the default",
+			notification: &domainmodel.Notification{Type: events.EventTypeNotificationDepartmentTransfer, Message: "stored", Data: transfer},
+			locale:       "fr",
+			want:         "Nguyen Van A has moved from Sales to Engineering",
+		},
+		{
+			name:         "optional variable left out",
+			notification: &domainmodel.Notification{Type: events.EventTypeNotificationDepartmentTransfer, Message: "stored", Data: map[string]string{"starter_name": "A", "department_name": "Engineering", "previous_department_name": ""}},
+			locale:       domainmodel.LocaleEnglish,
+			want:         "A has moved to Engineering",
+		},
+		{
+			name:         "replaced template wins",
+			notification: &domainmodel.Notification{Type: events.EventTypeNotificationDepartmentTransfer, Message: "stored", Data: transfer},
+			locale:       domainmodel.LocaleVietnamese,
+			stored:       map[string]string{domainmodel.LocaleVietnamese: "{{.starter_name}} → {{.department_name}}"},
+			want:         "Nguyen Van A → Engineering",
+		},
+		{
+			name:         "missing variable keeps the stored message",
+			notification: &domainmodel.Notification{Type: events.EventTypeNotificationDepartmentTransfer, Message: "stored", Data: map[string]string{"starter_name": "A"}},
+			locale:       domainmodel.LocaleEnglish,
+			want:         "stored",
+		},
+		{
+			name:         "broken replaced template keeps the stored message",
+			notification: &domainmodel.Notification{Type: events.EventTypeNotificationDepartmentTransfer, Message: "stored", Data: transfer},
+			locale:       domainmodel.LocaleEnglish,
+			stored:       map[string]string{domainmodel.LocaleEnglish: "{{.starter_name"},
+			want:         "stored",
+		},
+		{
+			name:         "event type without templates keeps the stored message",
+			notification: &domainmodel.Notification{Type: "notification.unknown", Message: "stored", Data: transfer},
+			locale:       domainmodel.LocaleEnglish,
+			want:         "stored",
+		},
+		{
+			name:         "template store failing keeps the stored message",
+			notification: &domainmodel.Notification{Type: events.EventTypeNotificationDepartmentTransfer, Message: "stored", Data: transfer},
+			locale:       domainmodel.LocaleEnglish,
+			storeErr:     errors.New("connection refused"),
+			want:         "stored",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockTemplateRepository{
+				GetFunc: func(ctx context.Context, eventType, locale string) (*domainmodel.NotificationTemplate, error) {
+					if tt.storeErr != nil {
+						return nil, tt.storeErr
+					}
+					if body, ok := tt.stored[locale]; ok {
+						return &domainmodel.NotificationTemplate{EventType: eventType, Locale: locale, Body: body, Custom: true}, nil
+					}
+					return nil, domainErr.ErrNotFound
+				},
+			}
+			service := NewTemplateService(repo, domainmodel.LocaleEnglish)
+
+			if got := service.Render(context.Background(), tt.notification, tt.locale); got != tt.want {
+				t.Errorf("Render() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateServiceLocalize(t *testing.T) {
+	service := NewTemplateService(&mocks.MockTemplateRepository{}, domainmodel.LocaleVietnamese)
+	original := &domainmodel.Notification{
+		ID:      "n-1",
+		Type:    events.EventTypeNotificationLeaderAssignment,
+		Message: "stored",
+		Data:    map[string]string{"department_name": "Engineering", "previous_leader": ""},
+	}
+
+	localized := service.Localize(context.Background(), []*domainmodel.Notification{original, original}, "")
+
+	for _, notification := range localized {
+		if want := "Bạn đã được bổ nhiệm làm trưởng bộ phận Engineering"; notification.Message != want {
+			t.Errorf("message = %q; want %q", notification.Message, want)
+		}
+	}
+	if original.Message != "stored" {
+		t.Errorf("the original notification was changed to %q", original.Message)
+	}
+}
+
+func TestTemplateServiceSave(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		locale    string
+		body      string
+		wantErr   bool
+	}{
+		{"valid", events.EventTypeNotificationStarterCreated, domainmodel.LocaleVietnamese, "Chào {{.starter_name}}", false},
+		{"unknown variable", events.EventTypeNotificationStarterCreated, domainmodel.LocaleEnglish, "Hi {{.nickname}}", true},
+		{"syntax error", events.EventTypeNotificationStarterCreated, domainmodel.LocaleEnglish, "Hi {{.starter_name", true},
+		{"blank body", events.EventTypeNotificationStarterCreated, domainmodel.LocaleEnglish, "  ", true},
+		{"unsupported locale", events.EventTypeNotificationStarterCreated, "fr", "Salut {{.starter_name}}", true},
+		{"event type without templates", "notification.unknown", domainmodel.LocaleEnglish, "Hi", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved *domainmodel.NotificationTemplate
+			repo := &mocks.MockTemplateRepository{
+				SaveFunc: func(ctx context.Context, template *domainmodel.NotificationTemplate) error {
+					saved = template
+					return nil
+				},
+			}
+			service := NewTemplateService(repo, domainmodel.LocaleEnglish)
+
+			_, err := service.Save(context.Background(), tt.eventType, tt.locale, tt.body, "hr.admin")
+			if tt.wantErr {
+				if !errors.Is(err, domainErr.ErrInvalidTemplate) {
+					t.Errorf("expected ErrInvalidTemplate, got %v", err)
+				}
+				if saved != nil {
+					t.Error("an invalid template was saved")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if saved == nil || !saved.Custom || saved.Body != tt.body || saved.UpdatedBy != "hr.admin" {
+				t.Errorf("unexpected saved template %+v", saved)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiin21/go-rest/pkg/events"
+	domainDelivery "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/delivery"
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+	domainmodel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	domainrepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+)
+
+// minWebhookSecretLength keeps signatures from being guessed
+const minWebhookSecretLength = 16
+
+type CreateWebhookCommand struct {
+	URL        string
+	EventTypes []string
+	Secret     string
+	CreatedBy  string
+}
+
+// WebhookService forwards events to the URLs subscribed to them. Each event gets a stored
+// delivery per subscription; a background worker claims due deliveries, sends them and
+// retries failures with exponential backoff. Deliveries that run out of attempts become dead
+// letters, which stay until they are replayed by hand.
+type WebhookService struct {
+	repo   domainrepo.WebhookRepository
+	sender domainDelivery.WebhookSender
+	policy RetryPolicy
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewWebhookService(
+	repo domainrepo.WebhookRepository,
+	sender domainDelivery.WebhookSender,
+	policy RetryPolicy,
+) *WebhookService {
+	return &WebhookService{
+		repo:   repo,
+		sender: sender,
+		policy: policy,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+func (s *WebhookService) Subscribe(ctx context.Context, cmd CreateWebhookCommand) (*domainmodel.WebhookSubscription, error) {
+	if err := validateWebhookURL(ctx, cmd.URL); err != nil {
+		return nil, err
+	}
+	if len(cmd.Secret) < minWebhookSecretLength {
+		return nil, fmt.Errorf("%w: secret must be at least %d characters", domainErr.ErrInvalidWebhook, minWebhookSecretLength)
+	}
+	if len(cmd.EventTypes) == 0 {
+		return nil, fmt.Errorf("%w: at least one event type is required", domainErr.ErrInvalidWebhook)
+	}
+	eventTypes := make([]string, 0, len(cmd.EventTypes))
+	for _, eventType := range cmd.EventTypes {
+		if !slices.Contains(domainmodel.WebhookEventTypes, eventType) {
+			return nil, fmt.Errorf("%w: unknown event type %q, expected one of %s",
+				domainErr.ErrInvalidWebhook, eventType, strings.Join(domainmodel.WebhookEventTypes, ", "))
+		}
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	subscription := &domainmodel.WebhookSubscription{
+		ID:         uuid.NewString(),
+		URL:        cmd.URL,
+		EventTypes: eventTypes,
+		Secret:     cmd.Secret,
+		CreatedBy:  cmd.CreatedBy,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if err := s.repo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// validateWebhookURL accepts absolute http(s) URLs whose host is public. The sender checks the
+// address it connects to again, since the host may resolve elsewhere by then.
+func validateWebhookURL(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", domainErr.ErrInvalidWebhook)
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: url must not point to a private or local host", domainErr.ErrInvalidWebhook)
+	}
+
+	addrs := make([]netip.Addr, 0, 1)
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = append(addrs, addr)
+	} else if addrs, err = net.DefaultResolver.LookupNetIP(ctx, "ip", host); err != nil {
+		return fmt.Errorf("%w: url host %q cannot be resolved", domainErr.ErrInvalidWebhook, host)
+	}
+	for _, addr := range addrs {
+		if !domainmodel.WebhookAddressAllowed(addr) {
+			return fmt.Errorf("%w: url must not point to a private or local host", domainErr.ErrInvalidWebhook)
+		}
+	}
+	return nil
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]*domainmodel.WebhookSubscription, error) {
+	return s.repo.ListSubscriptions(ctx)
+}
+
+func (s *WebhookService) GetSubscription(ctx context.Context, id string) (*domainmodel.WebhookSubscription, error) {
+	return s.repo.GetSubscription(ctx, id)
+}
+
+// Unsubscribe deletes a subscription along with its pending deliveries and dead letters.
+func (s *WebhookService) Unsubscribe(ctx context.Context, id string) error {
+	return s.repo.DeleteSubscription(ctx, id)
+}
+
+// Dispatch queues a delivery of the event, whose envelope is payload, to every subscription
+// to its type.
+func (s *WebhookService) Dispatch(ctx context.Context, event *events.Event, payload []byte) error {
+	subscriptions, err := s.repo.ListSubscriptionsTo(ctx, event.Type)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	deliveries := make([]*domainmodel.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, domainmodel.NewWebhookDelivery(subscription.ID, event, payload, now))
+	}
+	if err := s.repo.EnqueueDeliveries(ctx, deliveries); err != nil {
+		return err
+	}
+	s.Wake()
+	return nil
+}
+
+// ListDeadLetters returns the latest dead letters of a subscription, or of all when
+// subscriptionID is empty.
+func (s *WebhookService) ListDeadLetters(
+	ctx context.Context,
+	subscriptionID string,
+	limit int,
+) ([]*domainmodel.WebhookDeadLetter, error) {
+	return s.repo.ListDeadLetters(ctx, subscriptionID, limit)
+}
+
+// ReplayDeadLetter queues the dead letter's event for delivery again, with a fresh set of
+// attempts.
+func (s *WebhookService) ReplayDeadLetter(ctx context.Context, id string) (*domainmodel.WebhookDelivery, error) {
+	letter, err := s.repo.GetDeadLetter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := letter.Replay(time.Now())
+	// The delivery is stored first, so a failure in between leaves a duplicate rather than
+	// losing the event
+	if err := s.repo.SaveDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	if err := s.repo.DeleteDeadLetter(ctx, id); err != nil {
+		return nil, err
+	}
+	s.Wake()
+	return delivery, nil
+}
+
+// Wake makes the worker look for due deliveries now rather than at its next poll.
+func (s *WebhookService) Wake() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *WebhookService) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.policy.PollInterval)
+		defer ticker.Stop()
+		for {
+			s.deliverDue(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-s.wake:
+			}
+		}
+	}()
+	log.Println("Webhook delivery worker started")
+}
+
+// Stop waits for the attempt in progress to finish.
+func (s *WebhookService) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *WebhookService) deliverDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		delivery, err := s.repo.ClaimDueDelivery(ctx, time.Now(), 2*s.policy.SendTimeout)
+		if errors.Is(err, domainErr.ErrNotFound) {
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to claim webhook deliveries: %v", err)
+			return
+		}
+		s.deliver(ctx, delivery)
+	}
+}
+
+func (s *WebhookService) deliver(ctx context.Context, delivery *domainmodel.WebhookDelivery) {
+	// The outcome must be kept even when shutting down, or the event is sent again
+	saveCtx := context.WithoutCancel(ctx)
+
+	subscription, err := s.repo.GetSubscription(ctx, delivery.SubscriptionID)
+	if errors.Is(err, domainErr.ErrNotFound) {
+		if err := s.repo.DeleteDelivery(saveCtx, delivery.ID); err != nil {
+			log.Printf("Failed to drop webhook delivery %s of a deleted subscription: %v", delivery.ID, err)
+		}
+		return
+	}
+	if err != nil {
+		// The claim runs out and the delivery is tried again
+		log.Printf("Failed to load subscription of webhook delivery %s: %v", delivery.ID, err)
+		return
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, s.policy.SendTimeout)
+	err = s.sender.Send(sendCtx, subscription, delivery)
+	cancel()
+
+	now := time.Now()
+	if err == nil {
+		delivery.MarkSent(now)
+		if err := s.repo.SaveDelivery(saveCtx, delivery); err != nil {
+			log.Printf("Failed to save webhook delivery %s: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	wait, retry := s.policy.retryAfter(delivery.Attempts)
+	if retry && !domainDelivery.IsPermanent(err) {
+		at := now.Add(wait)
+		delivery.MarkFailed(err, &at, now)
+		log.Printf("Failed to deliver webhook %s to %s (attempt %d, retry at %s): %v",
+			delivery.ID, subscription.URL, delivery.Attempts, at.Format(time.RFC3339), err)
+		if err := s.repo.SaveDelivery(saveCtx, delivery); err != nil {
+			log.Printf("Failed to save webhook delivery %s: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	delivery.MarkFailed(err, nil, now)
+	log.Printf("Giving up webhook delivery %s to %s after %d attempt(s): %v",
+		delivery.ID, subscription.URL, delivery.Attempts, err)
+	// The dead letter is stored first, so a failure in between leaves the delivery to be
+	// claimed again rather than losing it
+	if err := s.repo.SaveDeadLetter(saveCtx, delivery.DeadLetter(now)); err != nil {
+		log.Printf("Failed to dead-letter webhook delivery %s: %v", delivery.ID, err)
+		return
+	}
+	if err := s.repo.DeleteDelivery(saveCtx, delivery.ID); err != nil {
+		log.Printf("Failed to remove dead-lettered webhook delivery %s: %v", delivery.ID, err)
+	}
+}
@@ -0,0 +1,47 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+)
+
+func TestValidateWebhookURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://93.184.216.34/hooks/starters", false},
+		{"http://[2606:4700::6810:84e5]:8080/hook", false},
+		{"ftp://93.184.216.34/hook", true},
+		{"/hooks/starters", true},
+		{"https://", true},
+		{"http://localhost:8080/hook", true},
+		{"http://LOCALHOST./hook", true},
+		{"http://hooks.localhost/hook", true},
+		{"http://127.0.0.1/hook", true},
+		{"http://10.0.0.12/hook", true},
+		{"http://192.168.1.20/hook", true},
+		{"http://169.254.169.254/latest/meta-data", true},
+		{"http://[::1]/hook", true},
+		{"http://[fc00::1]/hook", true},
+		{"http://[::ffff:127.0.0.1]/hook", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := validateWebhookURL(context.Background(), tt.url)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, domainErr.ErrInvalidWebhook) {
+				t.Errorf("expected ErrInvalidWebhook, got %v", err)
+			}
+		})
+	}
+}
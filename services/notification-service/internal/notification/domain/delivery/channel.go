@@ -0,0 +1,52 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+// ErrNoAddress means the recipient cannot be reached through a channel.
+var ErrNoAddress = errors.New("recipient has no address")
+
+// Channel sends notifications out of the service, e.g. by email.
+type Channel interface {
+	// Name identifies the channel in a notification's deliveries
+	Name() string
+	Send(ctx context.Context, notification *model.Notification) error
+}
+
+// DigestSender is a Channel that can also send many notifications to a recipient at once.
+type DigestSender interface {
+	Channel
+	SendDigest(ctx context.Context, recipient string, notifications []*model.Notification) error
+}
+
+// AddressResolver finds a starter's email address by their domain. Returns ErrNoAddress when
+// the starter has none.
+type AddressResolver interface {
+	ResolveEmail(ctx context.Context, domain string) (string, error)
+}
+
+// PermanentError marks a send error that retrying will not fix.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+func Permanent(err error) error {
+	return &PermanentError{Err: err}
+}
+
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent) || errors.Is(err, ErrNoAddress)
+}
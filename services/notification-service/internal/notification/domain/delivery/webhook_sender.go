@@ -0,0 +1,13 @@
+package delivery
+
+import (
+	"context"
+
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+// WebhookSender POSTs a delivery's payload to its subscription's URL. Errors the receiver
+// will keep returning are Permanent.
+type WebhookSender interface {
+	Send(ctx context.Context, subscription *model.WebhookSubscription, delivery *model.WebhookDelivery) error
+}
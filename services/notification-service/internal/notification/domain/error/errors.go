@@ -0,0 +1,14 @@
+package error
+
+import "errors"
+
+// Common domain errors
+var (
+	ErrNotFound = errors.New("resource not found")
+	// ErrInvalidWebhook wraps the reason a webhook subscription was rejected
+	ErrInvalidWebhook = errors.New("invalid webhook subscription")
+	// ErrInvalidTemplate wraps the reason a notification template was rejected
+	ErrInvalidTemplate = errors.New("invalid notification template")
+	// ErrInvalidPreferences wraps the reason notification preferences were rejected
+	ErrInvalidPreferences = errors.New("invalid notification preferences")
+)
@@ -0,0 +1,14 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+// NotificationPublisher announces stored notifications to every instance of the service, so
+// that each can push them to the recipients streaming from it.
+type NotificationPublisher interface {
+	Publish(ctx context.Context, notification *model.Notification) error
+	Close() error
+}
@@ -0,0 +1,66 @@
+package model
+
+import "time"
+
+type DeliveryStatus string
+
+const (
+	// DeliveryPending waits for its next attempt
+	DeliveryPending DeliveryStatus = "pending"
+	// DeliverySending is claimed by an instance that is sending it
+	DeliverySending DeliveryStatus = "sending"
+	DeliverySent    DeliveryStatus = "sent"
+	// DeliveryFailed gave up: the error was permanent or the attempts ran out
+	DeliveryFailed DeliveryStatus = "failed"
+	// DeliveryDigest waits to be sent with the recipient's next digest
+	DeliveryDigest DeliveryStatus = "digest"
+)
+
+// Delivery is the state of sending a notification through one channel.
+type Delivery struct {
+	Status   DeliveryStatus
+	Attempts int
+	// LastError is the error of the latest failed attempt
+	LastError string
+	// NextAttemptAt is when a pending delivery is due, or when the claim of a sending one
+	// expires
+	NextAttemptAt *time.Time
+	DeliveredAt   *time.Time
+	UpdatedAt     time.Time
+}
+
+func NewPendingDelivery(now time.Time) *Delivery {
+	return &Delivery{
+		Status:        DeliveryPending,
+		NextAttemptAt: &now,
+		UpdatedAt:     now,
+	}
+}
+
+// NewDigestDelivery is left for the recipient's digest rather than sent on its own.
+func NewDigestDelivery(now time.Time) *Delivery {
+	return &Delivery{
+		Status:    DeliveryDigest,
+		UpdatedAt: now,
+	}
+}
+
+func (d *Delivery) MarkSent(now time.Time) {
+	d.Status = DeliverySent
+	d.LastError = ""
+	d.NextAttemptAt = nil
+	d.DeliveredAt = &now
+	d.UpdatedAt = now
+}
+
+// MarkFailed records a failed attempt. The delivery is retried at retryAt, or given up when
+// retryAt is nil.
+func (d *Delivery) MarkFailed(err error, retryAt *time.Time, now time.Time) {
+	d.Status = DeliveryPending
+	if retryAt == nil {
+		d.Status = DeliveryFailed
+	}
+	d.LastError = err.Error()
+	d.NextAttemptAt = retryAt
+	d.UpdatedAt = now
+}
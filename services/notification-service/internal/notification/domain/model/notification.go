@@ -8,7 +8,52 @@ type Notification struct {
 	ID          string
 	FromStarter string
 	ToStarter   string
-	Message     string
-	Type        string
-	Timestamp   time.Time
+	// Message is rendered in the default locale when the notification is stored
+	Message   string
+	Type      string
+	Timestamp time.Time
+	// Data holds the template variables of the event, so the message can be rendered again
+	// in the reader's locale
+	Data map[string]string
+	// ReadAt is when the recipient marked the notification as read, nil while unread
+	ReadAt *time.Time
+	// Deliveries tracks sending the notification out, by channel name
+	Deliveries map[string]*Delivery
+}
+
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// Subject returns what the notification is about: the starter, or for leader assignments
+// the department, and the message itself for notifications without variables. Notifications
+// of one type to one recipient about one subject say the same thing.
+func (n *Notification) Subject() string {
+	if domain := n.Data["starter_domain"]; domain != "" {
+		return "starter:" + domain
+	}
+	if department := n.Data["department_id"]; department != "" {
+		return "department:" + department
+	}
+	return "message:" + n.Message
+}
+
+// DedupeKey identifies the notifications that say the same thing to the same recipient.
+func (n *Notification) DedupeKey() string {
+	return n.Type + "|" + n.ToStarter + "|" + n.Subject()
+}
+
+// CursorKey returns the notification's value for a list sort field and its id, in the form
+// kept in pagination cursors.
+func (n *Notification) CursorKey(sortBy string) (value, id string) {
+	switch sortBy {
+	case "from":
+		return n.FromStarter, n.ID
+	case "to":
+		return n.ToStarter, n.ID
+	case "type":
+		return n.Type, n.ID
+	default:
+		return n.Timestamp.Format(time.RFC3339Nano), n.ID
+	}
 }
@@ -0,0 +1,76 @@
+package model
+
+import "testing"
+
+func TestNotificationDedupeKey(t *testing.T) {
+	tests := []struct {
+		name         string
+		notification *Notification
+		wantSubject  string
+		wantKey      string
+	}{
+		{
+			name: "about a starter",
+			notification: &Notification{
+				Type:      "notification.starter_created",
+				ToStarter: "line.manager",
+				Data:      map[string]string{"starter_domain": "new.starter", "department_id": "12"},
+			},
+			wantSubject: "starter:new.starter",
+			wantKey:     "notification.starter_created|line.manager|starter:new.starter",
+		},
+		{
+			name: "about a department",
+			notification: &Notification{
+				Type:      "notification.leader_assignment",
+				ToStarter: "new.leader",
+				Data:      map[string]string{"department_id": "12", "department_name": "Engineering"},
+			},
+			wantSubject: "department:12",
+			wantKey:     "notification.leader_assignment|new.leader|department:12",
+		},
+		{
+			name: "without variables",
+			notification: &Notification{
+				Type:      "notification.leader_assignment",
+				ToStarter: "new.leader",
+				Message:   "You have been assigned as leader of Engineering",
+			},
+			wantSubject: "message:You have been assigned as leader of Engineering",
+			wantKey:     "notification.leader_assignment|new.leader|message:You have been assigned as leader of Engineering",
+		},
+		{
+			name: "empty variables fall through",
+			notification: &Notification{
+				Type:      "notification.leader_assignment",
+				ToStarter: "new.leader",
+				Message:   "hello",
+				Data:      map[string]string{"starter_domain": "", "department_id": ""},
+			},
+			wantSubject: "message:hello",
+			wantKey:     "notification.leader_assignment|new.leader|message:hello",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.notification.Subject(); got != tt.wantSubject {
+				t.Errorf("Subject() = %q; want %q", got, tt.wantSubject)
+			}
+			if got := tt.notification.DedupeKey(); got != tt.wantKey {
+				t.Errorf("DedupeKey() = %q; want %q", got, tt.wantKey)
+			}
+		})
+	}
+
+	// The same event from another producer, or for another recipient, differs only where it should
+	a := &Notification{ID: "a", Type: "notification.starter_deleted", ToStarter: "m", Data: map[string]string{"starter_domain": "s"}}
+	b := &Notification{ID: "b", Type: "notification.starter_deleted", ToStarter: "m", Data: map[string]string{"starter_domain": "s"}, Message: "other text"}
+	c := &Notification{ID: "c", Type: "notification.starter_deleted", ToStarter: "n", Data: map[string]string{"starter_domain": "s"}}
+	if a.DedupeKey() != b.DedupeKey() {
+		t.Error("notifications saying the same have different keys")
+	}
+	if a.DedupeKey() == c.DedupeKey() {
+		t.Error("notifications to different recipients share a key")
+	}
+}
@@ -0,0 +1,196 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kiin21/go-rest/pkg/events"
+)
+
+// Channels a recipient can turn on or off per event type
+const (
+	ChannelInApp   = "in_app"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+// NotificationEventTypes lists the event types that notify a recipient.
+var NotificationEventTypes = []string{
+	events.EventTypeNotificationLeaderAssignment,
+	events.EventTypeNotificationStarterCreated,
+	events.EventTypeNotificationLineManagerChanged,
+	events.EventTypeNotificationDepartmentTransfer,
+	events.EventTypeNotificationStarterDeleted,
+}
+
+type DigestFrequency string
+
+const (
+	// DigestOff emails each notification as it arrives
+	DigestOff    DigestFrequency = "off"
+	DigestDaily  DigestFrequency = "daily"
+	DigestWeekly DigestFrequency = "weekly"
+)
+
+const (
+	DefaultTimezone = "Asia/Ho_Chi_Minh"
+	DefaultDigestAt = "08:00"
+	// clockLayout is the layout of quiet hours and digest times
+	clockLayout = "15:04"
+)
+
+// ChannelSettings says through which channels notifications of one event type reach the
+// recipient. Without in-app, notifications are still kept, but arrive already read and are
+// not pushed to open streams.
+type ChannelSettings struct {
+	InApp   bool
+	Email   bool
+	Webhook bool
+}
+
+var DefaultChannelSettings = ChannelSettings{InApp: true, Email: true, Webhook: true}
+
+// Enabled reports whether the channel is on; channels without a setting are always on.
+func (c ChannelSettings) Enabled(channel string) bool {
+	switch channel {
+	case ChannelInApp:
+		return c.InApp
+	case ChannelEmail:
+		return c.Email
+	case ChannelWebhook:
+		return c.Webhook
+	default:
+		return true
+	}
+}
+
+// QuietHours is a daily window, in the recipient's timezone, in which no email is sent. End
+// before Start spans midnight.
+type QuietHours struct {
+	Start string
+	End   string
+}
+
+// Preferences are a recipient's notification settings.
+type Preferences struct {
+	Recipient string
+	// EventTypes holds the channel settings per event type; other types use the defaults
+	EventTypes map[string]ChannelSettings
+	QuietHours *QuietHours
+	// Digest batches emails into one sent at DigestAt, on Mondays for weekly digests
+	Digest   DigestFrequency
+	DigestAt string
+	// Timezone is the IANA name quiet hours and digest times are in
+	Timezone string
+	// NextDigestAt is when the next digest is due, nil without digest
+	NextDigestAt *time.Time
+	UpdatedAt    time.Time
+}
+
+// DefaultPreferences apply to recipients who have not saved any.
+func DefaultPreferences(recipient string) *Preferences {
+	return &Preferences{
+		Recipient:  recipient,
+		EventTypes: map[string]ChannelSettings{},
+		Digest:     DigestOff,
+		DigestAt:   DefaultDigestAt,
+		Timezone:   DefaultTimezone,
+	}
+}
+
+func (p *Preferences) Settings(eventType string) ChannelSettings {
+	if settings, ok := p.EventTypes[eventType]; ok {
+		return settings
+	}
+	return DefaultChannelSettings
+}
+
+func (p *Preferences) DigestEnabled() bool {
+	return p.Digest == DigestDaily || p.Digest == DigestWeekly
+}
+
+// Validate returns the first setting that is not valid.
+func (p *Preferences) Validate() error {
+	for eventType := range p.EventTypes {
+		if !slices.Contains(NotificationEventTypes, eventType) {
+			return fmt.Errorf("unknown event type %q", eventType)
+		}
+	}
+	if _, err := time.LoadLocation(p.Timezone); err != nil {
+		return fmt.Errorf("unknown timezone %q", p.Timezone)
+	}
+	switch p.Digest {
+	case DigestOff, DigestDaily, DigestWeekly:
+	default:
+		return fmt.Errorf("digest must be one of %s, %s or %s", DigestOff, DigestDaily, DigestWeekly)
+	}
+	if _, err := time.Parse(clockLayout, p.DigestAt); err != nil {
+		return fmt.Errorf("digest_at must be a time like 08:00")
+	}
+	if p.QuietHours != nil {
+		start, startErr := time.Parse(clockLayout, p.QuietHours.Start)
+		end, endErr := time.Parse(clockLayout, p.QuietHours.End)
+		if startErr != nil || endErr != nil {
+			return fmt.Errorf("quiet hours must be times like 22:00")
+		}
+		if start.Equal(end) {
+			return fmt.Errorf("quiet hours must not start and end at the same time")
+		}
+	}
+	return nil
+}
+
+// QuietUntil returns the end of the quiet hours t falls in, or false outside quiet hours.
+func (p *Preferences) QuietUntil(t time.Time) (time.Time, bool) {
+	if p.QuietHours == nil {
+		return time.Time{}, false
+	}
+	local := t.In(p.location())
+	start := clockOn(local, p.QuietHours.Start)
+	end := clockOn(local, p.QuietHours.End)
+
+	if start.Before(end) {
+		if !local.Before(start) && local.Before(end) {
+			return end, true
+		}
+		return time.Time{}, false
+	}
+	// The window spans midnight: quiet until end today, or from start until end tomorrow
+	if local.Before(end) {
+		return end, true
+	}
+	if !local.Before(start) {
+		return end.AddDate(0, 0, 1), true
+	}
+	return time.Time{}, false
+}
+
+// NextDigest returns when the digest following t is due.
+func (p *Preferences) NextDigest(t time.Time) time.Time {
+	local := t.In(p.location())
+	next := clockOn(local, p.DigestAt)
+	if !next.After(local) {
+		next = next.AddDate(0, 0, 1)
+	}
+	if p.Digest == DigestWeekly {
+		for next.Weekday() != time.Monday {
+			next = next.AddDate(0, 0, 1)
+		}
+	}
+	return next
+}
+
+func (p *Preferences) location() *time.Location {
+	location, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// clockOn returns the time of day clock on the day of t, in t's location.
+func clockOn(t time.Time, clock string) time.Time {
+	c, _ := time.Parse(clockLayout, clock)
+	return time.Date(t.Year(), t.Month(), t.Day(), c.Hour(), c.Minute(), 0, 0, t.Location())
+}
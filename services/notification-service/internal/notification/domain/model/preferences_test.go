@@ -0,0 +1,143 @@
+package model
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+// at returns the time clock on 2026-03-<day> in the named zone.
+func at(t *testing.T, zone string, day, hour, minute int) time.Time {
+	t.Helper()
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		t.Fatalf("failed to load %s: %v", zone, err)
+	}
+	return time.Date(2026, time.March, day, hour, minute, 0, 0, location)
+}
+
+func TestPreferencesQuietUntil(t *testing.T) {
+	const hcm = "Asia/Ho_Chi_Minh"
+
+	tests := []struct {
+		name      string
+		quiet     *QuietHours
+		timezone  string
+		t         time.Time
+		wantUntil time.Time
+		wantQuiet bool
+	}{
+		{"no quiet hours", nil, hcm, at(t, hcm, 10, 23, 0), time.Time{}, false},
+
+		{"same day, inside", &QuietHours{"12:00", "13:30"}, hcm, at(t, hcm, 10, 12, 45), at(t, hcm, 10, 13, 30), true},
+		{"same day, at start", &QuietHours{"12:00", "13:30"}, hcm, at(t, hcm, 10, 12, 0), at(t, hcm, 10, 13, 30), true},
+		{"same day, at end", &QuietHours{"12:00", "13:30"}, hcm, at(t, hcm, 10, 13, 30), time.Time{}, false},
+		{"same day, before", &QuietHours{"12:00", "13:30"}, hcm, at(t, hcm, 10, 11, 59), time.Time{}, false},
+
+		{"across midnight, evening", &QuietHours{"22:00", "07:00"}, hcm, at(t, hcm, 10, 23, 30), at(t, hcm, 11, 7, 0), true},
+		{"across midnight, at start", &QuietHours{"22:00", "07:00"}, hcm, at(t, hcm, 10, 22, 0), at(t, hcm, 11, 7, 0), true},
+		{"across midnight, after midnight", &QuietHours{"22:00", "07:00"}, hcm, at(t, hcm, 11, 2, 0), at(t, hcm, 11, 7, 0), true},
+		{"across midnight, at end", &QuietHours{"22:00", "07:00"}, hcm, at(t, hcm, 11, 7, 0), time.Time{}, false},
+		{"across midnight, daytime", &QuietHours{"22:00", "07:00"}, hcm, at(t, hcm, 10, 21, 59), time.Time{}, false},
+		{"across midnight, end of month", &QuietHours{"22:00", "07:00"}, hcm, at(t, hcm, 31, 23, 0), at(t, hcm, 31, 23, 0).Add(8 * time.Hour), true},
+
+		// 15:30 UTC is 22:30 in Ho Chi Minh City
+		{"window in the recipient's zone", &QuietHours{"22:00", "07:00"}, hcm, at(t, "UTC", 10, 15, 30), at(t, hcm, 11, 7, 0), true},
+		{"same instant in UTC", &QuietHours{"22:00", "07:00"}, "UTC", at(t, "UTC", 10, 15, 30), time.Time{}, false},
+		{"unknown zone is UTC", &QuietHours{"22:00", "07:00"}, "Mars/Olympus", at(t, "UTC", 10, 23, 0), at(t, "UTC", 11, 7, 0), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Preferences{QuietHours: tt.quiet, Timezone: tt.timezone}
+
+			until, quiet := p.QuietUntil(tt.t)
+			if quiet != tt.wantQuiet || !until.Equal(tt.wantUntil) {
+				t.Errorf("QuietUntil(%v) = %v, %v; want %v, %v", tt.t, until, quiet, tt.wantUntil, tt.wantQuiet)
+			}
+		})
+	}
+}
+
+func TestPreferencesNextDigest(t *testing.T) {
+	const hcm = "Asia/Ho_Chi_Minh"
+
+	tests := []struct {
+		name   string
+		digest DigestFrequency
+		t      time.Time
+		want   time.Time
+	}{
+		{"daily, before the time", DigestDaily, at(t, hcm, 10, 7, 59), at(t, hcm, 10, 8, 0)},
+		{"daily, at the time", DigestDaily, at(t, hcm, 10, 8, 0), at(t, hcm, 11, 8, 0)},
+		{"daily, after the time", DigestDaily, at(t, hcm, 10, 9, 0), at(t, hcm, 11, 8, 0)},
+		// 23:00 UTC on the 9th is already 06:00 on the 10th in Ho Chi Minh City
+		{"daily, in the recipient's zone", DigestDaily, at(t, "UTC", 9, 23, 0), at(t, hcm, 10, 8, 0)},
+		{"weekly, Monday before the time", DigestWeekly, at(t, hcm, 9, 7, 0), at(t, hcm, 9, 8, 0)},
+		{"weekly, Monday after the time", DigestWeekly, at(t, hcm, 9, 9, 0), at(t, hcm, 16, 8, 0)},
+		{"weekly, Sunday", DigestWeekly, at(t, hcm, 15, 20, 0), at(t, hcm, 16, 8, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Preferences{Digest: tt.digest, DigestAt: DefaultDigestAt, Timezone: hcm}
+
+			if got := p.NextDigest(tt.t); !got.Equal(tt.want) {
+				t.Errorf("NextDigest(%v) = %v; want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreferencesSettings(t *testing.T) {
+	p := DefaultPreferences("thanhnt")
+	p.EventTypes[NotificationEventTypes[0]] = ChannelSettings{InApp: true}
+
+	tests := []struct {
+		eventType string
+		channel   string
+		want      bool
+	}{
+		{NotificationEventTypes[0], ChannelInApp, true},
+		{NotificationEventTypes[0], ChannelEmail, false},
+		{NotificationEventTypes[0], ChannelWebhook, false},
+		{NotificationEventTypes[0], "sms", true},
+		{NotificationEventTypes[1], ChannelEmail, true},
+		{NotificationEventTypes[1], ChannelWebhook, true},
+	}
+
+	for _, tt := range tests {
+		if got := p.Settings(tt.eventType).Enabled(tt.channel); got != tt.want {
+			t.Errorf("%s via %s enabled = %v; want %v", tt.eventType, tt.channel, got, tt.want)
+		}
+	}
+}
+
+func TestPreferencesValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(p *Preferences)
+		wantErr bool
+	}{
+		{"defaults", func(p *Preferences) {}, false},
+		{"quiet hours across midnight", func(p *Preferences) { p.QuietHours = &QuietHours{"22:00", "07:00"} }, false},
+		{"weekly digest", func(p *Preferences) { p.Digest, p.DigestAt = DigestWeekly, "17:30" }, false},
+		{"unknown event type", func(p *Preferences) { p.EventTypes["notification.unknown"] = DefaultChannelSettings }, true},
+		{"unknown timezone", func(p *Preferences) { p.Timezone = "Mars/Olympus" }, true},
+		{"unknown digest", func(p *Preferences) { p.Digest = "hourly" }, true},
+		{"bad digest time", func(p *Preferences) { p.DigestAt = "8am" }, true},
+		{"bad quiet hours", func(p *Preferences) { p.QuietHours = &QuietHours{"22:00", "25:00"} }, true},
+		{"empty quiet hours window", func(p *Preferences) { p.QuietHours = &QuietHours{"22:00", "22:00"} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := DefaultPreferences("thanhnt")
+			tt.change(p)
+
+			if err := p.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v; wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// Locales the notification texts are written in
+const (
+	LocaleEnglish    = "en"
+	LocaleVietnamese = "vi"
+)
+
+var Locales = []string{LocaleEnglish, LocaleVietnamese}
+
+// NotificationTemplate renders the message of one event type in one locale. Body is a Go
+// text/template over the notification's data, e.g. "{{.department_name}}".
+type NotificationTemplate struct {
+	EventType string
+	Locale    string
+	Body      string
+	// Custom is false for the built-in templates, which apply until replaced
+	Custom    bool
+	UpdatedBy string
+	UpdatedAt *time.Time
+}
@@ -0,0 +1,133 @@
+package model
+
+import (
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/kiin21/go-rest/pkg/events"
+)
+
+// WebhookEventTypes lists the event types a webhook may subscribe to.
+var WebhookEventTypes = []string{
+	events.EventTypeNotificationLeaderAssignment,
+	events.EventTypeNotificationStarterCreated,
+	events.EventTypeNotificationLineManagerChanged,
+	events.EventTypeNotificationDepartmentTransfer,
+	events.EventTypeNotificationStarterDeleted,
+	events.EventTypeStarterInsert,
+	events.EventTypeStarterUpdate,
+	events.EventTypeStarterIndex,
+	events.EventTypeStarterDelete,
+}
+
+// webhookReservedPrefixes are public looking ranges that still never lead outside: "this
+// network", carrier-grade NAT and benchmarking.
+var webhookReservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+}
+
+// WebhookAddressAllowed reports whether webhooks may be sent to addr. Only public unicast
+// addresses are, so that a subscription cannot reach this host, the cloud metadata endpoint or
+// anything else on the internal network.
+func WebhookAddressAllowed(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range webhookReservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// WebhookSubscription asks for events of the given types to be POSTed to URL, signed with
+// Secret.
+type WebhookSubscription struct {
+	ID         string
+	URL        string
+	EventTypes []string
+	Secret     string
+	CreatedBy  string
+	CreatedAt  time.Time
+}
+
+func (s *WebhookSubscription) Subscribes(eventType string) bool {
+	return slices.Contains(s.EventTypes, eventType)
+}
+
+// WebhookDelivery is one event on its way to one subscription. Its ID is derived from both,
+// so an event consumed twice is still delivered once, and receivers can use it to drop
+// duplicates of their own.
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	// Payload is the events.Event envelope, sent as the request body
+	Payload   []byte
+	CreatedAt time.Time
+	Delivery
+}
+
+func WebhookDeliveryID(subscriptionID, eventID string) string {
+	return subscriptionID + ":" + eventID
+}
+
+func NewWebhookDelivery(subscriptionID string, event *events.Event, payload []byte, now time.Time) *WebhookDelivery {
+	return &WebhookDelivery{
+		ID:             WebhookDeliveryID(subscriptionID, event.ID.String()),
+		SubscriptionID: subscriptionID,
+		EventID:        event.ID.String(),
+		EventType:      event.Type,
+		Payload:        payload,
+		CreatedAt:      now,
+		Delivery:       *NewPendingDelivery(now),
+	}
+}
+
+// DeadLetter is kept in place of the delivery once its attempts ran out.
+func (d *WebhookDelivery) DeadLetter(now time.Time) *WebhookDeadLetter {
+	return &WebhookDeadLetter{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Attempts:       d.Attempts,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		FailedAt:       now,
+	}
+}
+
+// WebhookDeadLetter is a webhook delivery that was given up, kept until it is replayed or
+// its subscription is deleted.
+type WebhookDeadLetter struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	Payload        []byte
+	Attempts       int
+	LastError      string
+	CreatedAt      time.Time
+	FailedAt       time.Time
+}
+
+// Replay returns a new delivery of the same event, with a fresh set of attempts.
+func (l *WebhookDeadLetter) Replay(now time.Time) *WebhookDelivery {
+	return &WebhookDelivery{
+		ID:             l.ID,
+		SubscriptionID: l.SubscriptionID,
+		EventID:        l.EventID,
+		EventType:      l.EventType,
+		Payload:        l.Payload,
+		CreatedAt:      l.CreatedAt,
+		Delivery:       *NewPendingDelivery(now),
+	}
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type NotificationArchiveRepository interface {
+	// Archive moves up to limit notifications from before cutoff, oldest first, to the
+	// archive and returns how many were moved. Notifications still waiting to be sent
+	// through one of channels are kept.
+	Archive(ctx context.Context, cutoff time.Time, channels []string, limit int) (int, error)
+}
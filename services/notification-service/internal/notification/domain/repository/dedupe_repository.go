@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// NotificationDedupeRepository keeps which notification last said something, so that other
+// producers' notifications saying the same soon after are dropped.
+type NotificationDedupeRepository interface {
+	// Claim takes key for the notification with id, sent at, until at+window. Returns false
+	// when another notification holds key past at. Claiming a key again for the same
+	// notification succeeds, so that a redelivered event is not taken for a duplicate.
+	Claim(ctx context.Context, key, id string, at time.Time, window time.Duration) (bool, error)
+}
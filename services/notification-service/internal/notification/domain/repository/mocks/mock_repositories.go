@@ -0,0 +1,302 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/kiin21/go-rest/pkg/httputil"
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+)
+
+// MockNotificationRepository is a mock implementation of NotificationRepository
+type MockNotificationRepository struct {
+	ListFunc             func(ctx context.Context, filter repository.ListNotificationsFilter, pagination httputil.ReqPagination) ([]*model.Notification, int64, error)
+	CreateFunc           func(ctx context.Context, notification *model.Notification) (bool, error)
+	MarkReadFunc         func(ctx context.Context, id, toStarter string, readAt time.Time) (*model.Notification, error)
+	MarkAllReadFunc      func(ctx context.Context, toStarter string, readAt time.Time) (int64, error)
+	CountUnreadFunc      func(ctx context.Context, toStarter string) (int64, error)
+	ListAfterFunc        func(ctx context.Context, toStarter, afterID string, limit int) ([]*model.Notification, error)
+	ClaimDueDeliveryFunc func(ctx context.Context, channel string, now time.Time, lease time.Duration) (*model.Notification, error)
+	SaveDeliveryFunc     func(ctx context.Context, id, channel string, delivery *model.Delivery) error
+	ListDigestFunc       func(ctx context.Context, channel, toStarter string, limit int) ([]*model.Notification, error)
+	MarkDigestSentFunc   func(ctx context.Context, channel string, ids []string, sentAt time.Time) error
+}
+
+func (m *MockNotificationRepository) List(ctx context.Context, filter repository.ListNotificationsFilter, pagination httputil.ReqPagination) ([]*model.Notification, int64, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter, pagination)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockNotificationRepository) Create(ctx context.Context, notification *model.Notification) (bool, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, notification)
+	}
+	return true, nil
+}
+
+func (m *MockNotificationRepository) MarkRead(ctx context.Context, id, toStarter string, readAt time.Time) (*model.Notification, error) {
+	if m.MarkReadFunc != nil {
+		return m.MarkReadFunc(ctx, id, toStarter, readAt)
+	}
+	return nil, nil
+}
+
+func (m *MockNotificationRepository) MarkAllRead(ctx context.Context, toStarter string, readAt time.Time) (int64, error) {
+	if m.MarkAllReadFunc != nil {
+		return m.MarkAllReadFunc(ctx, toStarter, readAt)
+	}
+	return 0, nil
+}
+
+func (m *MockNotificationRepository) CountUnread(ctx context.Context, toStarter string) (int64, error) {
+	if m.CountUnreadFunc != nil {
+		return m.CountUnreadFunc(ctx, toStarter)
+	}
+	return 0, nil
+}
+
+func (m *MockNotificationRepository) ListAfter(ctx context.Context, toStarter, afterID string, limit int) ([]*model.Notification, error) {
+	if m.ListAfterFunc != nil {
+		return m.ListAfterFunc(ctx, toStarter, afterID, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockNotificationRepository) ClaimDueDelivery(ctx context.Context, channel string, now time.Time, lease time.Duration) (*model.Notification, error) {
+	if m.ClaimDueDeliveryFunc != nil {
+		return m.ClaimDueDeliveryFunc(ctx, channel, now, lease)
+	}
+	return nil, nil
+}
+
+func (m *MockNotificationRepository) SaveDelivery(ctx context.Context, id, channel string, delivery *model.Delivery) error {
+	if m.SaveDeliveryFunc != nil {
+		return m.SaveDeliveryFunc(ctx, id, channel, delivery)
+	}
+	return nil
+}
+
+func (m *MockNotificationRepository) ListDigest(ctx context.Context, channel, toStarter string, limit int) ([]*model.Notification, error) {
+	if m.ListDigestFunc != nil {
+		return m.ListDigestFunc(ctx, channel, toStarter, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockNotificationRepository) MarkDigestSent(ctx context.Context, channel string, ids []string, sentAt time.Time) error {
+	if m.MarkDigestSentFunc != nil {
+		return m.MarkDigestSentFunc(ctx, channel, ids, sentAt)
+	}
+	return nil
+}
+
+// MockTemplateRepository is a mock implementation of TemplateRepository; without GetFunc no
+// template is replaced.
+type MockTemplateRepository struct {
+	ListFunc   func(ctx context.Context) ([]*model.NotificationTemplate, error)
+	GetFunc    func(ctx context.Context, eventType, locale string) (*model.NotificationTemplate, error)
+	SaveFunc   func(ctx context.Context, template *model.NotificationTemplate) error
+	DeleteFunc func(ctx context.Context, eventType, locale string) error
+}
+
+func (m *MockTemplateRepository) List(ctx context.Context) ([]*model.NotificationTemplate, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockTemplateRepository) Get(ctx context.Context, eventType, locale string) (*model.NotificationTemplate, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, eventType, locale)
+	}
+	return nil, domainErr.ErrNotFound
+}
+
+func (m *MockTemplateRepository) Save(ctx context.Context, template *model.NotificationTemplate) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, template)
+	}
+	return nil
+}
+
+func (m *MockTemplateRepository) Delete(ctx context.Context, eventType, locale string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, eventType, locale)
+	}
+	return nil
+}
+
+// MockNotificationArchiveRepository is a mock implementation of NotificationArchiveRepository
+type MockNotificationArchiveRepository struct {
+	ArchiveFunc func(ctx context.Context, cutoff time.Time, channels []string, limit int) (int, error)
+}
+
+func (m *MockNotificationArchiveRepository) Archive(ctx context.Context, cutoff time.Time, channels []string, limit int) (int, error) {
+	if m.ArchiveFunc != nil {
+		return m.ArchiveFunc(ctx, cutoff, channels, limit)
+	}
+	return 0, nil
+}
+
+// MockPreferenceRepository is a mock implementation of PreferenceRepository; without GetFunc
+// no recipient has saved preferences.
+type MockPreferenceRepository struct {
+	GetFunc            func(ctx context.Context, recipient string) (*model.Preferences, error)
+	SaveFunc           func(ctx context.Context, preferences *model.Preferences) error
+	ClaimDueDigestFunc func(ctx context.Context, now time.Time, lease time.Duration) (*model.Preferences, error)
+	SetNextDigestFunc  func(ctx context.Context, recipient string, at *time.Time) error
+}
+
+func (m *MockPreferenceRepository) Get(ctx context.Context, recipient string) (*model.Preferences, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, recipient)
+	}
+	return nil, domainErr.ErrNotFound
+}
+
+func (m *MockPreferenceRepository) Save(ctx context.Context, preferences *model.Preferences) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, preferences)
+	}
+	return nil
+}
+
+func (m *MockPreferenceRepository) ClaimDueDigest(ctx context.Context, now time.Time, lease time.Duration) (*model.Preferences, error) {
+	if m.ClaimDueDigestFunc != nil {
+		return m.ClaimDueDigestFunc(ctx, now, lease)
+	}
+	return nil, domainErr.ErrNotFound
+}
+
+func (m *MockPreferenceRepository) SetNextDigest(ctx context.Context, recipient string, at *time.Time) error {
+	if m.SetNextDigestFunc != nil {
+		return m.SetNextDigestFunc(ctx, recipient, at)
+	}
+	return nil
+}
+
+// MockWebhookRepository is a mock implementation of WebhookRepository
+type MockWebhookRepository struct {
+	CreateSubscriptionFunc  func(ctx context.Context, subscription *model.WebhookSubscription) error
+	ListSubscriptionsFunc   func(ctx context.Context) ([]*model.WebhookSubscription, error)
+	ListSubscriptionsToFunc func(ctx context.Context, eventType string) ([]*model.WebhookSubscription, error)
+	GetSubscriptionFunc     func(ctx context.Context, id string) (*model.WebhookSubscription, error)
+	DeleteSubscriptionFunc  func(ctx context.Context, id string) error
+	EnqueueDeliveriesFunc   func(ctx context.Context, deliveries []*model.WebhookDelivery) error
+	ClaimDueDeliveryFunc    func(ctx context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error)
+	SaveDeliveryFunc        func(ctx context.Context, delivery *model.WebhookDelivery) error
+	DeleteDeliveryFunc      func(ctx context.Context, id string) error
+	SaveDeadLetterFunc      func(ctx context.Context, letter *model.WebhookDeadLetter) error
+	ListDeadLettersFunc     func(ctx context.Context, subscriptionID string, limit int) ([]*model.WebhookDeadLetter, error)
+	GetDeadLetterFunc       func(ctx context.Context, id string) (*model.WebhookDeadLetter, error)
+	DeleteDeadLetterFunc    func(ctx context.Context, id string) error
+}
+
+func (m *MockWebhookRepository) CreateSubscription(ctx context.Context, subscription *model.WebhookSubscription) error {
+	if m.CreateSubscriptionFunc != nil {
+		return m.CreateSubscriptionFunc(ctx, subscription)
+	}
+	return nil
+}
+
+func (m *MockWebhookRepository) ListSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	if m.ListSubscriptionsFunc != nil {
+		return m.ListSubscriptionsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookRepository) ListSubscriptionsTo(ctx context.Context, eventType string) ([]*model.WebhookSubscription, error) {
+	if m.ListSubscriptionsToFunc != nil {
+		return m.ListSubscriptionsToFunc(ctx, eventType)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookRepository) GetSubscription(ctx context.Context, id string) (*model.WebhookSubscription, error) {
+	if m.GetSubscriptionFunc != nil {
+		return m.GetSubscriptionFunc(ctx, id)
+	}
+	return nil, domainErr.ErrNotFound
+}
+
+func (m *MockWebhookRepository) DeleteSubscription(ctx context.Context, id string) error {
+	if m.DeleteSubscriptionFunc != nil {
+		return m.DeleteSubscriptionFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockWebhookRepository) EnqueueDeliveries(ctx context.Context, deliveries []*model.WebhookDelivery) error {
+	if m.EnqueueDeliveriesFunc != nil {
+		return m.EnqueueDeliveriesFunc(ctx, deliveries)
+	}
+	return nil
+}
+
+func (m *MockWebhookRepository) ClaimDueDelivery(ctx context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error) {
+	if m.ClaimDueDeliveryFunc != nil {
+		return m.ClaimDueDeliveryFunc(ctx, now, lease)
+	}
+	return nil, domainErr.ErrNotFound
+}
+
+func (m *MockWebhookRepository) SaveDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	if m.SaveDeliveryFunc != nil {
+		return m.SaveDeliveryFunc(ctx, delivery)
+	}
+	return nil
+}
+
+func (m *MockWebhookRepository) DeleteDelivery(ctx context.Context, id string) error {
+	if m.DeleteDeliveryFunc != nil {
+		return m.DeleteDeliveryFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockWebhookRepository) SaveDeadLetter(ctx context.Context, letter *model.WebhookDeadLetter) error {
+	if m.SaveDeadLetterFunc != nil {
+		return m.SaveDeadLetterFunc(ctx, letter)
+	}
+	return nil
+}
+
+func (m *MockWebhookRepository) ListDeadLetters(ctx context.Context, subscriptionID string, limit int) ([]*model.WebhookDeadLetter, error) {
+	if m.ListDeadLettersFunc != nil {
+		return m.ListDeadLettersFunc(ctx, subscriptionID, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookRepository) GetDeadLetter(ctx context.Context, id string) (*model.WebhookDeadLetter, error) {
+	if m.GetDeadLetterFunc != nil {
+		return m.GetDeadLetterFunc(ctx, id)
+	}
+	return nil, domainErr.ErrNotFound
+}
+
+func (m *MockWebhookRepository) DeleteDeadLetter(ctx context.Context, id string) error {
+	if m.DeleteDeadLetterFunc != nil {
+		return m.DeleteDeadLetterFunc(ctx, id)
+	}
+	return nil
+}
+
+// MockNotificationDedupeRepository is a mock implementation of NotificationDedupeRepository;
+// without ClaimFunc every claim succeeds.
+type MockNotificationDedupeRepository struct {
+	ClaimFunc func(ctx context.Context, key, id string, at time.Time, window time.Duration) (bool, error)
+}
+
+func (m *MockNotificationDedupeRepository) Claim(ctx context.Context, key, id string, at time.Time, window time.Duration) (bool, error) {
+	if m.ClaimFunc != nil {
+		return m.ClaimFunc(ctx, key, id, at, window)
+	}
+	return true, nil
+}
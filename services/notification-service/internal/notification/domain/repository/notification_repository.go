@@ -2,17 +2,58 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/kiin21/go-rest/pkg/httputil"
 	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
 )
 
 type ListNotificationsFilter struct {
+	// ToStarter limits the list to the notifications sent to this starter domain; empty lists
+	// every recipient's
+	ToStarter string
+	// Read keeps only read (true) or unread (false) notifications; nil keeps both
+	Read *bool
+	// Types keeps the notifications of these event types; empty keeps every type
+	Types []string
+	// FromStarter keeps the notifications sent by this starter domain
+	FromStarter string
+	// DepartmentID keeps the notifications whose event is about this department
+	DepartmentID string
+	// Since and Until keep the notifications sent from Since and before Until; nil leaves the
+	// range open
+	Since *time.Time
+	Until *time.Time
+	// Search keeps the notifications whose message has the words of the text search
+	Search    string
 	SortBy    string
 	SortOrder string
 }
 
 type NotificationRepository interface {
 	List(ctx context.Context, filter ListNotificationsFilter, pagination httputil.ReqPagination) ([]*model.Notification, int64, error)
-	Create(ctx context.Context, notification *model.Notification) error
+	// Create stores the notification unless one with its ID is stored already, and reports
+	// whether it did.
+	Create(ctx context.Context, notification *model.Notification) (bool, error)
+	// MarkRead sets the read time of a notification sent to toStarter and returns it. A
+	// notification that was already read keeps its read time. Returns ErrNotFound when there
+	// is no such notification for toStarter.
+	MarkRead(ctx context.Context, id, toStarter string, readAt time.Time) (*model.Notification, error)
+	// MarkAllRead marks every unread notification sent to toStarter as read and returns how
+	// many were updated.
+	MarkAllRead(ctx context.Context, toStarter string, readAt time.Time) (int64, error)
+	CountUnread(ctx context.Context, toStarter string) (int64, error)
+	// ListAfter returns up to limit notifications sent to toStarter after the one with id
+	// afterID, oldest first. Returns ErrNotFound when toStarter has no notification afterID.
+	ListAfter(ctx context.Context, toStarter, afterID string, limit int) ([]*model.Notification, error)
+	// ClaimDueDelivery takes the notification whose delivery through channel is due soonest
+	// and marks it as sending until now+lease, counting the attempt, so that no other
+	// instance sends it meanwhile. Returns ErrNotFound when nothing is due.
+	ClaimDueDelivery(ctx context.Context, channel string, now time.Time, lease time.Duration) (*model.Notification, error)
+	SaveDelivery(ctx context.Context, id, channel string, delivery *model.Delivery) error
+	// ListDigest returns up to limit notifications sent to toStarter that wait for a digest
+	// through channel, oldest first.
+	ListDigest(ctx context.Context, channel, toStarter string, limit int) ([]*model.Notification, error)
+	// MarkDigestSent marks the digest deliveries through channel of the notifications as sent.
+	MarkDigestSent(ctx context.Context, channel string, ids []string, sentAt time.Time) error
 }
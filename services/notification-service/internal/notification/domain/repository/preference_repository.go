@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+type PreferenceRepository interface {
+	// Get returns ErrNotFound when recipient has not saved preferences.
+	Get(ctx context.Context, recipient string) (*model.Preferences, error)
+	Save(ctx context.Context, preferences *model.Preferences) error
+	// ClaimDueDigest takes preferences whose next digest is due and moves it to now+lease, so
+	// that no other instance sends the digest meanwhile. Returns ErrNotFound when none is due.
+	ClaimDueDigest(ctx context.Context, now time.Time, lease time.Duration) (*model.Preferences, error)
+	// SetNextDigest sets when recipient's next digest is due; nil stops digests.
+	SetNextDigest(ctx context.Context, recipient string, at *time.Time) error
+}
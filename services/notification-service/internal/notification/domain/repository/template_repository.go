@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+// TemplateRepository keeps the templates that replace the built-in ones.
+type TemplateRepository interface {
+	List(ctx context.Context) ([]*model.NotificationTemplate, error)
+	// Get returns ErrNotFound when the built-in template of eventType and locale was not
+	// replaced.
+	Get(ctx context.Context, eventType, locale string) (*model.NotificationTemplate, error)
+	// Save stores the template, replacing any of the same event type and locale.
+	Save(ctx context.Context, template *model.NotificationTemplate) error
+	// Delete returns ErrNotFound when there is no such template.
+	Delete(ctx context.Context, eventType, locale string) error
+}
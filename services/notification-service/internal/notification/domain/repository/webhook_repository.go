@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *model.WebhookSubscription) error
+	ListSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error)
+	// ListSubscriptionsTo returns the subscriptions to events of eventType.
+	ListSubscriptionsTo(ctx context.Context, eventType string) ([]*model.WebhookSubscription, error)
+	// GetSubscription returns ErrNotFound when there is no subscription with id.
+	GetSubscription(ctx context.Context, id string) (*model.WebhookSubscription, error)
+	// DeleteSubscription also drops the subscription's deliveries and dead letters. Returns
+	// ErrNotFound when there is no subscription with id.
+	DeleteSubscription(ctx context.Context, id string) error
+
+	// EnqueueDeliveries stores new deliveries, skipping those already stored.
+	EnqueueDeliveries(ctx context.Context, deliveries []*model.WebhookDelivery) error
+	// ClaimDueDelivery takes the delivery that is due soonest and marks it as sending until
+	// now+lease, counting the attempt, so that no other instance sends it meanwhile. Returns
+	// ErrNotFound when nothing is due.
+	ClaimDueDelivery(ctx context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error)
+	// SaveDelivery stores the delivery, replacing any with the same ID.
+	SaveDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+	DeleteDelivery(ctx context.Context, id string) error
+
+	// SaveDeadLetter stores the letter, replacing any with the same ID.
+	SaveDeadLetter(ctx context.Context, letter *model.WebhookDeadLetter) error
+	// ListDeadLetters returns up to limit dead letters, most recently failed first, of one
+	// subscription or of all when subscriptionID is empty.
+	ListDeadLetters(ctx context.Context, subscriptionID string, limit int) ([]*model.WebhookDeadLetter, error)
+	// GetDeadLetter returns ErrNotFound when there is no dead letter with id.
+	GetDeadLetter(ctx context.Context, id string) (*model.WebhookDeadLetter, error)
+	DeleteDeadLetter(ctx context.Context, id string) error
+}
@@ -0,0 +1,83 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	domainDelivery "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/delivery"
+)
+
+// StarterServiceResolver looks up email addresses in the starter service. The token must
+// carry the hr_admin role, or the addresses come back masked or not at all.
+type StarterServiceResolver struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+func NewStarterServiceResolver(baseURL, token string) *StarterServiceResolver {
+	return &StarterServiceResolver{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (r *StarterServiceResolver) ResolveEmail(ctx context.Context, domain string) (string, error) {
+	endpoint := r.baseURL + "/api/v1/starters/" + url.PathEscape(domain) + "?fields=email"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+r.token)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", domainDelivery.ErrNoAddress
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return "", domainDelivery.Permanent(fmt.Errorf("starter service refused the address lookup: %s", resp.Status))
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("starter service address lookup failed: %s", resp.Status)
+	}
+
+	var body struct {
+		Data struct {
+			Email string `json:"email"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode starter: %w", err)
+	}
+	// Masked addresses are no use for sending
+	if body.Data.Email == "" || strings.Contains(body.Data.Email, "*") {
+		return "", domainDelivery.ErrNoAddress
+	}
+	return body.Data.Email, nil
+}
+
+// MailDomainResolver derives addresses from the starter domain, as in <domain>@<mail domain>.
+type MailDomainResolver struct {
+	mailDomain string
+}
+
+func NewMailDomainResolver(mailDomain string) *MailDomainResolver {
+	return &MailDomainResolver{mailDomain: strings.TrimPrefix(mailDomain, "@")}
+}
+
+func (r *MailDomainResolver) ResolveEmail(_ context.Context, domain string) (string, error) {
+	if domain == "" {
+		return "", domainDelivery.ErrNoAddress
+	}
+	return domain + "@" + r.mailDomain, nil
+}
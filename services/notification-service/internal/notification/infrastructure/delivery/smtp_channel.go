@@ -0,0 +1,293 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"embed"
+	"errors"
+	"fmt"
+	htmlTemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	textTemplate "text/template"
+	"time"
+
+	"github.com/kiin21/go-rest/pkg/events"
+	domainDelivery "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/delivery"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+// ChannelEmail is the name of the email channel in a notification's deliveries.
+const ChannelEmail = model.ChannelEmail
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var (
+	htmlTemplates = htmlTemplate.Must(htmlTemplate.ParseFS(templateFS, "templates/*.html.tmpl"))
+	textTemplates = textTemplate.Must(textTemplate.ParseFS(templateFS, "templates/*.txt.tmpl"))
+)
+
+// subjects holds the email subject per notification type.
+var subjects = map[string]string{
+	events.EventTypeNotificationLeaderAssignment:   "You have been assigned as a department leader",
+	events.EventTypeNotificationStarterCreated:     "A new starter has joined your team",
+	events.EventTypeNotificationLineManagerChanged: "A starter's line manager has changed",
+	events.EventTypeNotificationDepartmentTransfer: "A starter has changed department",
+	events.EventTypeNotificationStarterDeleted:     "A starter has left your team",
+}
+
+const defaultSubject = "You have a new notification"
+
+// SMTPConfig locates the mail server. Username and Password are optional; without them the
+// server must accept mail unauthenticated, as local SMTP stubs do.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPChannel emails notifications to their recipients as HTML with a plain text
+// alternative. STARTTLS is used whenever the server offers it.
+type SMTPChannel struct {
+	cfg      SMTPConfig
+	from     *mail.Address
+	resolver domainDelivery.AddressResolver
+}
+
+func NewSMTPChannel(cfg SMTPConfig, resolver domainDelivery.AddressResolver) (*SMTPChannel, error) {
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", cfg.From, err)
+	}
+	return &SMTPChannel{cfg: cfg, from: from, resolver: resolver}, nil
+}
+
+func (c *SMTPChannel) Name() string {
+	return ChannelEmail
+}
+
+func (c *SMTPChannel) Send(ctx context.Context, notification *model.Notification) error {
+	to, err := c.recipientAddress(ctx, notification.ToStarter)
+	if err != nil {
+		return err
+	}
+
+	subject, ok := subjects[notification.Type]
+	if !ok {
+		subject = defaultSubject
+	}
+	data := emailData{
+		Subject:     subject,
+		Recipient:   notification.ToStarter,
+		FromStarter: notification.FromStarter,
+		Message:     notification.Message,
+		Timestamp:   notification.Timestamp,
+	}
+	message, err := c.compose(to, email{
+		subject:   subject,
+		template:  "notification",
+		data:      data,
+		date:      notification.Timestamp,
+		messageID: notification.ID,
+	})
+	if err != nil {
+		return domainDelivery.Permanent(err)
+	}
+	return c.send(ctx, to.Address, message)
+}
+
+// SendDigest emails the notifications, oldest first, to recipient in one message.
+func (c *SMTPChannel) SendDigest(ctx context.Context, recipient string, notifications []*model.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	to, err := c.recipientAddress(ctx, recipient)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf(digestSubject, len(notifications))
+	data := digestData{Subject: subject, Recipient: recipient}
+	for _, notification := range notifications {
+		data.Notifications = append(data.Notifications, emailData{
+			FromStarter: notification.FromStarter,
+			Message:     notification.Message,
+			Timestamp:   notification.Timestamp,
+		})
+	}
+	last := notifications[len(notifications)-1]
+	message, err := c.compose(to, email{
+		subject:  subject,
+		template: "digest",
+		data:     data,
+		date:     time.Now(),
+		// The newest notification keeps the message ID stable when the same digest is retried
+		messageID: "digest." + last.ID,
+	})
+	if err != nil {
+		return domainDelivery.Permanent(err)
+	}
+	return c.send(ctx, to.Address, message)
+}
+
+const digestSubject = "Your notification digest: %d new"
+
+func (c *SMTPChannel) recipientAddress(ctx context.Context, domain string) (*mail.Address, error) {
+	address, err := c.resolver.ResolveEmail(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	to, err := mail.ParseAddress(address)
+	if err != nil {
+		return nil, domainDelivery.Permanent(fmt.Errorf("invalid recipient address %q: %w", address, err))
+	}
+	return to, nil
+}
+
+type emailData struct {
+	Subject     string
+	Recipient   string
+	FromStarter string
+	Message     string
+	Timestamp   time.Time
+}
+
+type digestData struct {
+	Subject       string
+	Recipient     string
+	Notifications []emailData
+}
+
+// email is a message to compose from the template pair named template.
+type email struct {
+	subject   string
+	template  string
+	data      any
+	date      time.Time
+	messageID string
+}
+
+func (c *SMTPChannel) compose(to *mail.Address, e email) ([]byte, error) {
+	var text, html bytes.Buffer
+	if err := textTemplates.ExecuteTemplate(&text, e.template+".txt.tmpl", e.data); err != nil {
+		return nil, fmt.Errorf("failed to render text email: %w", err)
+	}
+	if err := htmlTemplates.ExecuteTemplate(&html, e.template+".html.tmpl", e.data); err != nil {
+		return nil, fmt.Errorf("failed to render html email: %w", err)
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		// Clients show the last alternative they support, so HTML goes last
+		{"text/plain; charset=utf-8", text.Bytes()},
+		{"text/html; charset=utf-8", html.Bytes()},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write(part.content); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	headers := []struct{ name, value string }{
+		{"From", c.from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", e.subject)},
+		{"Date", e.date.Format(time.RFC1123Z)},
+		// The message ID stays the same across retries, so clients can drop duplicates
+		{"Message-ID", "<" + e.messageID + "@notification-service>"},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + parts.Boundary()},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&message, "%s: %s\r\n", header.name, header.value)
+	}
+	message.WriteString("\r\n")
+	message.Write(body.Bytes())
+	return message.Bytes(), nil
+}
+
+func (c *SMTPChannel) send(ctx context.Context, to string, message []byte) error {
+	addr := net.JoinHostPort(c.cfg.Host, strconv.Itoa(c.cfg.Port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, c.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return smtpError(err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: c.cfg.Host}); err != nil {
+			return smtpError(err)
+		}
+	}
+	if c.cfg.Username != "" {
+		auth := smtp.PlainAuth("", c.cfg.Username, c.cfg.Password, c.cfg.Host)
+		if err := client.Auth(auth); err != nil {
+			return smtpError(err)
+		}
+	}
+
+	if err := client.Mail(c.from.Address); err != nil {
+		return smtpError(err)
+	}
+	if err := client.Rcpt(to); err != nil {
+		return smtpError(err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return smtpError(err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return smtpError(err)
+	}
+	if err := w.Close(); err != nil {
+		return smtpError(err)
+	}
+	return smtpError(client.Quit())
+}
+
+// smtpError marks 5xx replies as permanent; the server will refuse the message again.
+func smtpError(err error) error {
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return domainDelivery.Permanent(err)
+	}
+	return err
+}
@@ -0,0 +1,392 @@
+package delivery
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kiin21/go-rest/pkg/events"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+	domainDelivery "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/delivery"
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	domainrepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+)
+
+// smtpStub is a minimal SMTP server on a loopback listener. It accepts every message, or
+// answers RCPT TO with rcptReply when that is set.
+type smtpStub struct {
+	listener  net.Listener
+	rcptReply string
+
+	mu       sync.Mutex
+	messages []stubMessage
+}
+
+type stubMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+func newSMTPStub(t *testing.T, rcptReply string) *smtpStub {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	stub := &smtpStub{listener: listener, rcptReply: rcptReply}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go stub.serve(conn)
+		}
+	}()
+	return stub
+}
+
+func (s *smtpStub) config() SMTPConfig {
+	addr := s.listener.Addr().(*net.TCPAddr)
+	return SMTPConfig{Host: addr.IP.String(), Port: addr.Port, From: "Notifications <noreply@vng.test>"}
+}
+
+func (s *smtpStub) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+
+	var message stubMessage
+	reply("220 stub ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(strings.SplitN(command, " ", 2)[0])
+		switch verb {
+		case "EHLO", "HELO":
+			reply("250 stub")
+		case "MAIL":
+			message = stubMessage{from: addressOf(command)}
+			reply("250 OK")
+		case "RCPT":
+			if s.rcptReply != "" {
+				reply(s.rcptReply)
+				continue
+			}
+			message.to = append(message.to, addressOf(command))
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			message.data = data.String()
+			s.mu.Lock()
+			s.messages = append(s.messages, message)
+			s.mu.Unlock()
+			reply("250 OK")
+		case "RSET", "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func (s *smtpStub) received() []stubMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]stubMessage(nil), s.messages...)
+}
+
+// addressOf returns the address between the angle brackets of MAIL FROM and RCPT TO.
+func addressOf(command string) string {
+	start, end := strings.Index(command, "<"), strings.LastIndex(command, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return command[start+1 : end]
+}
+
+// readParts returns the decoded bodies of a multipart message by content type.
+func readParts(t *testing.T, data string) (*mail.Message, map[string]string) {
+	t.Helper()
+	message, err := mail.ReadMessage(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to read message: %v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("expected multipart/alternative, got %q (%v)", message.Header.Get("Content-Type"), err)
+	}
+
+	parts := map[string]string{}
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read part: %v", err)
+		}
+		// The reader undoes the quoted-printable encoding
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatalf("failed to read part body: %v", err)
+		}
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		parts[contentType] = string(body)
+	}
+	return message, parts
+}
+
+func TestSMTPChannelSend(t *testing.T) {
+	stub := newSMTPStub(t, "")
+	channel, err := NewSMTPChannel(stub.config(), NewMailDomainResolver("@vng.test"))
+	if err != nil {
+		t.Fatalf("failed to create channel: %v", err)
+	}
+
+	notification := &model.Notification{
+		ID:          "n-1",
+		Type:        events.EventTypeNotificationStarterCreated,
+		FromStarter: "system",
+		ToStarter:   "thanhnt",
+		Message:     "Nguyễn Văn A <avn> joined Engineering",
+		Timestamp:   time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC),
+	}
+	if err := channel.Send(context.Background(), notification); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	received := stub.received()
+	if len(received) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(received))
+	}
+	if received[0].from != "noreply@vng.test" {
+		t.Errorf("MAIL FROM = %q; want noreply@vng.test", received[0].from)
+	}
+	if len(received[0].to) != 1 || received[0].to[0] != "thanhnt@vng.test" {
+		t.Errorf("RCPT TO = %v; want the address derived from the starter domain", received[0].to)
+	}
+
+	message, parts := readParts(t, received[0].data)
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if err != nil || subject != subjects[events.EventTypeNotificationStarterCreated] {
+		t.Errorf("Subject = %q; want the subject of the notification type", subject)
+	}
+	if to := message.Header.Get("To"); to != "<thanhnt@vng.test>" {
+		t.Errorf("To = %q; want <thanhnt@vng.test>", to)
+	}
+	if id := message.Header.Get("Message-ID"); id != "<n-1@notification-service>" {
+		t.Errorf("Message-ID = %q; want it derived from the notification ID", id)
+	}
+
+	text := parts["text/plain"]
+	for _, want := range []string{"Hi thanhnt,", "Nguyễn Văn A <avn> joined Engineering", "Sent by system on 02 Mar 2026 09:30 UTC."} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text part does not contain %q:\n%s", want, text)
+		}
+	}
+	html := parts["text/html"]
+	for _, want := range []string{"<p>Hi thanhnt,</p>", "<p>Nguyễn Văn A &lt;avn&gt; joined Engineering</p>", "Sent by system"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("html part does not contain %q:\n%s", want, html)
+		}
+	}
+}
+
+func TestSMTPChannelSendDigest(t *testing.T) {
+	stub := newSMTPStub(t, "")
+	channel, err := NewSMTPChannel(stub.config(), NewMailDomainResolver("vng.test"))
+	if err != nil {
+		t.Fatalf("failed to create channel: %v", err)
+	}
+
+	notifications := []*model.Notification{
+		{ID: "n-1", FromStarter: "system", Message: "First message", Timestamp: time.Now()},
+		{ID: "n-2", FromStarter: "system", Message: "Second message", Timestamp: time.Now()},
+	}
+	if err := channel.SendDigest(context.Background(), "thanhnt", notifications); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	received := stub.received()
+	if len(received) != 1 || received[0].to[0] != "thanhnt@vng.test" {
+		t.Fatalf("expected one digest to thanhnt@vng.test, got %+v", received)
+	}
+	message, parts := readParts(t, received[0].data)
+	if id := message.Header.Get("Message-ID"); id != "<digest.n-2@notification-service>" {
+		t.Errorf("Message-ID = %q; want it derived from the newest notification", id)
+	}
+	for _, contentType := range []string{"text/plain", "text/html"} {
+		for _, want := range []string{"First message", "Second message"} {
+			if !strings.Contains(parts[contentType], want) {
+				t.Errorf("%s part does not contain %q", contentType, want)
+			}
+		}
+	}
+}
+
+func TestSMTPChannelRecipientAddress(t *testing.T) {
+	stub := newSMTPStub(t, "")
+	channel, err := NewSMTPChannel(stub.config(), NewMailDomainResolver("vng.test"))
+	if err != nil {
+		t.Fatalf("failed to create channel: %v", err)
+	}
+
+	err = channel.Send(context.Background(), &model.Notification{ID: "n-1", Message: "Hello"})
+	if !errors.Is(err, domainDelivery.ErrNoAddress) || !domainDelivery.IsPermanent(err) {
+		t.Errorf("expected a permanent ErrNoAddress without a recipient domain, got %v", err)
+	}
+
+	channel, err = NewSMTPChannel(stub.config(), NewMailDomainResolver("not a domain"))
+	if err != nil {
+		t.Fatalf("failed to create channel: %v", err)
+	}
+	err = channel.Send(context.Background(), &model.Notification{ID: "n-1", ToStarter: "thanhnt", Message: "Hello"})
+	if !domainDelivery.IsPermanent(err) {
+		t.Errorf("expected a permanent error for an invalid address, got %v", err)
+	}
+	if received := stub.received(); len(received) != 0 {
+		t.Errorf("expected nothing to be sent, got %d messages", len(received))
+	}
+}
+
+// deliveryRepository hands out one notification to deliver and records what is saved for it;
+// the rest of the repository is not used by the delivery worker.
+type deliveryRepository struct {
+	domainrepo.NotificationRepository
+
+	mu           sync.Mutex
+	notification *model.Notification
+	saved        chan *model.Delivery
+}
+
+func (r *deliveryRepository) ClaimDueDelivery(_ context.Context, channel string, now time.Time, lease time.Duration) (*model.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.notification == nil {
+		return nil, domainErr.ErrNotFound
+	}
+	notification := r.notification
+	r.notification = nil
+
+	// As the store does: the claim counts the attempt
+	delivery := notification.Deliveries[channel]
+	delivery.Status = model.DeliverySending
+	delivery.Attempts++
+	until := now.Add(lease)
+	delivery.NextAttemptAt = &until
+	return notification, nil
+}
+
+func (r *deliveryRepository) SaveDelivery(_ context.Context, _, _ string, delivery *model.Delivery) error {
+	saved := *delivery
+	r.saved <- &saved
+	return nil
+}
+
+func TestSMTPChannelDeliveryStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		rcptReply    string
+		attempts     int
+		wantStatus   model.DeliveryStatus
+		wantRetry    bool
+		wantError    bool
+		wantMessages int
+	}{
+		{"sent", "", 0, model.DeliverySent, false, false, 1},
+		{"temporary failure is retried", "451 Mailbox busy", 0, model.DeliveryPending, true, true, 0},
+		{"permanent failure gives up", "550 No such user", 0, model.DeliveryFailed, false, true, 0},
+		{"last attempt gives up", "451 Mailbox busy", 2, model.DeliveryFailed, false, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := newSMTPStub(t, tt.rcptReply)
+			channel, err := NewSMTPChannel(stub.config(), NewMailDomainResolver("vng.test"))
+			if err != nil {
+				t.Fatalf("failed to create channel: %v", err)
+			}
+
+			delivery := model.NewPendingDelivery(time.Now())
+			delivery.Attempts = tt.attempts
+			repo := &deliveryRepository{
+				notification: &model.Notification{
+					ID:         "n-1",
+					Type:       events.EventTypeNotificationStarterCreated,
+					ToStarter:  "thanhnt",
+					Message:    "Hello",
+					Timestamp:  time.Now(),
+					Deliveries: map[string]*model.Delivery{ChannelEmail: delivery},
+				},
+				saved: make(chan *model.Delivery, 1),
+			}
+			service := application.NewDeliveryService(repo, []domainDelivery.Channel{channel}, application.RetryPolicy{
+				MaxAttempts:  3,
+				Backoff:      time.Minute,
+				MaxBackoff:   time.Hour,
+				PollInterval: time.Hour,
+				SendTimeout:  5 * time.Second,
+			})
+			service.Start()
+			defer service.Stop()
+
+			var saved *model.Delivery
+			select {
+			case saved = <-repo.saved:
+			case <-time.After(5 * time.Second):
+				t.Fatal("the delivery was not saved")
+			}
+
+			if saved.Status != tt.wantStatus {
+				t.Errorf("status = %s; want %s", saved.Status, tt.wantStatus)
+			}
+			if saved.Attempts != tt.attempts+1 {
+				t.Errorf("attempts = %d; want %d", saved.Attempts, tt.attempts+1)
+			}
+			if (saved.NextAttemptAt != nil) != tt.wantRetry {
+				t.Errorf("next attempt = %v; want a retry: %v", saved.NextAttemptAt, tt.wantRetry)
+			}
+			if (saved.LastError != "") != tt.wantError {
+				t.Errorf("last error = %q; want an error: %v", saved.LastError, tt.wantError)
+			}
+			if tt.wantStatus == model.DeliverySent && saved.DeliveredAt == nil {
+				t.Error("expected the delivery time to be recorded")
+			}
+			if received := stub.received(); len(received) != tt.wantMessages {
+				t.Errorf("expected %d messages, got %d", tt.wantMessages, len(received))
+			}
+		})
+	}
+}
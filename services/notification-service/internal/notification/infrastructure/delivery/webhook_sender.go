@@ -0,0 +1,120 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	domainDelivery "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/delivery"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+// Headers sent with every webhook request
+const (
+	WebhookIDHeader        = "X-Webhook-ID"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// HTTPWebhookSender POSTs deliveries signed with their subscription's secret. The signature
+// is "sha256=" followed by the hex HMAC-SHA256 of the timestamp header, a dot and the body;
+// receivers recompute it and reject old timestamps to stop replayed requests.
+type HTTPWebhookSender struct {
+	client *http.Client
+}
+
+func NewHTTPWebhookSender() *HTTPWebhookSender {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would hide the receiver's address from the dialer's check
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   checkWebhookAddress,
+	}).DialContext
+
+	return &HTTPWebhookSender{
+		client: &http.Client{
+			Transport: transport,
+			// A redirect would send the signed event somewhere the subscriber did not register
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// checkWebhookAddress runs on the resolved address of every connection, so a host that passed
+// the check at registration cannot be pointed at an internal address afterwards.
+func checkWebhookAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return domainDelivery.Permanent(err)
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return domainDelivery.Permanent(err)
+	}
+	if !model.WebhookAddressAllowed(addr) {
+		return domainDelivery.Permanent(fmt.Errorf("webhook receiver address %s is not public", addr))
+	}
+	return nil
+}
+
+func (s *HTTPWebhookSender) Send(
+	ctx context.Context,
+	subscription *model.WebhookSubscription,
+	delivery *model.WebhookDelivery,
+) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return domainDelivery.Permanent(err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookIDHeader, delivery.ID)
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(subscription.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Draining the body lets the connection be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= 500:
+		return fmt.Errorf("webhook receiver answered %s", resp.Status)
+	default:
+		// Other answers will not change by retrying; the dead letter can be replayed once the
+		// receiver is fixed
+		return domainDelivery.Permanent(fmt.Errorf("webhook receiver answered %s", resp.Status))
+	}
+}
+
+// SignWebhook returns the signature header value for body sent at timestamp.
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,156 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	domainDelivery "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/delivery"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+func TestSignWebhook(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      string
+		want      string
+	}{
+		{
+			"event body", "whsec_test", "1700000000", `{"type":"starter.created"}`,
+			"sha256=baa0a6a6f3ce1f64879667e2a242bf15dd7ebf2bc9b2e9ac134f087bcb942e7d",
+		},
+		{
+			"empty body", "whsec_test", "1700000000", "",
+			"sha256=5967f3c560522fa40cf2876ebc3c3a08551dd6959aaade3b413460591895bdcc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SignWebhook(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Errorf("SignWebhook() = %s; want %s", got, tt.want)
+			}
+		})
+	}
+
+	// Changing any input must change the signature
+	base := SignWebhook("whsec_test", "1700000000", []byte("{}"))
+	for name, got := range map[string]string{
+		"secret":    SignWebhook("whsec_other", "1700000000", []byte("{}")),
+		"timestamp": SignWebhook("whsec_test", "1700000001", []byte("{}")),
+		"body":      SignWebhook("whsec_test", "1700000000", []byte("{ }")),
+	} {
+		if got == base {
+			t.Errorf("signature did not change with the %s", name)
+		}
+	}
+}
+
+func TestCheckWebhookAddress(t *testing.T) {
+	tests := []struct {
+		address       string
+		wantPermanent bool
+	}{
+		{"93.184.216.34:443", false},
+		{"[2606:4700::6810:84e5]:443", false},
+		{"127.0.0.1:80", true},
+		{"10.0.0.5:8080", true},
+		{"169.254.169.254:80", true},
+		{"[::1]:80", true},
+		{"[::ffff:192.168.1.1]:80", true},
+		{"not-an-address", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := checkWebhookAddress("tcp", tt.address, nil)
+			if tt.wantPermanent {
+				if !domainDelivery.IsPermanent(err) {
+					t.Errorf("expected a permanent error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestHTTPWebhookSenderSend(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		wantErr       bool
+		wantPermanent bool
+	}{
+		{"accepted", http.StatusOK, false, false},
+		{"no content", http.StatusNoContent, false, false},
+		{"redirect is not followed", http.StatusFound, true, true},
+		{"rejected", http.StatusBadRequest, true, true},
+		{"gone", http.StatusGone, true, true},
+		{"request timeout", http.StatusRequestTimeout, true, false},
+		{"rate limited", http.StatusTooManyRequests, true, false},
+		{"server error", http.StatusBadGateway, true, false},
+	}
+
+	delivery := &model.WebhookDelivery{ID: "sub-1:evt-1", EventType: "starter.created", Payload: []byte(`{"id":"evt-1"}`)}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				if tt.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			// The test server listens on loopback, which the guarded dialer refuses
+			sender := NewHTTPWebhookSender()
+			sender.client.Transport = http.DefaultTransport
+			subscription := &model.WebhookSubscription{ID: "sub-1", URL: server.URL, Secret: "whsec_test"}
+			err := sender.Send(context.Background(), subscription, delivery)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v; wantErr %v", err, tt.wantErr)
+			}
+			if domainDelivery.IsPermanent(err) != tt.wantPermanent {
+				t.Errorf("permanent = %v; want %v (%v)", !tt.wantPermanent, tt.wantPermanent, err)
+			}
+
+			if got.URL.Path != "/" || got.Header.Get(WebhookIDHeader) != delivery.ID ||
+				got.Header.Get(WebhookEventHeader) != delivery.EventType {
+				t.Errorf("unexpected request %s %v", got.URL.Path, got.Header)
+			}
+			timestamp := got.Header.Get(WebhookTimestampHeader)
+			if _, err := strconv.ParseInt(timestamp, 10, 64); err != nil {
+				t.Errorf("timestamp %q is not unix seconds", timestamp)
+			}
+			if want := SignWebhook(subscription.Secret, timestamp, delivery.Payload); got.Header.Get(WebhookSignatureHeader) != want {
+				t.Errorf("signature = %s; want %s", got.Header.Get(WebhookSignatureHeader), want)
+			}
+		})
+	}
+}
+
+func TestHTTPWebhookSenderRefusesLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the request reached a loopback receiver")
+	}))
+	defer server.Close()
+
+	err := NewHTTPWebhookSender().Send(context.Background(),
+		&model.WebhookSubscription{ID: "sub-1", URL: server.URL, Secret: "whsec_test"},
+		&model.WebhookDelivery{ID: "sub-1:evt-1", Payload: []byte("{}")},
+	)
+	if !domainDelivery.IsPermanent(err) {
+		t.Errorf("expected a permanent error, got %v", err)
+	}
+}
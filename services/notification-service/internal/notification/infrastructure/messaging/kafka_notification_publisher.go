@@ -0,0 +1,82 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+	domainMq "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/messaging"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+// deliveryMessage is a stored notification as announced on the delivery topic.
+type deliveryMessage struct {
+	ID          string     `json:"id"`
+	FromStarter string     `json:"from_starter"`
+	ToStarter   string     `json:"to_starter"`
+	Message     string     `json:"message"`
+	Type        string     `json:"type"`
+	Timestamp   time.Time  `json:"timestamp"`
+	ReadAt      *time.Time `json:"read_at,omitempty"`
+}
+
+// DecodeDelivery reads a notification announced by KafkaNotificationPublisher.
+func DecodeDelivery(data []byte) (*model.Notification, error) {
+	var msg deliveryMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal delivery message: %w", err)
+	}
+	return &model.Notification{
+		ID:          msg.ID,
+		FromStarter: msg.FromStarter,
+		ToStarter:   msg.ToStarter,
+		Message:     msg.Message,
+		Type:        msg.Type,
+		Timestamp:   msg.Timestamp,
+		ReadAt:      msg.ReadAt,
+	}, nil
+}
+
+type KafkaNotificationPublisher struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func NewKafkaNotificationPublisher(producer sarama.SyncProducer, topic string) domainMq.NotificationPublisher {
+	return &KafkaNotificationPublisher{
+		producer: producer,
+		topic:    topic,
+	}
+}
+
+func (p *KafkaNotificationPublisher) Publish(_ context.Context, notification *model.Notification) error {
+	value, err := json.Marshal(deliveryMessage{
+		ID:          notification.ID,
+		FromStarter: notification.FromStarter,
+		ToStarter:   notification.ToStarter,
+		Message:     notification.Message,
+		Type:        notification.Type,
+		Timestamp:   notification.Timestamp,
+		ReadAt:      notification.ReadAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal delivery message: %w", err)
+	}
+
+	// Keyed by recipient so that one recipient's notifications stay in order
+	_, _, err = p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: p.topic,
+		Key:   sarama.StringEncoder(notification.ToStarter),
+		Value: sarama.ByteEncoder(value),
+	})
+	return err
+}
+
+func (p *KafkaNotificationPublisher) Close() error {
+	if p.producer != nil {
+		return p.producer.Close()
+	}
+	return nil
+}
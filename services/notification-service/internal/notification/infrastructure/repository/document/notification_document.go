@@ -7,15 +7,59 @@ import (
 )
 
 type NotificationDocument struct {
-	ID          string    `bson:"_id"`
-	FromStarter string    `bson:"from_starter"`
-	ToStarter   string    `bson:"to_starter"`
-	Message     string    `bson:"message"`
-	Type        string    `bson:"type"`
-	Timestamp   time.Time `bson:"timestamp"`
+	ID          string     `bson:"_id"`
+	FromStarter string     `bson:"from_starter"`
+	ToStarter   string     `bson:"to_starter"`
+	Message     string     `bson:"message"`
+	Type        string     `bson:"type"`
+	Timestamp   time.Time  `bson:"timestamp"`
+	ReadAt      *time.Time `bson:"read_at"`
+	// Data holds the template variables of the event
+	Data map[string]string `bson:"data,omitempty"`
+	// Deliveries is keyed by channel name
+	Deliveries map[string]*DeliveryDocument `bson:"deliveries,omitempty"`
+}
+
+type DeliveryDocument struct {
+	Status        string     `bson:"status"`
+	Attempts      int        `bson:"attempts"`
+	LastError     string     `bson:"last_error,omitempty"`
+	NextAttemptAt *time.Time `bson:"next_attempt_at"`
+	DeliveredAt   *time.Time `bson:"delivered_at,omitempty"`
+	UpdatedAt     time.Time  `bson:"updated_at"`
+}
+
+func (d *DeliveryDocument) ToDomain() *domainModel.Delivery {
+	return &domainModel.Delivery{
+		Status:        domainModel.DeliveryStatus(d.Status),
+		Attempts:      d.Attempts,
+		LastError:     d.LastError,
+		NextAttemptAt: d.NextAttemptAt,
+		DeliveredAt:   d.DeliveredAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+}
+
+func FromDomainDelivery(d *domainModel.Delivery) *DeliveryDocument {
+	return &DeliveryDocument{
+		Status:        string(d.Status),
+		Attempts:      d.Attempts,
+		LastError:     d.LastError,
+		NextAttemptAt: d.NextAttemptAt,
+		DeliveredAt:   d.DeliveredAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
 }
 
 func (d *NotificationDocument) ToDomain() *domainModel.Notification {
+	var deliveries map[string]*domainModel.Delivery
+	if len(d.Deliveries) > 0 {
+		deliveries = make(map[string]*domainModel.Delivery, len(d.Deliveries))
+		for channel, delivery := range d.Deliveries {
+			deliveries[channel] = delivery.ToDomain()
+		}
+	}
+
 	return &domainModel.Notification{
 		ID:          d.ID,
 		FromStarter: d.FromStarter,
@@ -23,10 +67,21 @@ func (d *NotificationDocument) ToDomain() *domainModel.Notification {
 		Message:     d.Message,
 		Type:        d.Type,
 		Timestamp:   d.Timestamp,
+		ReadAt:      d.ReadAt,
+		Data:        d.Data,
+		Deliveries:  deliveries,
 	}
 }
 
 func FromDomain(n *domainModel.Notification) *NotificationDocument {
+	var deliveries map[string]*DeliveryDocument
+	if len(n.Deliveries) > 0 {
+		deliveries = make(map[string]*DeliveryDocument, len(n.Deliveries))
+		for channel, delivery := range n.Deliveries {
+			deliveries[channel] = FromDomainDelivery(delivery)
+		}
+	}
+
 	return &NotificationDocument{
 		ID:          n.ID,
 		FromStarter: n.FromStarter,
@@ -34,5 +89,8 @@ func FromDomain(n *domainModel.Notification) *NotificationDocument {
 		Message:     n.Message,
 		Type:        n.Type,
 		Timestamp:   n.Timestamp,
+		ReadAt:      n.ReadAt,
+		Data:        n.Data,
+		Deliveries:  deliveries,
 	}
 }
@@ -0,0 +1,82 @@
+package document
+
+import (
+	"time"
+
+	domainModel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+type PreferencesDocument struct {
+	// ID is the recipient's starter domain
+	ID           string                             `bson:"_id"`
+	EventTypes   map[string]ChannelSettingsDocument `bson:"event_types"`
+	QuietHours   *QuietHoursDocument                `bson:"quiet_hours"`
+	Digest       string                             `bson:"digest"`
+	DigestAt     string                             `bson:"digest_at"`
+	Timezone     string                             `bson:"timezone"`
+	NextDigestAt *time.Time                         `bson:"next_digest_at"`
+	UpdatedAt    time.Time                          `bson:"updated_at"`
+}
+
+type ChannelSettingsDocument struct {
+	InApp   bool `bson:"in_app"`
+	Email   bool `bson:"email"`
+	Webhook bool `bson:"webhook"`
+}
+
+type QuietHoursDocument struct {
+	Start string `bson:"start"`
+	End   string `bson:"end"`
+}
+
+func (d *PreferencesDocument) ToDomain() *domainModel.Preferences {
+	eventTypes := make(map[string]domainModel.ChannelSettings, len(d.EventTypes))
+	for eventType, settings := range d.EventTypes {
+		eventTypes[eventType] = domainModel.ChannelSettings{
+			InApp:   settings.InApp,
+			Email:   settings.Email,
+			Webhook: settings.Webhook,
+		}
+	}
+	var quietHours *domainModel.QuietHours
+	if d.QuietHours != nil {
+		quietHours = &domainModel.QuietHours{Start: d.QuietHours.Start, End: d.QuietHours.End}
+	}
+
+	return &domainModel.Preferences{
+		Recipient:    d.ID,
+		EventTypes:   eventTypes,
+		QuietHours:   quietHours,
+		Digest:       domainModel.DigestFrequency(d.Digest),
+		DigestAt:     d.DigestAt,
+		Timezone:     d.Timezone,
+		NextDigestAt: d.NextDigestAt,
+		UpdatedAt:    d.UpdatedAt,
+	}
+}
+
+func FromDomainPreferences(p *domainModel.Preferences) *PreferencesDocument {
+	eventTypes := make(map[string]ChannelSettingsDocument, len(p.EventTypes))
+	for eventType, settings := range p.EventTypes {
+		eventTypes[eventType] = ChannelSettingsDocument{
+			InApp:   settings.InApp,
+			Email:   settings.Email,
+			Webhook: settings.Webhook,
+		}
+	}
+	var quietHours *QuietHoursDocument
+	if p.QuietHours != nil {
+		quietHours = &QuietHoursDocument{Start: p.QuietHours.Start, End: p.QuietHours.End}
+	}
+
+	return &PreferencesDocument{
+		ID:           p.Recipient,
+		EventTypes:   eventTypes,
+		QuietHours:   quietHours,
+		Digest:       string(p.Digest),
+		DigestAt:     p.DigestAt,
+		Timezone:     p.Timezone,
+		NextDigestAt: p.NextDigestAt,
+		UpdatedAt:    p.UpdatedAt,
+	}
+}
@@ -0,0 +1,47 @@
+package document
+
+import (
+	"time"
+
+	domainModel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+type TemplateDocument struct {
+	// ID is the event type and locale, e.g. "notification.leader_assignment/vi"
+	ID        string    `bson:"_id"`
+	EventType string    `bson:"event_type"`
+	Locale    string    `bson:"locale"`
+	Body      string    `bson:"body"`
+	UpdatedBy string    `bson:"updated_by"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+func TemplateID(eventType, locale string) string {
+	return eventType + "/" + locale
+}
+
+func (d *TemplateDocument) ToDomain() *domainModel.NotificationTemplate {
+	updatedAt := d.UpdatedAt
+	return &domainModel.NotificationTemplate{
+		EventType: d.EventType,
+		Locale:    d.Locale,
+		Body:      d.Body,
+		Custom:    true,
+		UpdatedBy: d.UpdatedBy,
+		UpdatedAt: &updatedAt,
+	}
+}
+
+func FromDomainTemplate(t *domainModel.NotificationTemplate) *TemplateDocument {
+	doc := &TemplateDocument{
+		ID:        TemplateID(t.EventType, t.Locale),
+		EventType: t.EventType,
+		Locale:    t.Locale,
+		Body:      t.Body,
+		UpdatedBy: t.UpdatedBy,
+	}
+	if t.UpdatedAt != nil {
+		doc.UpdatedAt = *t.UpdatedAt
+	}
+	return doc
+}
@@ -0,0 +1,112 @@
+package document
+
+import (
+	"time"
+
+	domainModel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+type WebhookSubscriptionDocument struct {
+	ID         string    `bson:"_id"`
+	URL        string    `bson:"url"`
+	EventTypes []string  `bson:"event_types"`
+	Secret     string    `bson:"secret"`
+	CreatedBy  string    `bson:"created_by"`
+	CreatedAt  time.Time `bson:"created_at"`
+}
+
+func (d *WebhookSubscriptionDocument) ToDomain() *domainModel.WebhookSubscription {
+	return &domainModel.WebhookSubscription{
+		ID:         d.ID,
+		URL:        d.URL,
+		EventTypes: d.EventTypes,
+		Secret:     d.Secret,
+		CreatedBy:  d.CreatedBy,
+		CreatedAt:  d.CreatedAt,
+	}
+}
+
+func FromDomainWebhookSubscription(s *domainModel.WebhookSubscription) *WebhookSubscriptionDocument {
+	return &WebhookSubscriptionDocument{
+		ID:         s.ID,
+		URL:        s.URL,
+		EventTypes: s.EventTypes,
+		Secret:     s.Secret,
+		CreatedBy:  s.CreatedBy,
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+type WebhookDeliveryDocument struct {
+	ID               string    `bson:"_id"`
+	SubscriptionID   string    `bson:"subscription_id"`
+	EventID          string    `bson:"event_id"`
+	EventType        string    `bson:"event_type"`
+	Payload          []byte    `bson:"payload"`
+	CreatedAt        time.Time `bson:"created_at"`
+	DeliveryDocument `bson:",inline"`
+}
+
+func (d *WebhookDeliveryDocument) ToDomain() *domainModel.WebhookDelivery {
+	return &domainModel.WebhookDelivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		CreatedAt:      d.CreatedAt,
+		Delivery:       *d.DeliveryDocument.ToDomain(),
+	}
+}
+
+func FromDomainWebhookDelivery(d *domainModel.WebhookDelivery) *WebhookDeliveryDocument {
+	return &WebhookDeliveryDocument{
+		ID:               d.ID,
+		SubscriptionID:   d.SubscriptionID,
+		EventID:          d.EventID,
+		EventType:        d.EventType,
+		Payload:          d.Payload,
+		CreatedAt:        d.CreatedAt,
+		DeliveryDocument: *FromDomainDelivery(&d.Delivery),
+	}
+}
+
+type WebhookDeadLetterDocument struct {
+	ID             string    `bson:"_id"`
+	SubscriptionID string    `bson:"subscription_id"`
+	EventID        string    `bson:"event_id"`
+	EventType      string    `bson:"event_type"`
+	Payload        []byte    `bson:"payload"`
+	Attempts       int       `bson:"attempts"`
+	LastError      string    `bson:"last_error"`
+	CreatedAt      time.Time `bson:"created_at"`
+	FailedAt       time.Time `bson:"failed_at"`
+}
+
+func (d *WebhookDeadLetterDocument) ToDomain() *domainModel.WebhookDeadLetter {
+	return &domainModel.WebhookDeadLetter{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Attempts:       d.Attempts,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		FailedAt:       d.FailedAt,
+	}
+}
+
+func FromDomainWebhookDeadLetter(l *domainModel.WebhookDeadLetter) *WebhookDeadLetterDocument {
+	return &WebhookDeadLetterDocument{
+		ID:             l.ID,
+		SubscriptionID: l.SubscriptionID,
+		EventID:        l.EventID,
+		EventType:      l.EventType,
+		Payload:        l.Payload,
+		Attempts:       l.Attempts,
+		LastError:      l.LastError,
+		CreatedAt:      l.CreatedAt,
+		FailedAt:       l.FailedAt,
+	}
+}
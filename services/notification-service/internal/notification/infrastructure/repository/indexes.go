@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureNotificationIndexes creates the indexes behind the inbox queries: a recipient's
+// notifications, optionally only the unread ones, newest first, and the unread count. The
+// other indexes serve the list filters by type, sender and department, and the text index
+// the search over messages. It does not stem words, as messages are not all in English.
+// Creating an index that already exists is a no-op.
+func EnsureNotificationIndexes(ctx context.Context, collection *mongo.Collection) error {
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "to_starter", Value: 1},
+				{Key: "read_at", Value: 1},
+				{Key: "timestamp", Value: -1},
+			},
+			Options: options.Index().SetName("to_starter_read_at_timestamp"),
+		},
+		{
+			Keys:    bson.D{{Key: "type", Value: 1}, {Key: "timestamp", Value: -1}},
+			Options: options.Index().SetName("type_timestamp"),
+		},
+		{
+			Keys:    bson.D{{Key: "from_starter", Value: 1}, {Key: "timestamp", Value: -1}},
+			Options: options.Index().SetName("from_starter_timestamp"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "data.department_id", Value: 1},
+				{Key: "type", Value: 1},
+				{Key: "timestamp", Value: -1},
+			},
+			Options: options.Index().
+				SetName("department_type_timestamp").
+				SetPartialFilterExpression(bson.D{{Key: "data.department_id", Value: bson.D{{Key: "$exists", Value: true}}}}),
+		},
+		{
+			Keys:    bson.D{{Key: "message", Value: "text"}},
+			Options: options.Index().SetName("message_text").SetDefaultLanguage("none"),
+		},
+	})
+	return err
+}
+
+// EnsureDeliveryIndexes creates the index used to claim the due deliveries of a channel.
+// It is sparse, so notifications that are not delivered through the channel stay out of it.
+func EnsureDeliveryIndexes(ctx context.Context, collection *mongo.Collection, channel string) error {
+	prefix := "deliveries." + channel + "."
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: prefix + "status", Value: 1},
+			{Key: prefix + "next_attempt_at", Value: 1},
+		},
+		Options: options.Index().SetName(channel + "_delivery_due").SetSparse(true),
+	})
+	return err
+}
+
+// EnsureDigestIndexes creates the index used to list the notifications held for a
+// recipient's digest on a channel.
+func EnsureDigestIndexes(ctx context.Context, collection *mongo.Collection, channel string) error {
+	prefix := "deliveries." + channel + "."
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: prefix + "status", Value: 1},
+			{Key: "to_starter", Value: 1},
+			{Key: "timestamp", Value: 1},
+		},
+		Options: options.Index().SetName(channel + "_digest").SetSparse(true),
+	})
+	return err
+}
+
+// EnsureWebhookIndexes creates the indexes used to find the subscriptions to an event type,
+// claim due webhook deliveries and list dead letters.
+func EnsureWebhookIndexes(ctx context.Context, subscriptions, deliveries, deadLetters *mongo.Collection) error {
+	if _, err := subscriptions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "event_types", Value: 1}},
+		Options: options.Index().SetName("event_types"),
+	}); err != nil {
+		return err
+	}
+	if _, err := deliveries.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+		Options: options.Index().SetName("delivery_due"),
+	}); err != nil {
+		return err
+	}
+	_, err := deadLetters.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "subscription_id", Value: 1}, {Key: "failed_at", Value: -1}},
+		Options: options.Index().SetName("subscription_id_failed_at"),
+	})
+	return err
+}
+
+// EnsurePreferenceIndexes creates the index used to claim due digests. It is sparse, so
+// recipients without digest stay out of it.
+func EnsurePreferenceIndexes(ctx context.Context, collection *mongo.Collection) error {
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "next_digest_at", Value: 1}},
+		Options: options.Index().SetName("next_digest_at").SetSparse(true),
+	})
+	return err
+}
+
+// EnsureDedupeIndexes makes MongoDB delete notification keys once their window has passed.
+func EnsureDedupeIndexes(ctx context.Context, collection *mongo.Collection) error {
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
+const readTTLIndex = "read_ttl"
+
+// Server error codes of an index that exists with other options
+const (
+	indexOptionsConflictCode  = 85
+	indexKeySpecsConflictCode = 86
+	indexNotFoundCode         = 27
+	namespaceNotFoundCode     = 26
+)
+
+// EnsureRetentionIndexes makes MongoDB delete read notifications once their timestamp is
+// older than readRetention, through a TTL index that only covers read notifications. The
+// index is updated when the retention changes, and dropped when readRetention is zero.
+func EnsureRetentionIndexes(ctx context.Context, collection *mongo.Collection, readRetention time.Duration) error {
+	if readRetention <= 0 {
+		_, err := collection.Indexes().DropOne(ctx, readTTLIndex)
+		if hasServerCode(err, indexNotFoundCode, namespaceNotFoundCode) {
+			return nil
+		}
+		return err
+	}
+
+	expireAfter := int32(readRetention / time.Second)
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "timestamp", Value: 1}},
+		Options: options.Index().
+			SetName(readTTLIndex).
+			SetExpireAfterSeconds(expireAfter).
+			SetPartialFilterExpression(bson.D{{Key: "read_at", Value: bson.D{{Key: "$type", Value: "date"}}}}),
+	})
+	if !hasServerCode(err, indexOptionsConflictCode, indexKeySpecsConflictCode) {
+		return err
+	}
+
+	// The index exists with another retention
+	return collection.Database().RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: collection.Name()},
+		{Key: "index", Value: bson.D{
+			{Key: "name", Value: readTTLIndex},
+			{Key: "expireAfterSeconds", Value: expireAfter},
+		}},
+	}).Err()
+}
+
+// EnsureArchiveIndexes creates the index used to find the oldest notifications to archive,
+// and the recipient index on the archive.
+func EnsureArchiveIndexes(ctx context.Context, notifications, archive *mongo.Collection) error {
+	if _, err := notifications.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("timestamp_id"),
+	}); err != nil {
+		return err
+	}
+	_, err := archive.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "to_starter", Value: 1}, {Key: "timestamp", Value: -1}},
+		Options: options.Index().SetName("to_starter_timestamp"),
+	})
+	return err
+}
+
+func hasServerCode(err error, codes ...int) bool {
+	var serverErr mongo.ServerError
+	if !errors.As(err, &serverErr) {
+		return false
+	}
+	for _, code := range codes {
+		if serverErr.HasErrorCode(code) {
+			return true
+		}
+	}
+	return false
+}
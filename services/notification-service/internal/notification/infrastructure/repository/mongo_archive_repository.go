@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	domainModel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	domainRepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ArchiveCollectionSuffix names the archive after the notifications collection unless
+// another name is configured.
+const ArchiveCollectionSuffix = "_archive"
+
+type notificationArchiveMongoRepository struct {
+	notifications *mongo.Collection
+	archive       *mongo.Collection
+}
+
+func NewNotificationArchiveMongoRepository(notifications, archive *mongo.Collection) domainRepo.NotificationArchiveRepository {
+	return &notificationArchiveMongoRepository{notifications: notifications, archive: archive}
+}
+
+// Archive copies the documents unchanged, plus their archived_at time, before deleting them.
+// A batch that is interrupted in between is archived again by the next run; the copies that
+// already made it are skipped on their _id.
+func (r *notificationArchiveMongoRepository) Archive(
+	ctx context.Context,
+	cutoff time.Time,
+	channels []string,
+	limit int,
+) (int, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.notifications.Find(ctx, archiveFilter(cutoff, channels), findOptions)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("Error closing MongoDB cursor: %v", err)
+		}
+	}()
+
+	var docs []bson.M
+	if err := cursor.All(ctx, &docs); err != nil {
+		return 0, err
+	}
+	if len(docs) == 0 {
+		return 0, nil
+	}
+
+	archivedAt := time.Now().UTC()
+	ids := make(bson.A, 0, len(docs))
+	copies := make([]interface{}, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc["_id"])
+		doc["archived_at"] = archivedAt
+		copies = append(copies, doc)
+	}
+	if _, err := r.archive.InsertMany(ctx, copies, options.InsertMany().SetOrdered(false)); err != nil && !onlyDuplicateKeys(err) {
+		return 0, err
+	}
+
+	deleted, err := r.notifications.DeleteMany(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}})
+	if err != nil {
+		return 0, err
+	}
+	return int(deleted.DeletedCount), nil
+}
+
+// archiveFilter matches notifications from before cutoff that no channel is still waiting to
+// send; a notification without a delivery on a channel is not waiting for it.
+func archiveFilter(cutoff time.Time, channels []string) bson.D {
+	filter := bson.D{{Key: "timestamp", Value: bson.D{{Key: "$lt", Value: cutoff}}}}
+	waiting := bson.A{
+		string(domainModel.DeliveryPending),
+		string(domainModel.DeliverySending),
+		string(domainModel.DeliveryDigest),
+	}
+	for _, channel := range channels {
+		filter = append(filter, bson.E{Key: "deliveries." + channel + ".status", Value: bson.D{{Key: "$nin", Value: waiting}}})
+	}
+	return filter
+}
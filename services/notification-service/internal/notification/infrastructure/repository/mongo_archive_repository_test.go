@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestArchiveFilter(t *testing.T) {
+	cutoff := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	waiting := bson.D{{Key: "$nin", Value: bson.A{"pending", "sending", "digest"}}}
+
+	tests := []struct {
+		name     string
+		channels []string
+		want     bson.D
+	}{
+		{
+			name: "without channels",
+			want: bson.D{{Key: "timestamp", Value: bson.D{{Key: "$lt", Value: cutoff}}}},
+		},
+		{
+			name:     "keeps deliveries still waiting",
+			channels: []string{"email", "webhook"},
+			want: bson.D{
+				{Key: "timestamp", Value: bson.D{{Key: "$lt", Value: cutoff}}},
+				{Key: "deliveries.email.status", Value: waiting},
+				{Key: "deliveries.webhook.status", Value: waiting},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bson.MarshalExtJSON(archiveFilter(cutoff, tt.channels), true, false)
+			if err != nil {
+				t.Fatalf("failed to marshal filter: %v", err)
+			}
+			want, _ := bson.MarshalExtJSON(tt.want, true, false)
+			if string(got) != string(want) {
+				t.Errorf("archiveFilter() = %s; want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestOnlyDuplicateKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"no error", nil, false},
+		{"other error", errors.New("connection refused"), false},
+		{
+			"copies already archived",
+			mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{
+				{WriteError: mongo.WriteError{Code: duplicateKeyCode}},
+				{WriteError: mongo.WriteError{Code: duplicateKeyCode}},
+			}},
+			true,
+		},
+		{
+			"other write error",
+			mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{
+				{WriteError: mongo.WriteError{Code: duplicateKeyCode}},
+				{WriteError: mongo.WriteError{Code: 121}},
+			}},
+			false,
+		},
+		{
+			"write concern error",
+			mongo.BulkWriteException{WriteConcernError: &mongo.WriteConcernError{Code: 64}},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := onlyDuplicateKeys(tt.err); got != tt.want {
+				t.Errorf("onlyDuplicateKeys() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
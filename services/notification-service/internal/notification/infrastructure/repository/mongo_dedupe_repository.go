@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	domainRepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DedupeCollection keeps the keys of recently stored notifications
+const DedupeCollection = "notification_dedupe"
+
+type dedupeMongoRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDedupeMongoRepository(collection *mongo.Collection) domainRepo.NotificationDedupeRepository {
+	return &dedupeMongoRepository{collection: collection}
+}
+
+func (r *dedupeMongoRepository) Claim(
+	ctx context.Context,
+	key, id string,
+	at time.Time,
+	window time.Duration,
+) (bool, error) {
+	// The key is free once its window has passed, and stays with the notification holding it.
+	// When neither holds, the upsert inserts a second document with the same _id, which fails:
+	// that makes the claim atomic across instances.
+	claimable := bson.D{
+		{Key: "_id", Value: key},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "expires_at", Value: bson.D{{Key: "$lte", Value: at}}}},
+			bson.D{{Key: "notification_id", Value: id}},
+		}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "notification_id", Value: id},
+		{Key: "expires_at", Value: at.Add(window)},
+	}}}
+
+	_, err := r.collection.UpdateOne(ctx, claimable, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
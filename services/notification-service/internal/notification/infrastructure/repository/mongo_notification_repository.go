@@ -3,10 +3,13 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/kiin21/go-rest/pkg/httputil"
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
 	domainModel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
 	domainRepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
 	"github.com/kiin21/go-rest/services/notification-service/internal/notification/infrastructure/repository/document"
@@ -38,12 +41,29 @@ func (r *notificationMongoRepository) List(
 	sortBy := mapSortField(filter.SortBy)
 	sortOrder := mapSortOrder(filter.SortOrder)
 
+	match := listFilter(filter)
+
 	findOptions := options.Find()
-	findOptions.SetSort(bson.D{{Key: sortBy, Value: sortOrder}})
-	findOptions.SetSkip(int64(pg.GetOffset()))
-	findOptions.SetLimit(int64(pg.GetLimit()))
+	query := match
+	if pg.IsCursorMode() {
+		// Keyset pagination: _id breaks ties so equal sort values are neither skipped nor repeated,
+		// and one extra document tells whether there is a next page
+		findOptions.SetSort(bson.D{{Key: sortBy, Value: sortOrder}, {Key: "_id", Value: sortOrder}})
+		findOptions.SetLimit(int64(pg.GetLimit() + 1))
+		if !pg.Cursor.IsFirstPage() {
+			keyset, err := buildKeysetFilter(pg.Cursor, sortBy, sortOrder)
+			if err != nil {
+				return nil, 0, err
+			}
+			query = bson.D{{Key: "$and", Value: bson.A{match, keyset}}}
+		}
+	} else {
+		findOptions.SetSort(bson.D{{Key: sortBy, Value: sortOrder}})
+		findOptions.SetSkip(int64(pg.GetOffset()))
+		findOptions.SetLimit(int64(pg.GetLimit()))
+	}
 
-	cursor, err := r.collection.Find(ctx, bson.D{}, findOptions)
+	cursor, err := r.collection.Find(ctx, query, findOptions)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -67,7 +87,12 @@ func (r *notificationMongoRepository) List(
 		return nil, 0, err
 	}
 
-	total, err := r.collection.CountDocuments(ctx, bson.D{})
+	// Cursor pages do not report a total, which saves a collection scan per request
+	if pg.IsCursorMode() {
+		return results, 0, nil
+	}
+
+	total, err := r.countTotal(ctx, match, pg, len(results))
 	if err != nil {
 		return nil, 0, err
 	}
@@ -75,18 +100,304 @@ func (r *notificationMongoRepository) List(
 	return results, total, nil
 }
 
-func (r *notificationMongoRepository) Create(ctx context.Context, notification *domainModel.Notification) error {
+// countTotal counts the documents matching match without a query where it can: a page that
+// is not full is the last one, so the total follows from its offset, and the total of an
+// unfiltered list is estimated from the collection metadata.
+func (r *notificationMongoRepository) countTotal(
+	ctx context.Context,
+	match bson.D,
+	pg httputil.ReqPagination,
+	pageSize int,
+) (int64, error) {
+	if pageSize < pg.GetLimit() && (pageSize > 0 || pg.GetOffset() == 0) {
+		return int64(pg.GetOffset() + pageSize), nil
+	}
+	if len(match) == 0 {
+		return r.collection.EstimatedDocumentCount(ctx)
+	}
+	return r.collection.CountDocuments(ctx, match)
+}
+
+func (r *notificationMongoRepository) Create(ctx context.Context, notification *domainModel.Notification) (bool, error) {
 	if notification == nil {
-		return errors.New("notification is nil")
+		return false, errors.New("notification is nil")
 	}
 
 	// Convert domain model to document with BSON tags
 	doc := document.FromDomain(notification)
 
-	if _, err := r.collection.InsertOne(ctx, doc); err != nil {
-		return err
+	// Upserted on its ID, so storing a redelivered event again changes nothing, e.g. the read
+	// time or deliveries the notification has since
+	result, err := r.collection.UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: doc.ID}},
+		bson.D{{Key: "$setOnInsert", Value: doc}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.UpsertedCount > 0, nil
+}
+
+func (r *notificationMongoRepository) MarkRead(
+	ctx context.Context,
+	id, toStarter string,
+	readAt time.Time,
+) (*domainModel.Notification, error) {
+	own := bson.D{{Key: "_id", Value: id}, {Key: "to_starter", Value: toStarter}}
+
+	// Only unread notifications are updated, so the first read time is kept
+	unread := bson.D{{Key: "_id", Value: id}, {Key: "to_starter", Value: toStarter}, {Key: "read_at", Value: nil}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "read_at", Value: readAt}}}}
+	if _, err := r.collection.UpdateOne(ctx, unread, update); err != nil {
+		return nil, err
+	}
+
+	var doc document.NotificationDocument
+	if err := r.collection.FindOne(ctx, own).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return doc.ToDomain(), nil
+}
+
+func (r *notificationMongoRepository) MarkAllRead(ctx context.Context, toStarter string, readAt time.Time) (int64, error) {
+	unread := bson.D{{Key: "to_starter", Value: toStarter}, {Key: "read_at", Value: nil}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "read_at", Value: readAt}}}}
+
+	result, err := r.collection.UpdateMany(ctx, unread, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *notificationMongoRepository) CountUnread(ctx context.Context, toStarter string) (int64, error) {
+	unread := bson.D{{Key: "to_starter", Value: toStarter}, {Key: "read_at", Value: nil}}
+	return r.collection.CountDocuments(ctx, unread)
+}
+
+func (r *notificationMongoRepository) ListAfter(
+	ctx context.Context,
+	toStarter, afterID string,
+	limit int,
+) ([]*domainModel.Notification, error) {
+	var last document.NotificationDocument
+	own := bson.D{{Key: "_id", Value: afterID}, {Key: "to_starter", Value: toStarter}}
+	if err := r.collection.FindOne(ctx, own).Decode(&last); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainErr.ErrNotFound
+		}
+		return nil, err
+	}
+
+	after := &httputil.Cursor{Value: last.Timestamp.Format(time.RFC3339Nano), ID: last.ID}
+	keyset, err := buildKeysetFilter(after, "timestamp", 1)
+	if err != nil {
+		return nil, err
+	}
+	query := bson.D{{Key: "$and", Value: bson.A{bson.D{{Key: "to_starter", Value: toStarter}}, keyset}}}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("Error closing MongoDB cursor: %v", err)
+		}
+	}()
+
+	var docs []document.NotificationDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	results := make([]*domainModel.Notification, 0, len(docs))
+	for i := range docs {
+		results = append(results, docs[i].ToDomain())
+	}
+	return results, nil
+}
+
+func (r *notificationMongoRepository) ClaimDueDelivery(
+	ctx context.Context,
+	channel string,
+	now time.Time,
+	lease time.Duration,
+) (*domainModel.Notification, error) {
+	prefix := "deliveries." + channel + "."
+	// Pending deliveries that are due, and claims of instances that stopped before finishing
+	due := bson.D{
+		{Key: prefix + "status", Value: bson.D{{Key: "$in", Value: bson.A{
+			string(domainModel.DeliveryPending), string(domainModel.DeliverySending),
+		}}}},
+		{Key: prefix + "next_attempt_at", Value: bson.D{{Key: "$lte", Value: now}}},
 	}
-	return nil
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: prefix + "status", Value: string(domainModel.DeliverySending)},
+			{Key: prefix + "next_attempt_at", Value: now.Add(lease)},
+			{Key: prefix + "updated_at", Value: now},
+		}},
+		{Key: "$inc", Value: bson.D{{Key: prefix + "attempts", Value: 1}}},
+	}
+	findOptions := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: prefix + "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var doc document.NotificationDocument
+	if err := r.collection.FindOneAndUpdate(ctx, due, update, findOptions).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return doc.ToDomain(), nil
+}
+
+func (r *notificationMongoRepository) SaveDelivery(
+	ctx context.Context,
+	id, channel string,
+	delivery *domainModel.Delivery,
+) error {
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "deliveries." + channel, Value: document.FromDomainDelivery(delivery)},
+	}}}
+	_, err := r.collection.UpdateOne(ctx, bson.D{{Key: "_id", Value: id}}, update)
+	return err
+}
+
+func (r *notificationMongoRepository) ListDigest(
+	ctx context.Context,
+	channel, toStarter string,
+	limit int,
+) ([]*domainModel.Notification, error) {
+	filter := bson.D{
+		{Key: "deliveries." + channel + ".status", Value: string(domainModel.DeliveryDigest)},
+		{Key: "to_starter", Value: toStarter},
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("Error closing MongoDB cursor: %v", err)
+		}
+	}()
+
+	var docs []document.NotificationDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	results := make([]*domainModel.Notification, 0, len(docs))
+	for i := range docs {
+		results = append(results, docs[i].ToDomain())
+	}
+	return results, nil
+}
+
+func (r *notificationMongoRepository) MarkDigestSent(
+	ctx context.Context,
+	channel string,
+	ids []string,
+	sentAt time.Time,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	prefix := "deliveries." + channel + "."
+	filter := bson.D{
+		{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}},
+		{Key: prefix + "status", Value: string(domainModel.DeliveryDigest)},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: prefix + "status", Value: string(domainModel.DeliverySent)},
+			{Key: prefix + "delivered_at", Value: sentAt},
+			{Key: prefix + "updated_at", Value: sentAt},
+		}},
+		{Key: "$inc", Value: bson.D{{Key: prefix + "attempts", Value: 1}}},
+	}
+	_, err := r.collection.UpdateMany(ctx, filter, update)
+	return err
+}
+
+// listFilter matches the notifications of a list request. A nil read_at also matches
+// documents stored before read state existed. The search goes through the message text index.
+func listFilter(filter domainRepo.ListNotificationsFilter) bson.D {
+	match := bson.D{}
+	if filter.ToStarter != "" {
+		match = append(match, bson.E{Key: "to_starter", Value: filter.ToStarter})
+	}
+	if filter.FromStarter != "" {
+		match = append(match, bson.E{Key: "from_starter", Value: filter.FromStarter})
+	}
+	switch len(filter.Types) {
+	case 0:
+	case 1:
+		match = append(match, bson.E{Key: "type", Value: filter.Types[0]})
+	default:
+		match = append(match, bson.E{Key: "type", Value: bson.D{{Key: "$in", Value: filter.Types}}})
+	}
+	if filter.DepartmentID != "" {
+		match = append(match, bson.E{Key: "data.department_id", Value: filter.DepartmentID})
+	}
+	if filter.Since != nil || filter.Until != nil {
+		timestamp := bson.D{}
+		if filter.Since != nil {
+			timestamp = append(timestamp, bson.E{Key: "$gte", Value: *filter.Since})
+		}
+		if filter.Until != nil {
+			timestamp = append(timestamp, bson.E{Key: "$lt", Value: *filter.Until})
+		}
+		match = append(match, bson.E{Key: "timestamp", Value: timestamp})
+	}
+	if filter.Search != "" {
+		match = append(match, bson.E{Key: "$text", Value: bson.D{{Key: "$search", Value: filter.Search}}})
+	}
+	if filter.Read != nil {
+		if *filter.Read {
+			match = append(match, bson.E{Key: "read_at", Value: bson.D{{Key: "$ne", Value: nil}}})
+		} else {
+			match = append(match, bson.E{Key: "read_at", Value: nil})
+		}
+	}
+	return match
+}
+
+// buildKeysetFilter selects the documents that come after the cursor in the given sort.
+func buildKeysetFilter(c *httputil.Cursor, sortBy string, sortOrder int) (bson.D, error) {
+	var value any = c.Value
+	if sortBy == "timestamp" {
+		t, err := c.TimeValue()
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor timestamp: %w", err)
+		}
+		value = t
+	}
+
+	op := "$gt"
+	if sortOrder < 0 {
+		op = "$lt"
+	}
+
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: sortBy, Value: bson.D{{Key: op, Value: value}}}},
+		bson.D{
+			{Key: sortBy, Value: value},
+			{Key: "_id", Value: bson.D{{Key: op, Value: c.ID}}},
+		},
+	}}}, nil
 }
 
 func mapSortField(input string) string {
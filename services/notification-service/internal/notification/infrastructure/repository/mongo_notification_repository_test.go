@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	domainRepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestListFilter(t *testing.T) {
+	since := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	read, unread := true, false
+
+	tests := []struct {
+		name   string
+		filter domainRepo.ListNotificationsFilter
+		want   bson.D
+	}{
+		{"everyone's", domainRepo.ListNotificationsFilter{}, bson.D{}},
+		{
+			"recipient and sender",
+			domainRepo.ListNotificationsFilter{ToStarter: "thanhnt", FromStarter: "hr.admin"},
+			bson.D{{Key: "to_starter", Value: "thanhnt"}, {Key: "from_starter", Value: "hr.admin"}},
+		},
+		{
+			"one type",
+			domainRepo.ListNotificationsFilter{Types: []string{"notification.starter_created"}},
+			bson.D{{Key: "type", Value: "notification.starter_created"}},
+		},
+		{
+			"several types",
+			domainRepo.ListNotificationsFilter{Types: []string{"notification.starter_created", "notification.starter_deleted"}},
+			bson.D{{Key: "type", Value: bson.D{{Key: "$in", Value: []string{"notification.starter_created", "notification.starter_deleted"}}}}},
+		},
+		{
+			"department",
+			domainRepo.ListNotificationsFilter{DepartmentID: "12"},
+			bson.D{{Key: "data.department_id", Value: "12"}},
+		},
+		{
+			"time range",
+			domainRepo.ListNotificationsFilter{Since: &since, Until: &until},
+			bson.D{{Key: "timestamp", Value: bson.D{{Key: "$gte", Value: since}, {Key: "$lt", Value: until}}}},
+		},
+		{
+			"open ended",
+			domainRepo.ListNotificationsFilter{Since: &since},
+			bson.D{{Key: "timestamp", Value: bson.D{{Key: "$gte", Value: since}}}},
+		},
+		{
+			"search",
+			domainRepo.ListNotificationsFilter{Search: "engineering"},
+			bson.D{{Key: "$text", Value: bson.D{{Key: "$search", Value: "engineering"}}}},
+		},
+		{
+			"read",
+			domainRepo.ListNotificationsFilter{Read: &read},
+			bson.D{{Key: "read_at", Value: bson.D{{Key: "$ne", Value: nil}}}},
+		},
+		{
+			"unread, including documents without read state",
+			domainRepo.ListNotificationsFilter{Read: &unread},
+			bson.D{{Key: "read_at", Value: nil}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bson.MarshalExtJSON(listFilter(tt.filter), true, false)
+			if err != nil {
+				t.Fatalf("failed to marshal filter: %v", err)
+			}
+			want, _ := bson.MarshalExtJSON(tt.want, true, false)
+			if string(got) != string(want) {
+				t.Errorf("listFilter() = %s; want %s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+	domainModel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	domainRepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/infrastructure/repository/document"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PreferencesCollection keeps the recipients' notification preferences
+const PreferencesCollection = "notification_preferences"
+
+type preferenceMongoRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPreferenceMongoRepository(collection *mongo.Collection) domainRepo.PreferenceRepository {
+	return &preferenceMongoRepository{collection: collection}
+}
+
+func (r *preferenceMongoRepository) Get(ctx context.Context, recipient string) (*domainModel.Preferences, error) {
+	var doc document.PreferencesDocument
+	if err := r.collection.FindOne(ctx, bson.D{{Key: "_id", Value: recipient}}).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return doc.ToDomain(), nil
+}
+
+func (r *preferenceMongoRepository) Save(ctx context.Context, preferences *domainModel.Preferences) error {
+	doc := document.FromDomainPreferences(preferences)
+	_, err := r.collection.ReplaceOne(ctx, bson.D{{Key: "_id", Value: doc.ID}}, doc, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *preferenceMongoRepository) ClaimDueDigest(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+) (*domainModel.Preferences, error) {
+	due := bson.D{{Key: "next_digest_at", Value: bson.D{{Key: "$lte", Value: now}}}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "next_digest_at", Value: now.Add(lease)}}}}
+	findOptions := options.FindOneAndUpdate().SetSort(bson.D{{Key: "next_digest_at", Value: 1}})
+
+	var doc document.PreferencesDocument
+	if err := r.collection.FindOneAndUpdate(ctx, due, update, findOptions).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return doc.ToDomain(), nil
+}
+
+func (r *preferenceMongoRepository) SetNextDigest(ctx context.Context, recipient string, at *time.Time) error {
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "next_digest_at", Value: at}}}}
+	_, err := r.collection.UpdateOne(ctx, bson.D{{Key: "_id", Value: recipient}}, update)
+	return err
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+	domainModel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	domainRepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/infrastructure/repository/document"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TemplatesCollection keeps the notification templates that replace the built-in ones
+const TemplatesCollection = "notification_templates"
+
+type templateMongoRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTemplateMongoRepository(collection *mongo.Collection) domainRepo.TemplateRepository {
+	return &templateMongoRepository{collection: collection}
+}
+
+func (r *templateMongoRepository) List(ctx context.Context) ([]*domainModel.NotificationTemplate, error) {
+	cursor, err := r.collection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []document.TemplateDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	results := make([]*domainModel.NotificationTemplate, 0, len(docs))
+	for i := range docs {
+		results = append(results, docs[i].ToDomain())
+	}
+	return results, nil
+}
+
+func (r *templateMongoRepository) Get(ctx context.Context, eventType, locale string) (*domainModel.NotificationTemplate, error) {
+	var doc document.TemplateDocument
+	filter := bson.D{{Key: "_id", Value: document.TemplateID(eventType, locale)}}
+	if err := r.collection.FindOne(ctx, filter).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return doc.ToDomain(), nil
+}
+
+func (r *templateMongoRepository) Save(ctx context.Context, template *domainModel.NotificationTemplate) error {
+	doc := document.FromDomainTemplate(template)
+	_, err := r.collection.ReplaceOne(ctx, bson.D{{Key: "_id", Value: doc.ID}}, doc, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *templateMongoRepository) Delete(ctx context.Context, eventType, locale string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.D{{Key: "_id", Value: document.TemplateID(eventType, locale)}})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domainErr.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+	domainModel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	domainRepo "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/infrastructure/repository/document"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collections the webhook repository keeps its subscriptions, deliveries and dead letters in
+const (
+	WebhookSubscriptionsCollection = "webhook_subscriptions"
+	WebhookDeliveriesCollection    = "webhook_deliveries"
+	WebhookDeadLettersCollection   = "webhook_dead_letters"
+)
+
+type webhookMongoRepository struct {
+	subscriptions *mongo.Collection
+	deliveries    *mongo.Collection
+	deadLetters   *mongo.Collection
+}
+
+func NewWebhookMongoRepository(subscriptions, deliveries, deadLetters *mongo.Collection) domainRepo.WebhookRepository {
+	return &webhookMongoRepository{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		deadLetters:   deadLetters,
+	}
+}
+
+func (r *webhookMongoRepository) CreateSubscription(ctx context.Context, subscription *domainModel.WebhookSubscription) error {
+	_, err := r.subscriptions.InsertOne(ctx, document.FromDomainWebhookSubscription(subscription))
+	return err
+}
+
+func (r *webhookMongoRepository) ListSubscriptions(ctx context.Context) ([]*domainModel.WebhookSubscription, error) {
+	return r.findSubscriptions(ctx, bson.D{})
+}
+
+func (r *webhookMongoRepository) ListSubscriptionsTo(ctx context.Context, eventType string) ([]*domainModel.WebhookSubscription, error) {
+	return r.findSubscriptions(ctx, bson.D{{Key: "event_types", Value: eventType}})
+}
+
+func (r *webhookMongoRepository) findSubscriptions(ctx context.Context, filter bson.D) ([]*domainModel.WebhookSubscription, error) {
+	cursor, err := r.subscriptions.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []document.WebhookSubscriptionDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	results := make([]*domainModel.WebhookSubscription, 0, len(docs))
+	for i := range docs {
+		results = append(results, docs[i].ToDomain())
+	}
+	return results, nil
+}
+
+func (r *webhookMongoRepository) GetSubscription(ctx context.Context, id string) (*domainModel.WebhookSubscription, error) {
+	var doc document.WebhookSubscriptionDocument
+	if err := r.subscriptions.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return doc.ToDomain(), nil
+}
+
+func (r *webhookMongoRepository) DeleteSubscription(ctx context.Context, id string) error {
+	result, err := r.subscriptions.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domainErr.ErrNotFound
+	}
+
+	// Deliveries left behind are dropped by the worker when it finds no subscription
+	own := bson.D{{Key: "subscription_id", Value: id}}
+	if _, err := r.deliveries.DeleteMany(ctx, own); err != nil {
+		return err
+	}
+	_, err = r.deadLetters.DeleteMany(ctx, own)
+	return err
+}
+
+func (r *webhookMongoRepository) EnqueueDeliveries(ctx context.Context, deliveries []*domainModel.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		docs = append(docs, document.FromDomainWebhookDelivery(delivery))
+	}
+	// Unordered, so that deliveries already stored by an earlier read of the event do not stop
+	// the others from being inserted
+	_, err := r.deliveries.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err != nil && !onlyDuplicateKeys(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *webhookMongoRepository) ClaimDueDelivery(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+) (*domainModel.WebhookDelivery, error) {
+	// Pending deliveries that are due, and claims of instances that stopped before finishing
+	due := bson.D{
+		{Key: "status", Value: bson.D{{Key: "$in", Value: bson.A{
+			string(domainModel.DeliveryPending), string(domainModel.DeliverySending),
+		}}}},
+		{Key: "next_attempt_at", Value: bson.D{{Key: "$lte", Value: now}}},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "status", Value: string(domainModel.DeliverySending)},
+			{Key: "next_attempt_at", Value: now.Add(lease)},
+			{Key: "updated_at", Value: now},
+		}},
+		{Key: "$inc", Value: bson.D{{Key: "attempts", Value: 1}}},
+	}
+	findOptions := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var doc document.WebhookDeliveryDocument
+	if err := r.deliveries.FindOneAndUpdate(ctx, due, update, findOptions).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return doc.ToDomain(), nil
+}
+
+func (r *webhookMongoRepository) SaveDelivery(ctx context.Context, delivery *domainModel.WebhookDelivery) error {
+	_, err := r.deliveries.ReplaceOne(ctx,
+		bson.D{{Key: "_id", Value: delivery.ID}},
+		document.FromDomainWebhookDelivery(delivery),
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *webhookMongoRepository) DeleteDelivery(ctx context.Context, id string) error {
+	_, err := r.deliveries.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+	return err
+}
+
+func (r *webhookMongoRepository) SaveDeadLetter(ctx context.Context, letter *domainModel.WebhookDeadLetter) error {
+	_, err := r.deadLetters.ReplaceOne(ctx,
+		bson.D{{Key: "_id", Value: letter.ID}},
+		document.FromDomainWebhookDeadLetter(letter),
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *webhookMongoRepository) ListDeadLetters(
+	ctx context.Context,
+	subscriptionID string,
+	limit int,
+) ([]*domainModel.WebhookDeadLetter, error) {
+	filter := bson.D{}
+	if subscriptionID != "" {
+		filter = bson.D{{Key: "subscription_id", Value: subscriptionID}}
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "failed_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.deadLetters.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []document.WebhookDeadLetterDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	results := make([]*domainModel.WebhookDeadLetter, 0, len(docs))
+	for i := range docs {
+		results = append(results, docs[i].ToDomain())
+	}
+	return results, nil
+}
+
+func (r *webhookMongoRepository) GetDeadLetter(ctx context.Context, id string) (*domainModel.WebhookDeadLetter, error) {
+	var doc document.WebhookDeadLetterDocument
+	if err := r.deadLetters.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return doc.ToDomain(), nil
+}
+
+func (r *webhookMongoRepository) DeleteDeadLetter(ctx context.Context, id string) error {
+	_, err := r.deadLetters.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+	return err
+}
+
+const duplicateKeyCode = 11000
+
+// onlyDuplicateKeys reports whether every write of a bulk insert failed on an existing _id.
+func onlyDuplicateKeys(err error) bool {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		return false
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Code != duplicateKeyCode {
+			return false
+		}
+	}
+	return true
+}
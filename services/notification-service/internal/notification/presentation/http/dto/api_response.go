@@ -23,3 +23,99 @@ type ListNotiListAPIResponseData struct {
 	Data       []*ListNotiResponse     `json:"data"`
 	Pagination httputil.RespPagination `json:"pagination"`
 }
+
+// NotiAPIResponse wraps a single notification.
+type NotiAPIResponse struct {
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Data    *ListNotiResponse `json:"data"`
+	Error   interface{}       `json:"error,omitempty"`
+}
+
+// MarkAllReadAPIResponse wraps the result of marking all notifications as read.
+type MarkAllReadAPIResponse struct {
+	Code    int                  `json:"code"`
+	Message string               `json:"message"`
+	Data    *MarkAllReadResponse `json:"data"`
+	Error   interface{}          `json:"error,omitempty"`
+}
+
+// UnreadCountAPIResponse wraps the unread notification count.
+type UnreadCountAPIResponse struct {
+	Code    int                  `json:"code"`
+	Message string               `json:"message"`
+	Data    *UnreadCountResponse `json:"data"`
+	Error   interface{}          `json:"error,omitempty"`
+}
+
+// WebhookAPIResponse wraps a webhook subscription.
+type WebhookAPIResponse struct {
+	Code    int              `json:"code"`
+	Message string           `json:"message"`
+	Data    *WebhookResponse `json:"data"`
+	Error   interface{}      `json:"error,omitempty"`
+}
+
+// WebhookListAPIResponse wraps the webhook subscriptions.
+type WebhookListAPIResponse struct {
+	Code    int                `json:"code"`
+	Message string             `json:"message"`
+	Data    []*WebhookResponse `json:"data"`
+	Error   interface{}        `json:"error,omitempty"`
+}
+
+// WebhookDeadLetterListAPIResponse wraps webhook dead letters.
+type WebhookDeadLetterListAPIResponse struct {
+	Code    int                          `json:"code"`
+	Message string                       `json:"message"`
+	Data    []*WebhookDeadLetterResponse `json:"data"`
+	Error   interface{}                  `json:"error,omitempty"`
+}
+
+// WebhookReplayAPIResponse wraps the delivery queued by a replay.
+type WebhookReplayAPIResponse struct {
+	Code    int                    `json:"code"`
+	Message string                 `json:"message"`
+	Data    *WebhookReplayResponse `json:"data"`
+	Error   interface{}            `json:"error,omitempty"`
+}
+
+// TemplateAPIResponse wraps a notification template.
+type TemplateAPIResponse struct {
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Data    *TemplateResponse `json:"data"`
+	Error   interface{}       `json:"error,omitempty"`
+}
+
+// TemplateListAPIResponse wraps the notification templates.
+type TemplateListAPIResponse struct {
+	Code    int                 `json:"code"`
+	Message string              `json:"message"`
+	Data    []*TemplateResponse `json:"data"`
+	Error   interface{}         `json:"error,omitempty"`
+}
+
+// PreviewTemplateAPIResponse wraps a rendered template.
+type PreviewTemplateAPIResponse struct {
+	Code    int                      `json:"code"`
+	Message string                   `json:"message"`
+	Data    *PreviewTemplateResponse `json:"data"`
+	Error   interface{}              `json:"error,omitempty"`
+}
+
+// PreferencesAPIResponse wraps a recipient's notification preferences.
+type PreferencesAPIResponse struct {
+	Code    int                  `json:"code"`
+	Message string               `json:"message"`
+	Data    *PreferencesResponse `json:"data"`
+	Error   interface{}          `json:"error,omitempty"`
+}
+
+// IngestStatsAPIResponse wraps the event ingestion counts.
+type IngestStatsAPIResponse struct {
+	Code    int                  `json:"code"`
+	Message string               `json:"message"`
+	Data    *IngestStatsResponse `json:"data"`
+	Error   interface{}          `json:"error,omitempty"`
+}
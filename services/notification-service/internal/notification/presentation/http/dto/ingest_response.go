@@ -0,0 +1,24 @@
+package dto
+
+import notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+
+// IngestStatsResponse holds what became of the events this instance read since it started.
+type IngestStatsResponse struct {
+	Processed   int64 `json:"processed"`
+	Stored      int64 `json:"stored"`
+	Redelivered int64 `json:"redelivered"`
+	Duplicates  int64 `json:"duplicates"`
+	Malformed   int64 `json:"malformed"`
+	Retries     int64 `json:"retries"`
+}
+
+func FromIngestCounts(counts notiapp.IngestCounts) *IngestStatsResponse {
+	return &IngestStatsResponse{
+		Processed:   counts.Processed,
+		Stored:      counts.Stored,
+		Redelivered: counts.Redelivered,
+		Duplicates:  counts.Duplicates,
+		Malformed:   counts.Malformed,
+		Retries:     counts.Retries,
+	}
+}
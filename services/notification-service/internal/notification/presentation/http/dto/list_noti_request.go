@@ -1,11 +1,40 @@
 package dto
 
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kiin21/go-rest/pkg/httputil"
+)
+
 type ListNotiRequest struct {
+	// To is the recipient's starter domain; only HR admins may list someone else's, or
+	// everyone's with "*"
+	To string `form:"to"`
+	// Read keeps only read (true) or unread (false) notifications
+	Read *bool `form:"read"`
+	// Type keeps the notifications of these event types, comma separated
+	Type string `form:"type"`
+	// From keeps the notifications sent by this starter domain
+	From string `form:"from"`
+	// DepartmentID keeps the notifications about this department
+	DepartmentID string `form:"department_id" binding:"omitempty,numeric"`
+	// Since and Until bound the notification time: from Since, and before Until. Both take a
+	// date (YYYY-MM-DD) or an RFC 3339 timestamp.
+	Since string `form:"since"`
+	Until string `form:"until"`
+	// Q searches the words of the messages
+	Q string `form:"q" binding:"omitempty,max=200"`
+
 	SortBy    string `form:"sort_by" binding:"omitempty,oneof=from to type timestamp"`
 	SortOrder string `form:"sort_order" binding:"omitempty,oneof=asc desc"`
 
 	Page  int `form:"page" binding:"omitempty,min=1"`
 	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+
+	// Cursor selects keyset pagination; send it empty for the first page
+	Cursor *string `form:"cursor"`
 }
 
 func (r *ListNotiRequest) SetDefaults() {
@@ -22,3 +51,62 @@ func (r *ListNotiRequest) SetDefaults() {
 		r.SortOrder = "desc"
 	}
 }
+
+// ParseCursor returns the keyset cursor for the request, or nil when paginating by page.
+// SetDefaults must run first so the cursor is checked against the effective sort.
+func (r *ListNotiRequest) ParseCursor() (*httputil.Cursor, error) {
+	if r.Cursor == nil {
+		return nil, nil
+	}
+
+	cursor, err := httputil.ParseCursor(*r.Cursor, r.SortBy, r.SortOrder)
+	if err != nil {
+		return nil, err
+	}
+	if r.SortBy == "timestamp" && !cursor.IsFirstPage() {
+		if _, err := cursor.TimeValue(); err != nil {
+			return nil, httputil.InvalidCursorError("cursor is malformed")
+		}
+	}
+
+	return cursor, nil
+}
+
+// Types returns the event types of the type parameter.
+func (r *ListNotiRequest) Types() []string {
+	var types []string
+	for _, t := range strings.Split(r.Type, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// ParseTimeRange returns the since and until times of the request, nil when not given.
+func (r *ListNotiRequest) ParseTimeRange() (since, until *time.Time, err error) {
+	if since, err = parseTime("since", r.Since); err != nil {
+		return nil, nil, err
+	}
+	if until, err = parseTime("until", r.Until); err != nil {
+		return nil, nil, err
+	}
+	if since != nil && until != nil && !since.Before(*until) {
+		return nil, nil, fmt.Errorf("since must be before until")
+	}
+	return since, until, nil
+}
+
+func parseTime(name, raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	if value, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &value, nil
+	}
+	value, err := time.ParseInLocation(time.DateOnly, raw, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a date (YYYY-MM-DD) or an RFC 3339 timestamp", name)
+	}
+	return &value, nil
+}
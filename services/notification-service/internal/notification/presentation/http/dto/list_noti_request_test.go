@@ -0,0 +1,128 @@
+package dto
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestListNotiRequestTypes(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []string
+	}{
+		{"", nil},
+		{"notification.starter_created", []string{"notification.starter_created"}},
+		{"notification.starter_created,notification.starter_deleted", []string{"notification.starter_created", "notification.starter_deleted"}},
+		{" notification.starter_created , ,notification.starter_deleted,", []string{"notification.starter_created", "notification.starter_deleted"}},
+		{" , ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			req := &ListNotiRequest{Type: tt.raw}
+			if got := req.Types(); !slices.Equal(got, tt.want) {
+				t.Errorf("Types() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListNotiRequestParseTimeRange(t *testing.T) {
+	date := func(day int) *time.Time {
+		value := time.Date(2026, time.March, day, 0, 0, 0, 0, time.Local)
+		return &value
+	}
+	timestamp := func(raw string) *time.Time {
+		value, _ := time.Parse(time.RFC3339, raw)
+		return &value
+	}
+
+	tests := []struct {
+		name      string
+		since     string
+		until     string
+		wantSince *time.Time
+		wantUntil *time.Time
+		wantErr   bool
+	}{
+		{name: "open range"},
+		{name: "dates", since: "2026-03-01", until: "2026-03-10", wantSince: date(1), wantUntil: date(10)},
+		{name: "since only", since: "2026-03-01", wantSince: date(1)},
+		{name: "until only", until: "2026-03-10", wantUntil: date(10)},
+		{
+			name:      "timestamps with offsets",
+			since:     "2026-03-01T08:00:00+07:00",
+			until:     "2026-03-01T10:30:00Z",
+			wantSince: timestamp("2026-03-01T01:00:00Z"),
+			wantUntil: timestamp("2026-03-01T10:30:00Z"),
+		},
+		{name: "date and timestamp", since: "2026-03-01", until: "2026-03-02T00:00:00Z", wantSince: date(1), wantUntil: timestamp("2026-03-02T00:00:00Z")},
+		{name: "same time", since: "2026-03-01", until: "2026-03-01", wantErr: true},
+		{name: "reversed", since: "2026-03-10", until: "2026-03-01", wantErr: true},
+		{name: "bad since", since: "01/03/2026", wantErr: true},
+		{name: "bad until", until: "2026-03-01 10:00", wantErr: true},
+		{name: "no such date", since: "2026-02-30", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &ListNotiRequest{Since: tt.since, Until: tt.until}
+
+			since, until, err := req.ParseTimeRange()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v, %v", since, until)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !sameTime(since, tt.wantSince) || !sameTime(until, tt.wantUntil) {
+				t.Errorf("ParseTimeRange() = %v, %v; want %v, %v", since, until, tt.wantSince, tt.wantUntil)
+			}
+		})
+	}
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func TestListNotiRequestBinding(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr bool
+	}{
+		{"", false},
+		{"type=notification.starter_created,notification.starter_deleted&read=false&from=hr.admin", false},
+		{"department_id=12&since=2026-03-01&q=engineering", false},
+		{"department_id=twelve", true},
+		{"read=maybe", true},
+		{"sort_by=message", true},
+		{"sort_order=up", true},
+		{"limit=101", true},
+		{"page=0&limit=0", false},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/notifications?"+tt.query, nil)
+
+			var req ListNotiRequest
+			if err := ctx.ShouldBindQuery(&req); (err != nil) != tt.wantErr {
+				t.Errorf("ShouldBindQuery() error = %v; wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -7,12 +7,38 @@ import (
 )
 
 type ListNotiResponse struct {
-	ID          string    `json:"id"`
-	FromStarter string    `json:"from_starter"`
-	ToStarter   string    `json:"to_starter"`
-	Message     string    `json:"message"`
-	Type        string    `json:"type"`
-	Timestamp   time.Time `json:"timestamp"`
+	ID          string     `json:"id"`
+	FromStarter string     `json:"from_starter"`
+	ToStarter   string     `json:"to_starter"`
+	Message     string     `json:"message"`
+	Type        string     `json:"type"`
+	Timestamp   time.Time  `json:"timestamp"`
+	ReadAt      *time.Time `json:"read_at"`
+	// Data holds the event variables the message was rendered from
+	Data map[string]string `json:"data,omitempty"`
+}
+
+// MarkAllReadResponse reports how many notifications were marked as read.
+type MarkAllReadResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+// UnreadCountResponse holds the number of unread notifications of the caller.
+type UnreadCountResponse struct {
+	Unread int64 `json:"unread"`
+}
+
+func FromDomainSingle(notification *model.Notification) *ListNotiResponse {
+	return &ListNotiResponse{
+		ID:          notification.ID,
+		FromStarter: notification.FromStarter,
+		ToStarter:   notification.ToStarter,
+		Message:     notification.Message,
+		Type:        notification.Type,
+		Timestamp:   notification.Timestamp,
+		ReadAt:      notification.ReadAt,
+		Data:        notification.Data,
+	}
 }
 
 func FromDomain(notifications []*model.Notification) []*ListNotiResponse {
@@ -22,14 +48,7 @@ func FromDomain(notifications []*model.Notification) []*ListNotiResponse {
 			continue
 		}
 
-		responses = append(responses, &ListNotiResponse{
-			ID:          notification.ID,
-			FromStarter: notification.FromStarter,
-			ToStarter:   notification.ToStarter,
-			Message:     notification.Message,
-			Type:        notification.Type,
-			Timestamp:   notification.Timestamp,
-		})
+		responses = append(responses, FromDomainSingle(notification))
 	}
 
 	return responses
@@ -0,0 +1,54 @@
+package dto
+
+import "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+
+// SavePreferencesRequest replaces the caller's preferences. Settings left out take their
+// defaults.
+type SavePreferencesRequest struct {
+	// EventTypes holds the channel settings per event type; other types use every channel
+	EventTypes map[string]ChannelSettingsDTO `json:"event_types"`
+	QuietHours *QuietHoursDTO                `json:"quiet_hours"`
+	// Digest is off, daily or weekly; weekly digests are sent on Mondays
+	Digest string `json:"digest" example:"daily"`
+	// DigestAt is the time of day digests are sent at
+	DigestAt string `json:"digest_at" example:"08:00"`
+	// Timezone is the IANA name quiet hours and digest times are in
+	Timezone string `json:"timezone" example:"Asia/Ho_Chi_Minh"`
+}
+
+type ChannelSettingsDTO struct {
+	InApp   bool `json:"in_app"`
+	Email   bool `json:"email"`
+	Webhook bool `json:"webhook"`
+}
+
+// QuietHoursDTO is a daily window in which no email is sent; it spans midnight when end is
+// before start.
+type QuietHoursDTO struct {
+	Start string `json:"start" example:"22:00"`
+	End   string `json:"end" example:"07:00"`
+}
+
+func (r *SavePreferencesRequest) ToDomain(recipient string) *model.Preferences {
+	preferences := model.DefaultPreferences(recipient)
+	for eventType, settings := range r.EventTypes {
+		preferences.EventTypes[eventType] = model.ChannelSettings{
+			InApp:   settings.InApp,
+			Email:   settings.Email,
+			Webhook: settings.Webhook,
+		}
+	}
+	if r.QuietHours != nil {
+		preferences.QuietHours = &model.QuietHours{Start: r.QuietHours.Start, End: r.QuietHours.End}
+	}
+	if r.Digest != "" {
+		preferences.Digest = model.DigestFrequency(r.Digest)
+	}
+	if r.DigestAt != "" {
+		preferences.DigestAt = r.DigestAt
+	}
+	if r.Timezone != "" {
+		preferences.Timezone = r.Timezone
+	}
+	return preferences
+}
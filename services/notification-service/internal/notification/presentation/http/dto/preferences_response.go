@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+type PreferencesResponse struct {
+	Recipient  string                        `json:"recipient"`
+	EventTypes map[string]ChannelSettingsDTO `json:"event_types"`
+	QuietHours *QuietHoursDTO                `json:"quiet_hours,omitempty"`
+	Digest     string                        `json:"digest"`
+	DigestAt   string                        `json:"digest_at"`
+	Timezone   string                        `json:"timezone"`
+	// NextDigestAt is when the next digest is sent, absent without digest
+	NextDigestAt *time.Time `json:"next_digest_at,omitempty"`
+	// UpdatedAt is absent while the defaults apply
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// FromDomainPreferences lists the settings of every notification event type, including the
+// ones left at their defaults.
+func FromDomainPreferences(p *model.Preferences) *PreferencesResponse {
+	eventTypes := make(map[string]ChannelSettingsDTO, len(model.NotificationEventTypes))
+	for _, eventType := range model.NotificationEventTypes {
+		settings := p.Settings(eventType)
+		eventTypes[eventType] = ChannelSettingsDTO{
+			InApp:   settings.InApp,
+			Email:   settings.Email,
+			Webhook: settings.Webhook,
+		}
+	}
+	var quietHours *QuietHoursDTO
+	if p.QuietHours != nil {
+		quietHours = &QuietHoursDTO{Start: p.QuietHours.Start, End: p.QuietHours.End}
+	}
+	var updatedAt *time.Time
+	if !p.UpdatedAt.IsZero() {
+		updatedAt = &p.UpdatedAt
+	}
+
+	return &PreferencesResponse{
+		Recipient:    p.Recipient,
+		EventTypes:   eventTypes,
+		QuietHours:   quietHours,
+		Digest:       string(p.Digest),
+		DigestAt:     p.DigestAt,
+		Timezone:     p.Timezone,
+		NextDigestAt: p.NextDigestAt,
+		UpdatedAt:    updatedAt,
+	}
+}
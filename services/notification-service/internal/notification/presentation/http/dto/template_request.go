@@ -0,0 +1,15 @@
+package dto
+
+type SaveTemplateRequest struct {
+	// Body is a Go text/template over the event's variables, e.g. "{{.department_name}}"
+	Body string `json:"body" binding:"required"`
+}
+
+type PreviewTemplateRequest struct {
+	EventType string `json:"event_type" binding:"required"`
+	Locale    string `json:"locale" binding:"required"`
+	// Body is previewed instead of the template in use when set
+	Body string `json:"body"`
+	// Data replaces the sample variables when set
+	Data map[string]string `json:"data"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+type TemplateResponse struct {
+	EventType string `json:"event_type"`
+	Locale    string `json:"locale"`
+	Body      string `json:"body"`
+	// Custom is false for built-in templates
+	Custom    bool       `json:"custom"`
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type PreviewTemplateResponse struct {
+	Message string `json:"message"`
+}
+
+func FromDomainTemplate(t *model.NotificationTemplate) *TemplateResponse {
+	return &TemplateResponse{
+		EventType: t.EventType,
+		Locale:    t.Locale,
+		Body:      t.Body,
+		Custom:    t.Custom,
+		UpdatedBy: t.UpdatedBy,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+func FromDomainTemplates(templates []*model.NotificationTemplate) []*TemplateResponse {
+	responses := make([]*TemplateResponse, 0, len(templates))
+	for _, t := range templates {
+		responses = append(responses, FromDomainTemplate(t))
+	}
+	return responses
+}
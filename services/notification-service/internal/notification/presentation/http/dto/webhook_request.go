@@ -0,0 +1,21 @@
+package dto
+
+type CreateWebhookRequest struct {
+	// URL receives a POST per event; it must be http or https
+	URL string `json:"url" binding:"required"`
+	// EventTypes are the event types to forward, e.g. notification.leader_assignment
+	EventTypes []string `json:"event_types" binding:"required,min=1"`
+	// Secret signs the requests; it is never returned
+	Secret string `json:"secret" binding:"required"`
+}
+
+type ListDeadLettersRequest struct {
+	SubscriptionID string `form:"subscription_id"`
+	Limit          int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+func (r *ListDeadLettersRequest) SetDefaults() {
+	if r.Limit == 0 {
+		r.Limit = 20
+	}
+}
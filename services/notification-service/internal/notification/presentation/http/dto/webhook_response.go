@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+// WebhookResponse describes a subscription; its secret is left out.
+type WebhookResponse struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	CreatedBy  string    `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func FromDomainWebhook(subscription *model.WebhookSubscription) *WebhookResponse {
+	return &WebhookResponse{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		CreatedBy:  subscription.CreatedBy,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
+
+func FromDomainWebhooks(subscriptions []*model.WebhookSubscription) []*WebhookResponse {
+	responses := make([]*WebhookResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		responses = append(responses, FromDomainWebhook(subscription))
+	}
+	return responses
+}
+
+type WebhookDeadLetterResponse struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Event          json.RawMessage `json:"event" swaggertype:"object"`
+	Attempts       int             `json:"attempts"`
+	LastError      string          `json:"last_error"`
+	CreatedAt      time.Time       `json:"created_at"`
+	FailedAt       time.Time       `json:"failed_at"`
+}
+
+func FromDomainDeadLetters(letters []*model.WebhookDeadLetter) []*WebhookDeadLetterResponse {
+	responses := make([]*WebhookDeadLetterResponse, 0, len(letters))
+	for _, letter := range letters {
+		responses = append(responses, &WebhookDeadLetterResponse{
+			ID:             letter.ID,
+			SubscriptionID: letter.SubscriptionID,
+			EventID:        letter.EventID,
+			EventType:      letter.EventType,
+			Event:          letter.Payload,
+			Attempts:       letter.Attempts,
+			LastError:      letter.LastError,
+			CreatedAt:      letter.CreatedAt,
+			FailedAt:       letter.FailedAt,
+		})
+	}
+	return responses
+}
+
+// WebhookReplayResponse identifies the delivery queued by a replay.
+type WebhookReplayResponse struct {
+	DeliveryID    string    `json:"delivery_id"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+}
+
+func FromDomainReplay(delivery *model.WebhookDelivery) *WebhookReplayResponse {
+	return &WebhookReplayResponse{
+		DeliveryID:    delivery.ID,
+		NextAttemptAt: *delivery.NextAttemptAt,
+	}
+}
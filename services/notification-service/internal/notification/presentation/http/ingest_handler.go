@@ -0,0 +1,24 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/httputil"
+	notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/presentation/http/dto"
+)
+
+type IngestHandler struct {
+	stats *notiapp.IngestStats
+}
+
+func NewIngestHandler(stats *notiapp.IngestStats) *IngestHandler {
+	return &IngestHandler{stats: stats}
+}
+
+func (h *IngestHandler) GetStats(ctx *gin.Context) {
+	httputil.Wrap(h.getStats)(ctx)
+}
+
+func (h *IngestHandler) getStats(ctx *gin.Context) (res interface{}, err error) {
+	return dto.FromIngestCounts(h.stats.Snapshot()), nil
+}
@@ -0,0 +1,12 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
+)
+
+// RegisterIngestRoutes registers the event ingestion routes, which only admins may use.
+func RegisterIngestRoutes(rg *gin.RouterGroup, handler *IngestHandler) {
+	route := rg.Group("/admin/ingest", auth.RequireRoles(auth.RoleHRAdmin, auth.RoleOrgAdmin))
+	route.GET("/stats", handler.GetStats)
+}
@@ -0,0 +1,26 @@
+package http
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+)
+
+// requestLocale returns the locale messages are rendered in: the locale query parameter, or
+// the first supported language of the Accept-Language header. Empty means the default.
+func requestLocale(ctx *gin.Context) string {
+	if locale := ctx.Query("locale"); locale != "" {
+		return locale
+	}
+	for _, tag := range strings.Split(ctx.GetHeader("Accept-Language"), ",") {
+		tag, _, _ = strings.Cut(tag, ";")
+		language, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+		language = strings.ToLower(language)
+		if slices.Contains(model.Locales, language) {
+			return language
+		}
+	}
+	return ""
+}
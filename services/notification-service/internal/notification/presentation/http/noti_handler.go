@@ -1,25 +1,32 @@
 package http
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
 	"github.com/kiin21/go-rest/pkg/httputil"
 	notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
 	"github.com/kiin21/go-rest/services/notification-service/internal/notification/presentation/http/dto"
 )
 
 type NotiHandler struct {
 	service     *notiapp.NotiApplicationService
+	templates   *notiapp.TemplateService
 	urlResolver *httputil.RequestURLResolver
 }
 
 func NewNotiHandler(
 	service *notiapp.NotiApplicationService,
+	templates *notiapp.TemplateService,
 	urlResolver *httputil.RequestURLResolver,
 ) *NotiHandler {
 	return &NotiHandler{
 		service:     service,
+		templates:   templates,
 		urlResolver: urlResolver,
 	}
 }
@@ -36,10 +43,34 @@ func (h *NotiHandler) getList(ctx *gin.Context) (res interface{}, err error) {
 	}
 	req.SetDefaults()
 
+	cursor, err := req.ParseCursor()
+	if err != nil {
+		return nil, err
+	}
+
+	since, until, err := req.ParseTimeRange()
+	if err != nil {
+		return nil, httputil.NewAPIError(http.StatusBadRequest, "Invalid query parameters", err.Error())
+	}
+
+	recipient, err := listRecipient(ctx, req.To)
+	if err != nil {
+		return nil, err
+	}
+
 	query := notiapp.ListNotificationsQuery{
+		Recipient:    recipient,
+		Read:         req.Read,
+		Types:        req.Types(),
+		Sender:       req.From,
+		DepartmentID: req.DepartmentID,
+		Since:        since,
+		Until:        until,
+		Search:       strings.TrimSpace(req.Q),
 		Pagination: httputil.ReqPagination{
-			Page:  &req.Page,
-			Limit: &req.Limit,
+			Page:   &req.Page,
+			Limit:  &req.Limit,
+			Cursor: cursor,
 		},
 		SortBy:    req.SortBy,
 		SortOrder: req.SortOrder,
@@ -50,10 +81,73 @@ func (h *NotiHandler) getList(ctx *gin.Context) (res interface{}, err error) {
 		return nil, httputil.NewAPIError(http.StatusInternalServerError, "Failed to list notifications", err.Error())
 	}
 
-	responseData := dto.FromDomain(rawResult.Data)
+	responseData := dto.FromDomain(h.templates.Localize(ctx.Request.Context(), rawResult.Data, requestLocale(ctx)))
 
 	return &httputil.PaginatedResult[*dto.ListNotiResponse]{
 		Data:       responseData,
 		Pagination: httputil.CursorPagination(ctx, rawResult.Pagination),
 	}, nil
 }
+
+func (h *NotiHandler) MarkRead(ctx *gin.Context) {
+	httputil.Wrap(h.markRead)(ctx)
+}
+
+func (h *NotiHandler) markRead(ctx *gin.Context) (res interface{}, err error) {
+	notification, err := h.service.MarkRead(ctx.Request.Context(), ctx.Param("id"), auth.PrincipalFrom(ctx).Subject)
+	if err != nil {
+		if errors.Is(err, domainErr.ErrNotFound) {
+			return nil, httputil.NewAPIError(http.StatusNotFound, "Notification not found", err.Error())
+		}
+		return nil, httputil.NewAPIError(http.StatusInternalServerError, "Failed to mark notification as read", err.Error())
+	}
+
+	notification.Message = h.templates.Render(ctx.Request.Context(), notification, requestLocale(ctx))
+	return dto.FromDomainSingle(notification), nil
+}
+
+func (h *NotiHandler) MarkAllRead(ctx *gin.Context) {
+	httputil.Wrap(h.markAllRead)(ctx)
+}
+
+func (h *NotiHandler) markAllRead(ctx *gin.Context) (res interface{}, err error) {
+	updated, err := h.service.MarkAllRead(ctx.Request.Context(), auth.PrincipalFrom(ctx).Subject)
+	if err != nil {
+		return nil, httputil.NewAPIError(http.StatusInternalServerError, "Failed to mark notifications as read", err.Error())
+	}
+
+	return &dto.MarkAllReadResponse{Updated: updated}, nil
+}
+
+func (h *NotiHandler) GetUnreadCount(ctx *gin.Context) {
+	httputil.Wrap(h.getUnreadCount)(ctx)
+}
+
+func (h *NotiHandler) getUnreadCount(ctx *gin.Context) (res interface{}, err error) {
+	unread, err := h.service.CountUnread(ctx.Request.Context(), auth.PrincipalFrom(ctx).Subject)
+	if err != nil {
+		return nil, httputil.NewAPIError(http.StatusInternalServerError, "Failed to count unread notifications", err.Error())
+	}
+
+	return &dto.UnreadCountResponse{Unread: unread}, nil
+}
+
+// allRecipients is the "to" of an HR admin listing every recipient's notifications.
+const allRecipients = "*"
+
+// listRecipient returns whose notifications are listed: the caller's own unless an HR admin
+// asks for someone else's, or for everyone's, which is the empty recipient.
+func listRecipient(ctx *gin.Context, to string) (string, error) {
+	principal := auth.PrincipalFrom(ctx)
+	if to == "" || to == principal.Subject {
+		return principal.Subject, nil
+	}
+	if !principal.HasRole(auth.RoleHRAdmin) {
+		return "", httputil.NewAPIError(http.StatusForbidden, "Forbidden",
+			"you can only list your own notifications")
+	}
+	if to == allRecipients {
+		return "", nil
+	}
+	return to, nil
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
+	"github.com/kiin21/go-rest/pkg/httputil"
+)
+
+// contextAs returns a request context authenticated as subject with roles.
+func contextAs(subject string, roles ...auth.Role) *gin.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/notifications", nil)
+	ctx.Request = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{Subject: subject, Roles: roles}))
+	return ctx
+}
+
+func TestListRecipient(t *testing.T) {
+	tests := []struct {
+		name          string
+		roles         []auth.Role
+		to            string
+		wantRecipient string
+		wantForbidden bool
+	}{
+		{"own by default", []auth.Role{auth.RoleViewer}, "", "thanhnt", false},
+		{"own by name", []auth.Role{auth.RoleViewer}, "thanhnt", "thanhnt", false},
+		{"someone else's", []auth.Role{auth.RoleViewer}, "someone", "", true},
+		{"everyone's", []auth.Role{auth.RoleLeader}, "*", "", true},
+		{"HR admin, someone else's", []auth.Role{auth.RoleHRAdmin}, "someone", "someone", false},
+		{"HR admin, everyone's", []auth.Role{auth.RoleHRAdmin}, "*", "", false},
+		{"HR admin, own by default", []auth.Role{auth.RoleHRAdmin}, "", "thanhnt", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recipient, err := listRecipient(contextAs("thanhnt", tt.roles...), tt.to)
+
+			var apiErr *httputil.APIError
+			if tt.wantForbidden {
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+					t.Fatalf("expected 403, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if recipient != tt.wantRecipient {
+				t.Errorf("recipient = %q; want %q", recipient, tt.wantRecipient)
+			}
+		})
+	}
+}
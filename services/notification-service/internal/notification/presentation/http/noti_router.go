@@ -5,4 +5,15 @@ import "github.com/gin-gonic/gin"
 func RegisterNotificationRoutes(rg *gin.RouterGroup, handler *NotiHandler) {
 	route := rg.Group("/notifications")
 	route.GET("", handler.GetList)
+	route.GET("/unread-count", handler.GetUnreadCount)
+	route.POST("/read-all", handler.MarkAllRead)
+	route.PATCH("/:id/read", handler.MarkRead)
+}
+
+// RegisterNotificationStreamRoutes registers the streaming routes on a group that also
+// accepts the bearer token as a query parameter.
+func RegisterNotificationStreamRoutes(rg *gin.RouterGroup, handler *StreamHandler) {
+	route := rg.Group("/notifications")
+	route.GET("/stream", handler.Events)
+	route.GET("/ws", handler.WebSocket)
 }
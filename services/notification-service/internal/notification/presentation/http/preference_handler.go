@@ -0,0 +1,55 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
+	"github.com/kiin21/go-rest/pkg/httputil"
+	notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/presentation/http/dto"
+)
+
+type PreferenceHandler struct {
+	service *notiapp.PreferenceService
+}
+
+func NewPreferenceHandler(service *notiapp.PreferenceService) *PreferenceHandler {
+	return &PreferenceHandler{service: service}
+}
+
+func (h *PreferenceHandler) Get(ctx *gin.Context) {
+	httputil.Wrap(h.get)(ctx)
+}
+
+func (h *PreferenceHandler) get(ctx *gin.Context) (res interface{}, err error) {
+	preferences, err := h.service.Get(ctx.Request.Context(), auth.PrincipalFrom(ctx).Subject)
+	if err != nil {
+		return nil, httputil.NewAPIError(http.StatusInternalServerError, "Failed to get notification preferences", err.Error())
+	}
+
+	return dto.FromDomainPreferences(preferences), nil
+}
+
+func (h *PreferenceHandler) Save(ctx *gin.Context) {
+	httputil.Wrap(h.save)(ctx)
+}
+
+func (h *PreferenceHandler) save(ctx *gin.Context) (res interface{}, err error) {
+	var req dto.SavePreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, httputil.NewAPIError(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	preferences, err := h.service.Save(ctx.Request.Context(), req.ToDomain(auth.PrincipalFrom(ctx).Subject))
+	if err != nil {
+		if errors.Is(err, domainErr.ErrInvalidPreferences) {
+			return nil, httputil.NewAPIError(http.StatusBadRequest, "Invalid notification preferences", err.Error())
+		}
+		return nil, httputil.NewAPIError(http.StatusInternalServerError, "Failed to save notification preferences", err.Error())
+	}
+
+	return dto.FromDomainPreferences(preferences), nil
+}
@@ -0,0 +1,10 @@
+package http
+
+import "github.com/gin-gonic/gin"
+
+// RegisterPreferenceRoutes registers the routes of the caller's own notification preferences.
+func RegisterPreferenceRoutes(rg *gin.RouterGroup, handler *PreferenceHandler) {
+	route := rg.Group("/notification-preferences")
+	route.GET("", handler.Get)
+	route.PUT("", handler.Save)
+}
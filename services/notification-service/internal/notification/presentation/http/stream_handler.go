@@ -0,0 +1,203 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
+	"github.com/kiin21/go-rest/pkg/httputil"
+	notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+	domainmodel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/presentation/http/dto"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// streamHeartbeat keeps idle event streams from being closed by proxies
+	streamHeartbeat = 25 * time.Second
+	// streamRetry is how long EventSource clients wait before reconnecting
+	streamRetry = 3 * time.Second
+)
+
+// StreamHandler pushes new notifications to their recipients over Server-Sent Events or a
+// WebSocket. A reconnecting client first gets what it missed after the last notification it
+// received: the Last-Event-ID header for SSE, or the last_event_id query parameter.
+// Messages are rendered in the locale of the request that opened the stream.
+type StreamHandler struct {
+	service   *notiapp.NotiApplicationService
+	templates *notiapp.TemplateService
+	hub       *notiapp.NotificationHub
+}
+
+func NewStreamHandler(
+	service *notiapp.NotiApplicationService,
+	templates *notiapp.TemplateService,
+	hub *notiapp.NotificationHub,
+) *StreamHandler {
+	return &StreamHandler{
+		service:   service,
+		templates: templates,
+		hub:       hub,
+	}
+}
+
+// Events streams the caller's notifications as Server-Sent Events.
+func (h *StreamHandler) Events(ctx *gin.Context) {
+	lastEventID := ctx.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = ctx.Query("last_event_id")
+	}
+
+	sub, missed, err := h.open(ctx.Request.Context(), auth.PrincipalFrom(ctx).Subject, lastEventID)
+	if err != nil {
+		httputil.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to open notification stream", err.Error())
+		return
+	}
+	defer h.hub.Unsubscribe(sub)
+
+	header := ctx.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// Stops nginx from buffering the stream
+	header.Set("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	w := ctx.Writer
+	write := func(format string, args ...interface{}) error {
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			return err
+		}
+		w.Flush()
+		return nil
+	}
+
+	if err := write("retry: %d\n\n", streamRetry.Milliseconds()); err != nil {
+		return
+	}
+	h.pump(ctx.Request.Context(), sub, missed,
+		func(notification *domainmodel.Notification) error {
+			data, err := json.Marshal(h.response(ctx, notification))
+			if err != nil {
+				return err
+			}
+			return write("id: %s\nevent: notification\ndata: %s\n\n", notification.ID, data)
+		},
+		func() error {
+			return write(": keep-alive\n\n")
+		},
+	)
+}
+
+// WebSocket streams the caller's notifications as JSON text messages.
+func (h *StreamHandler) WebSocket(ctx *gin.Context) {
+	recipient := auth.PrincipalFrom(ctx).Subject
+
+	sub, missed, err := h.open(ctx.Request.Context(), recipient, ctx.Query("last_event_id"))
+	if err != nil {
+		httputil.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to open notification stream", err.Error())
+		return
+	}
+	defer h.hub.Unsubscribe(sub)
+
+	server := websocket.Server{
+		// The stream is authenticated by a bearer token rather than cookies, so a page on
+		// another origin gains nothing by opening it
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			defer conn.Close()
+
+			streamCtx, cancel := context.WithCancel(ctx.Request.Context())
+			defer cancel()
+			// Clients send nothing; reading only notices when they go away
+			go func() {
+				defer cancel()
+				_, _ = io.Copy(io.Discard, conn)
+			}()
+
+			h.pump(streamCtx, sub, missed,
+				func(notification *domainmodel.Notification) error {
+					return websocket.JSON.Send(conn, h.response(ctx, notification))
+				},
+				nil,
+			)
+		},
+	}
+	server.ServeHTTP(ctx.Writer, ctx.Request)
+}
+
+// response renders a notification for the stream; the hub shares it between streams, so it
+// is not changed in place.
+func (h *StreamHandler) response(ctx *gin.Context, notification *domainmodel.Notification) *dto.ListNotiResponse {
+	response := dto.FromDomainSingle(notification)
+	response.Message = h.templates.Render(ctx.Request.Context(), notification, requestLocale(ctx))
+	return response
+}
+
+// open subscribes before replaying, so that nothing stored in between is lost.
+func (h *StreamHandler) open(
+	ctx context.Context,
+	recipient, lastEventID string,
+) (*notiapp.Subscription, []*domainmodel.Notification, error) {
+	sub := h.hub.Subscribe(recipient)
+	if lastEventID == "" {
+		return sub, nil, nil
+	}
+
+	missed, err := h.service.Replay(ctx, recipient, lastEventID)
+	if err != nil {
+		h.hub.Unsubscribe(sub)
+		return nil, nil, err
+	}
+	return sub, missed, nil
+}
+
+// pump sends the missed notifications and then the live ones until the client goes away or
+// the subscription is dropped. heartbeat may be nil.
+func (h *StreamHandler) pump(
+	ctx context.Context,
+	sub *notiapp.Subscription,
+	missed []*domainmodel.Notification,
+	send func(*domainmodel.Notification) error,
+	heartbeat func() error,
+) {
+	// A notification stored during the replay may arrive both ways
+	replayed := make(map[string]struct{}, len(missed))
+	for _, notification := range missed {
+		if err := send(notification); err != nil {
+			return
+		}
+		replayed[notification.ID] = struct{}{}
+	}
+
+	ticker := time.NewTicker(streamHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification, ok := <-sub.Notifications():
+			if !ok {
+				return
+			}
+			if _, dup := replayed[notification.ID]; dup {
+				continue
+			}
+			if err := send(notification); err != nil {
+				log.Printf("Failed to push notification %s: %v", notification.ID, err)
+				return
+			}
+		case <-ticker.C:
+			if heartbeat != nil && heartbeat() != nil {
+				return
+			}
+		}
+	}
+}
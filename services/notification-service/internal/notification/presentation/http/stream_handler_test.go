@@ -0,0 +1,214 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/kiin21/go-rest/pkg/auth"
+	"github.com/kiin21/go-rest/pkg/events"
+	notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+	domainmodel "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/model"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/repository/mocks"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/presentation/http/dto"
+	"golang.org/x/net/websocket"
+)
+
+const streamTestSecret = "stream-test-secret"
+
+// newStreamServer serves the stream routes the way the router does. The recipient's store
+// holds n-1, n-2 and n-3; n-3 keeps its event variables, so it is rendered per locale.
+func newStreamServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	verifier, err := auth.NewVerifier(auth.Config{HMACSecret: streamTestSecret})
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	repo := &mocks.MockNotificationRepository{
+		ListAfterFunc: func(ctx context.Context, toStarter, afterID string, limit int) ([]*domainmodel.Notification, error) {
+			stored := []*domainmodel.Notification{
+				{ID: "n-1", ToStarter: "thanhnt", Message: "seen"},
+				{ID: "n-2", ToStarter: "thanhnt", Message: "missed"},
+				{
+					ID:        "n-3",
+					ToStarter: "thanhnt",
+					Type:      events.EventTypeNotificationLeaderAssignment,
+					Message:   "You have been assigned as leader of Engineering",
+					Data:      map[string]string{"department_name": "Engineering", "previous_leader": ""},
+				},
+			}
+			for i, notification := range stored {
+				if toStarter == "thanhnt" && notification.ID == afterID {
+					return stored[i+1 : min(i+1+limit, len(stored))], nil
+				}
+			}
+			return nil, nil
+		},
+	}
+	handler := NewStreamHandler(
+		notiapp.NewNotiApplicationService(repo),
+		notiapp.NewTemplateService(&mocks.MockTemplateRepository{}, domainmodel.LocaleEnglish),
+		notiapp.NewNotificationHub(),
+	)
+
+	r := gin.New()
+	stream := r.Group("/api/v1", auth.TokenFromQuery("access_token"), auth.Authenticate(verifier))
+	RegisterNotificationStreamRoutes(stream, handler)
+
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// signToken returns an HS256 token for subject signed with secret.
+func signToken(t *testing.T, secret, subject string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   subject,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	})
+	signed, err := token.SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func TestStreamQueryTokenAuth(t *testing.T) {
+	server := newStreamServer(t)
+
+	tests := []struct {
+		name       string
+		path       string
+		token      string
+		header     string
+		wantStatus int
+	}{
+		{"SSE without a token", "/api/v1/notifications/stream", "", "", http.StatusUnauthorized},
+		{"SSE with a forged token", "/api/v1/notifications/stream", signToken(t, "other-secret", "thanhnt"), "", http.StatusUnauthorized},
+		{"SSE with a malformed token", "/api/v1/notifications/stream", "not-a-token", "", http.StatusUnauthorized},
+		{"SSE with a query token", "/api/v1/notifications/stream", signToken(t, streamTestSecret, "thanhnt"), "", http.StatusOK},
+		{"header wins over the query token", "/api/v1/notifications/stream", signToken(t, streamTestSecret, "thanhnt"), "Bearer not-a-token", http.StatusUnauthorized},
+		{"WebSocket without a token", "/api/v1/notifications/ws", "", "", http.StatusUnauthorized},
+		{"WebSocket with a forged token", "/api/v1/notifications/ws", signToken(t, "other-secret", "thanhnt"), "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			query := url.Values{}
+			if tt.token != "" {
+				query.Set("access_token", tt.token)
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+tt.path+"?"+query.Encode(), nil)
+			if err != nil {
+				t.Fatalf("failed to build request: %v", err)
+			}
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d; want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestStreamEventsReplay(t *testing.T) {
+	server := newStreamServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	query := url.Values{"access_token": {signToken(t, streamTestSecret, "thanhnt")}, "locale": {"vi"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/notifications/stream?"+query.Encode(), nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Last-Event-ID", "n-1")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Content-Type = %q; want text/event-stream", got)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for _, want := range []struct{ id, message string }{
+		{"n-2", "missed"},
+		{"n-3", "Bạn đã được bổ nhiệm làm trưởng bộ phận Engineering"},
+	} {
+		var id, data string
+		for data == "" && scanner.Scan() {
+			line := scanner.Text()
+			if value, ok := strings.CutPrefix(line, "id: "); ok {
+				id = value
+			}
+			if value, ok := strings.CutPrefix(line, "data: "); ok {
+				data = value
+			}
+		}
+		if id != want.id {
+			t.Fatalf("replayed event id = %q; want %s", id, want.id)
+		}
+
+		var notification dto.ListNotiResponse
+		if err := json.Unmarshal([]byte(data), &notification); err != nil {
+			t.Fatalf("failed to decode event data %q: %v", data, err)
+		}
+		if notification.Message != want.message {
+			t.Errorf("message of %s = %q; want %q", id, notification.Message, want.message)
+		}
+	}
+}
+
+func TestStreamWebSocketReplay(t *testing.T) {
+	server := newStreamServer(t)
+
+	query := url.Values{
+		"access_token":  {signToken(t, streamTestSecret, "thanhnt")},
+		"last_event_id": {"n-2"},
+		"locale":        {"vi"},
+	}
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/notifications/ws?" + query.Encode()
+
+	conn, err := websocket.Dial(wsURL, "", server.URL)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+		t.Fatalf("failed to set deadline: %v", err)
+	}
+
+	var notification dto.ListNotiResponse
+	if err := websocket.JSON.Receive(conn, &notification); err != nil {
+		t.Fatalf("failed to receive: %v", err)
+	}
+	if want := "Bạn đã được bổ nhiệm làm trưởng bộ phận Engineering"; notification.ID != "n-3" || notification.Message != want {
+		t.Errorf("got %s %q; want n-3 %q", notification.ID, notification.Message, want)
+	}
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
+	"github.com/kiin21/go-rest/pkg/httputil"
+	notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/presentation/http/dto"
+)
+
+type TemplateHandler struct {
+	service *notiapp.TemplateService
+}
+
+func NewTemplateHandler(service *notiapp.TemplateService) *TemplateHandler {
+	return &TemplateHandler{service: service}
+}
+
+func (h *TemplateHandler) List(ctx *gin.Context) {
+	httputil.Wrap(h.list)(ctx)
+}
+
+func (h *TemplateHandler) list(ctx *gin.Context) (res interface{}, err error) {
+	templates, err := h.service.List(ctx.Request.Context())
+	if err != nil {
+		return nil, httputil.NewAPIError(http.StatusInternalServerError, "Failed to list notification templates", err.Error())
+	}
+
+	return dto.FromDomainTemplates(templates), nil
+}
+
+func (h *TemplateHandler) Get(ctx *gin.Context) {
+	httputil.Wrap(h.get)(ctx)
+}
+
+func (h *TemplateHandler) get(ctx *gin.Context) (res interface{}, err error) {
+	t, err := h.service.Get(ctx.Request.Context(), ctx.Param("event_type"), ctx.Param("locale"))
+	if err != nil {
+		return nil, templateError(err, "Failed to get notification template")
+	}
+
+	return dto.FromDomainTemplate(t), nil
+}
+
+func (h *TemplateHandler) Save(ctx *gin.Context) {
+	httputil.Wrap(h.save)(ctx)
+}
+
+func (h *TemplateHandler) save(ctx *gin.Context) (res interface{}, err error) {
+	var req dto.SaveTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, httputil.NewAPIError(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	t, err := h.service.Save(ctx.Request.Context(), ctx.Param("event_type"), ctx.Param("locale"), req.Body,
+		auth.PrincipalFrom(ctx).Subject)
+	if err != nil {
+		return nil, templateError(err, "Failed to save notification template")
+	}
+
+	return dto.FromDomainTemplate(t), nil
+}
+
+func (h *TemplateHandler) Reset(ctx *gin.Context) {
+	httputil.Wrap(h.reset)(ctx)
+}
+
+func (h *TemplateHandler) reset(ctx *gin.Context) (res interface{}, err error) {
+	if err := h.service.Reset(ctx.Request.Context(), ctx.Param("event_type"), ctx.Param("locale")); err != nil {
+		return nil, templateError(err, "Failed to reset notification template")
+	}
+
+	return nil, nil
+}
+
+func (h *TemplateHandler) Preview(ctx *gin.Context) {
+	httputil.Wrap(h.preview)(ctx)
+}
+
+func (h *TemplateHandler) preview(ctx *gin.Context) (res interface{}, err error) {
+	var req dto.PreviewTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, httputil.NewAPIError(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	message, err := h.service.Preview(ctx.Request.Context(), req.EventType, req.Locale, req.Body, req.Data)
+	if err != nil {
+		return nil, templateError(err, "Failed to preview notification template")
+	}
+
+	return &dto.PreviewTemplateResponse{Message: message}, nil
+}
+
+func templateError(err error, message string) error {
+	switch {
+	case errors.Is(err, domainErr.ErrInvalidTemplate):
+		return httputil.NewAPIError(http.StatusBadRequest, "Invalid notification template", err.Error())
+	case errors.Is(err, domainErr.ErrNotFound):
+		return httputil.NewAPIError(http.StatusNotFound, "Notification template not found", err.Error())
+	default:
+		return httputil.NewAPIError(http.StatusInternalServerError, message, err.Error())
+	}
+}
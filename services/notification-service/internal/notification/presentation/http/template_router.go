@@ -0,0 +1,17 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
+)
+
+// RegisterTemplateRoutes registers the notification template routes, which only admins may
+// use.
+func RegisterTemplateRoutes(rg *gin.RouterGroup, handler *TemplateHandler) {
+	route := rg.Group("/notification-templates", auth.RequireRoles(auth.RoleHRAdmin, auth.RoleOrgAdmin))
+	route.GET("", handler.List)
+	route.POST("/preview", handler.Preview)
+	route.GET("/:event_type/:locale", handler.Get)
+	route.PUT("/:event_type/:locale", handler.Save)
+	route.DELETE("/:event_type/:locale", handler.Reset)
+}
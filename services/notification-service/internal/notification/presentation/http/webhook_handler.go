@@ -0,0 +1,127 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
+	"github.com/kiin21/go-rest/pkg/httputil"
+	notiapp "github.com/kiin21/go-rest/services/notification-service/internal/notification/application"
+	domainErr "github.com/kiin21/go-rest/services/notification-service/internal/notification/domain/error"
+	"github.com/kiin21/go-rest/services/notification-service/internal/notification/presentation/http/dto"
+)
+
+type WebhookHandler struct {
+	service *notiapp.WebhookService
+}
+
+func NewWebhookHandler(service *notiapp.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+func (h *WebhookHandler) Create(ctx *gin.Context) {
+	httputil.Wrap(h.create)(ctx)
+}
+
+func (h *WebhookHandler) create(ctx *gin.Context) (res interface{}, err error) {
+	var req dto.CreateWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, httputil.NewAPIError(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	subscription, err := h.service.Subscribe(ctx.Request.Context(), notiapp.CreateWebhookCommand{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+		CreatedBy:  auth.PrincipalFrom(ctx).Subject,
+	})
+	if err != nil {
+		if errors.Is(err, domainErr.ErrInvalidWebhook) {
+			return nil, httputil.NewAPIError(http.StatusBadRequest, "Invalid webhook subscription", err.Error())
+		}
+		return nil, httputil.NewAPIError(http.StatusInternalServerError, "Failed to create webhook subscription", err.Error())
+	}
+
+	return dto.FromDomainWebhook(subscription), nil
+}
+
+func (h *WebhookHandler) List(ctx *gin.Context) {
+	httputil.Wrap(h.list)(ctx)
+}
+
+func (h *WebhookHandler) list(ctx *gin.Context) (res interface{}, err error) {
+	subscriptions, err := h.service.ListSubscriptions(ctx.Request.Context())
+	if err != nil {
+		return nil, httputil.NewAPIError(http.StatusInternalServerError, "Failed to list webhook subscriptions", err.Error())
+	}
+
+	return dto.FromDomainWebhooks(subscriptions), nil
+}
+
+func (h *WebhookHandler) Get(ctx *gin.Context) {
+	httputil.Wrap(h.get)(ctx)
+}
+
+func (h *WebhookHandler) get(ctx *gin.Context) (res interface{}, err error) {
+	subscription, err := h.service.GetSubscription(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		return nil, webhookNotFoundOr(err, "Failed to get webhook subscription")
+	}
+
+	return dto.FromDomainWebhook(subscription), nil
+}
+
+func (h *WebhookHandler) Delete(ctx *gin.Context) {
+	httputil.Wrap(h.delete)(ctx)
+}
+
+func (h *WebhookHandler) delete(ctx *gin.Context) (res interface{}, err error) {
+	if err := h.service.Unsubscribe(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		return nil, webhookNotFoundOr(err, "Failed to delete webhook subscription")
+	}
+
+	return nil, nil
+}
+
+func (h *WebhookHandler) ListDeadLetters(ctx *gin.Context) {
+	httputil.Wrap(h.listDeadLetters)(ctx)
+}
+
+func (h *WebhookHandler) listDeadLetters(ctx *gin.Context) (res interface{}, err error) {
+	var req dto.ListDeadLettersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		return nil, httputil.NewAPIError(http.StatusBadRequest, "Invalid query parameters", err.Error())
+	}
+	req.SetDefaults()
+
+	letters, err := h.service.ListDeadLetters(ctx.Request.Context(), req.SubscriptionID, req.Limit)
+	if err != nil {
+		return nil, httputil.NewAPIError(http.StatusInternalServerError, "Failed to list webhook dead letters", err.Error())
+	}
+
+	return dto.FromDomainDeadLetters(letters), nil
+}
+
+func (h *WebhookHandler) ReplayDeadLetter(ctx *gin.Context) {
+	httputil.Wrap(h.replayDeadLetter)(ctx)
+}
+
+func (h *WebhookHandler) replayDeadLetter(ctx *gin.Context) (res interface{}, err error) {
+	delivery, err := h.service.ReplayDeadLetter(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		if errors.Is(err, domainErr.ErrNotFound) {
+			return nil, httputil.NewAPIError(http.StatusNotFound, "Dead letter not found", err.Error())
+		}
+		return nil, httputil.NewAPIError(http.StatusInternalServerError, "Failed to replay dead letter", err.Error())
+	}
+
+	return dto.FromDomainReplay(delivery), nil
+}
+
+func webhookNotFoundOr(err error, message string) error {
+	if errors.Is(err, domainErr.ErrNotFound) {
+		return httputil.NewAPIError(http.StatusNotFound, "Webhook subscription not found", err.Error())
+	}
+	return httputil.NewAPIError(http.StatusInternalServerError, message, err.Error())
+}
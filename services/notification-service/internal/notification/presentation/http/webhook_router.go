@@ -0,0 +1,17 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
+)
+
+// RegisterWebhookRoutes registers the webhook subscription routes, which only admins may use.
+func RegisterWebhookRoutes(rg *gin.RouterGroup, handler *WebhookHandler) {
+	route := rg.Group("/webhooks", auth.RequireRoles(auth.RoleHRAdmin, auth.RoleOrgAdmin))
+	route.POST("", handler.Create)
+	route.GET("", handler.List)
+	route.GET("/dead-letters", handler.ListDeadLetters)
+	route.POST("/dead-letters/:id/replay", handler.ReplayDeadLetter)
+	route.GET("/:id", handler.Get)
+	route.DELETE("/:id", handler.Delete)
+}
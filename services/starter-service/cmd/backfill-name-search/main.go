@@ -0,0 +1,33 @@
+// Command backfill-name-search fills starters.name_search for the rows that existed before
+// migration 006. The migration can only fold đ/Đ and lowercase in SQL; this runs the rows
+// through the same folding as the application. Run it once after applying the migration:
+//
+//	go run ./cmd/backfill-name-search
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/kiin21/go-rest/services/starter-service/internal/config"
+	initDB "github.com/kiin21/go-rest/services/starter-service/internal/initialize/db"
+	persistentMySQL "github.com/kiin21/go-rest/services/starter-service/internal/starter/infrastructure/persistence/repository/mysql"
+)
+
+func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Could not load config: %v", err)
+	}
+
+	db, err := initDB.InitMySQL(cfg.DBURI)
+	if err != nil {
+		log.Fatalf("Could not initialize database: %v", err)
+	}
+
+	updated, err := persistentMySQL.BackfillNameSearch(context.Background(), db)
+	if err != nil {
+		log.Fatalf("Backfill stopped after %d starters: %v", updated, err)
+	}
+	log.Printf("Backfilled name_search of %d starters", updated)
+}
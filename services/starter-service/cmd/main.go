@@ -18,8 +18,12 @@ import (
 // @version 1.0
 // @description REST APIs for managing starters and organizations.
 // @BasePath /api/v1
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT bearer token, sent as "Bearer <token>"
 func main() {
-	router, port, notificationProducer, syncProducer, syncConsumer := initialize.Run()
+	router, port, notificationProducer, syncProducer, syncConsumer, searchReconciler, reindexJobs := initialize.Run()
 
 	docs.SwaggerInfo.Schemes = []string{"http", "https"}
 
@@ -56,6 +60,20 @@ func main() {
 	// Cleanup Kafka resources
 	log.Println("Cleaning up resources...")
 
+	// Stop search reconciler
+	if searchReconciler != nil {
+		log.Println("Stopping search reconciler...")
+		searchReconciler.Stop()
+		log.Println("Search reconciler stopped")
+	}
+
+	// Cancel running reindex job
+	if reindexJobs != nil {
+		log.Println("Stopping reindex jobs...")
+		reindexJobs.Shutdown()
+		log.Println("Reindex jobs stopped")
+	}
+
 	// Stop sync consumer
 	if syncConsumer != nil {
 		log.Println("Stopping Kafka sync consumer...")
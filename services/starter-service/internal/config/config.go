@@ -3,7 +3,12 @@ package config
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/kiin21/go-rest/pkg/auth"
+	"github.com/kiin21/go-rest/pkg/httpmw"
+	"github.com/kiin21/go-rest/pkg/utils"
 	"github.com/spf13/viper"
 )
 
@@ -20,12 +25,75 @@ type Config struct {
 	ElasticsearchUsername  string `mapstructure:"ELASTICSEARCH_USERNAME"`
 	ElasticsearchPassword  string `mapstructure:"ELASTICSEARCH_PASSWORD"`
 
+	// Search index reconciliation, 0 disables the schedule
+	SearchReconcileInterval time.Duration `mapstructure:"SEARCH_RECONCILE_INTERVAL"`
+
 	// Kafka
 	KafkaBrokers            string `mapstructure:"KAFKA_BROKERS"`
 	KafkaTopicSyncEvents    string `mapstructure:"KAFKA_TOPIC_SYNC_EVENTS"`
 	KafkaTopicNotifications string `mapstructure:"KAFKA_TOPIC_NOTIFICATIONS"`
 	KafkaConsumerGroup      string `mapstructure:"KAFKA_CONSUMER_GROUP"`
 
+	// Authentication: bearer tokens are verified with either the HMAC secret (HS256) or the
+	// RSA keys of a JWKS file (RS256); issuer and audience are checked when set
+	AuthJWTSecret   string `mapstructure:"AUTH_JWT_SECRET"`
+	AuthJWKSFile    string `mapstructure:"AUTH_JWKS_FILE"`
+	AuthJWTIssuer   string `mapstructure:"AUTH_JWT_ISSUER"`
+	AuthJWTAudience string `mapstructure:"AUTH_JWT_AUDIENCE"`
+
+	// CORS: comma separated origins allowed to call the API from a browser ("*" for any)
+	CORSAllowedOrigins   string        `mapstructure:"CORS_ALLOWED_ORIGINS"`
+	CORSAllowCredentials bool          `mapstructure:"CORS_ALLOW_CREDENTIALS"`
+	CORSMaxAge           time.Duration `mapstructure:"CORS_MAX_AGE"`
+
+	// Rate limiting: "<requests>/<duration>" per caller across all routes, and comma separated
+	// "<METHOD> <route>=<requests>/<duration>" entries for routes with their own limit
+	RateLimitDefault string `mapstructure:"RATE_LIMIT_DEFAULT"`
+	RateLimitRoutes  string `mapstructure:"RATE_LIMIT_ROUTES"`
+}
+
+// AuthConfig returns the bearer token verification settings.
+func (c Config) AuthConfig() auth.Config {
+	return auth.Config{
+		HMACSecret: c.AuthJWTSecret,
+		JWKSFile:   c.AuthJWKSFile,
+		Issuer:     c.AuthJWTIssuer,
+		Audience:   c.AuthJWTAudience,
+	}
+}
+
+// CORSConfig returns the cross-origin policy; no origin is allowed unless configured.
+func (c Config) CORSConfig() httpmw.CORSConfig {
+	cors := httpmw.DefaultCORSConfig()
+	cors.AllowedOrigins = utils.ParseString(c.CORSAllowedOrigins, ",")
+	cors.AllowCredentials = c.CORSAllowCredentials
+	if c.CORSMaxAge > 0 {
+		cors.MaxAge = c.CORSMaxAge
+	}
+	return cors
+}
+
+// RateLimitConfig returns the per-caller request limits.
+func (c Config) RateLimitConfig() (httpmw.RateLimitConfig, error) {
+	defaultLimit, err := httpmw.ParseRateLimit(c.RateLimitDefault)
+	if err != nil {
+		return httpmw.RateLimitConfig{}, fmt.Errorf("RATE_LIMIT_DEFAULT: %w", err)
+	}
+
+	routes := make(map[string]httpmw.RateLimit)
+	for _, entry := range utils.ParseString(c.RateLimitRoutes, ",") {
+		route, value, found := strings.Cut(entry, "=")
+		if !found {
+			return httpmw.RateLimitConfig{}, fmt.Errorf("RATE_LIMIT_ROUTES: %q: expected <METHOD> <route>=<limit>", entry)
+		}
+		limit, err := httpmw.ParseRateLimit(value)
+		if err != nil {
+			return httpmw.RateLimitConfig{}, fmt.Errorf("RATE_LIMIT_ROUTES: %w", err)
+		}
+		routes[strings.Join(strings.Fields(route), " ")] = limit
+	}
+
+	return httpmw.RateLimitConfig{Default: defaultLimit, Routes: routes}, nil
 }
 
 func LoadConfig() (config Config, err error) {
@@ -2,11 +2,13 @@ package messagebroker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
 	"github.com/IBM/sarama"
 	"github.com/kiin21/go-rest/pkg/events"
+	sharedDomain "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/error"
 	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
 	domainRepo "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository"
 	domainService "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/service"
@@ -47,31 +49,43 @@ func (h *EventHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim s
 
 		log.Printf("Processing event: Type=%s, ID=%s", event.Type, event.ID)
 
+		// A failed event is logged and skipped; the search reconciler repairs what it missed
+		if err := h.handleEvent(ctx, event); err != nil {
+			log.Printf("Failed to handle event %s (%s): %v", event.ID, event.Type, err)
+		}
+
+		session.MarkMessage(msg, "")
+	}
+	return nil
+}
+
+func (h *EventHandler) handleEvent(ctx context.Context, event *events.Event) error {
+	if h.starterSearchRepo == nil {
+		return nil
+	}
+
 	var payload events.IndexStarterPayload
 	if err := event.UnmarshalPayload(&payload); err != nil {
 		return fmt.Errorf("failed to unmarshal IndexStarterPayload: %w", err)
 	}
 
 	switch event.Type {
-		case events.EventTypeStarterInsert, events.EventTypeStarterUpdate:
-			{
-				esDocs, err := h.fetchAndEnrichStarter(ctx, payload.Domain)
-				if err != nil {
-					return fmt.Errorf("failed to fetch and enrich starter: %w", err)
-				}
-				return h.starterSearchRepo.IndexStarter(ctx, esDocs)
-			}
-		case events.EventTypeStarterDelete:
-			{
-				return h.starterSearchRepo.DeleteFromIndex(ctx, payload.Domain)
-			}
-
-		default:
-			log.Printf("Unknown event type: %s", event.Type)
+	case events.EventTypeStarterInsert, events.EventTypeStarterUpdate, events.EventTypeStarterIndex:
+		esDoc, err := h.fetchAndEnrichStarter(ctx, payload.Domain)
+		if errors.Is(err, sharedDomain.ErrNotFound) {
+			// Deleted since the event was produced
+			return h.starterSearchRepo.DeleteFromIndex(ctx, payload.Domain)
 		}
-
-		session.MarkMessage(msg, "")
+		if err != nil {
+			return fmt.Errorf("failed to fetch and enrich starter: %w", err)
+		}
+		return h.starterSearchRepo.IndexStarter(ctx, esDoc)
+	case events.EventTypeStarterDelete:
+		return h.starterSearchRepo.DeleteFromIndex(ctx, payload.Domain)
+	default:
+		log.Printf("Unknown event type: %s", event.Type)
 	}
+
 	return nil
 }
 
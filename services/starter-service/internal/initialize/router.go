@@ -4,9 +4,10 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
+	"github.com/kiin21/go-rest/pkg/httpmw"
 	"github.com/kiin21/go-rest/pkg/httputil"
 	"github.com/kiin21/go-rest/services/starter-service/docs"
-	"github.com/kiin21/go-rest/services/starter-service/internal/middleware"
 	orgHttp "github.com/kiin21/go-rest/services/starter-service/internal/starter/presentation/http"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -17,6 +18,12 @@ func InitRouter(
 	requestURLResolver *httputil.RequestURLResolver,
 	orgHandler *orgHttp.OrganizationHandler,
 	starterHandler *orgHttp.StarterHandler,
+	searchAdminHandler *orgHttp.SearchAdminHandler,
+	idempotencyStore httputil.IdempotencyStore,
+	verifier *auth.Verifier,
+	cors httpmw.CORSConfig,
+	rateLimitStore httpmw.RateLimitStore,
+	rateLimits httpmw.RateLimitConfig,
 ) *gin.Engine {
 	var router *gin.Engine
 	if logLevel == "debug" {
@@ -30,7 +37,9 @@ func InitRouter(
 
 	swaggerHandler := ginSwagger.WrapHandler(swaggerFiles.Handler)
 
-	router.Use(middleware.CORS)
+	cors.AllowedHeaders = append(cors.AllowedHeaders, httputil.IdempotencyKeyHeader)
+	cors.ExposedHeaders = append(cors.ExposedHeaders, httputil.IdempotencyReplayedHeader, httpmw.RetryAfterHeader)
+	router.Use(httpmw.SecurityHeaders(httpmw.DefaultSecurityHeadersConfig()), httpmw.CORS(cors))
 
 	router.GET("/health", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, gin.H{"status": "healthy"})
@@ -44,10 +53,13 @@ func InitRouter(
 		swaggerHandler(ctx)
 	})
 
-	v1 := router.Group("/api/v1")
+	v1 := router.Group("/api/v1", auth.Authenticate(verifier), httpmw.RateLimiter(rateLimitStore, rateLimits))
 
-	orgHttp.RegisterOrganizationRoutes(v1, orgHandler)
-	orgHttp.RegisterStarterRoutes(v1, starterHandler)
+	idempotent := httputil.Idempotency(idempotencyStore, httputil.DefaultIdempotencyTTL, auth.SubjectFrom)
+
+	orgHttp.RegisterOrganizationRoutes(v1, orgHandler, idempotent)
+	orgHttp.RegisterStarterRoutes(v1, starterHandler, idempotent)
+	orgHttp.RegisterSearchAdminRoutes(v1, searchAdminHandler)
 
 	return router
 }
@@ -5,6 +5,8 @@ import (
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
+	"github.com/kiin21/go-rest/pkg/httpmw"
 	"github.com/kiin21/go-rest/pkg/httputil"
 	"github.com/kiin21/go-rest/pkg/utils"
 	"github.com/kiin21/go-rest/services/starter-service/internal/config"
@@ -12,17 +14,29 @@ import (
 	initES "github.com/kiin21/go-rest/services/starter-service/internal/initialize/elasticsearch"
 	initBroker "github.com/kiin21/go-rest/services/starter-service/internal/initialize/messagebroker"
 	initStarter "github.com/kiin21/go-rest/services/starter-service/internal/initialize/starter"
+	appSvc "github.com/kiin21/go-rest/services/starter-service/internal/starter/application/service"
 	domainMq "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/messaging"
+	domainSvc "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/service"
 	persistentMySQL "github.com/kiin21/go-rest/services/starter-service/internal/starter/infrastructure/persistence/repository/mysql"
 )
 
-func Run() (*gin.Engine, string, domainMq.NotificationProducer, domainMq.SyncProducer, domainMq.StarterConsumer) {
+func Run() (*gin.Engine, string, domainMq.NotificationProducer, domainMq.SyncProducer, domainMq.StarterConsumer, *domainSvc.SearchReconciler, *appSvc.ReindexJobService) {
 	// 1> Read config -> environment variables
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("Could not load config: %v", err)
 	}
 
+	verifier, err := auth.NewVerifier(cfg.AuthConfig())
+	if err != nil {
+		log.Fatalf("Could not initialize authentication: %v", err)
+	}
+
+	rateLimits, err := cfg.RateLimitConfig()
+	if err != nil {
+		log.Fatalf("Could not load rate limits: %v", err)
+	}
+
 	// 2> Initialize database connection
 	db, err := initDB.InitMySQL(cfg.DBURI)
 	if err != nil {
@@ -47,7 +61,7 @@ func Run() (*gin.Engine, string, domainMq.NotificationProducer, domainMq.SyncPro
 	}
 
 	// 4> Initialize Kafka producers (2 separate topics)
-	notificationProducer := initBroker.InitNotificationProducer(cfg) // For leader assignment notifications
+	notificationProducer := initBroker.InitNotificationProducer(cfg) // For leader assignment and starter lifecycle notifications
 	syncProducer := initBroker.InitSyncProducer(cfg)                 // For Elasticsearch sync events
 
 	// 5> Prepare shared dependencies
@@ -55,20 +69,34 @@ func Run() (*gin.Engine, string, domainMq.NotificationProducer, domainMq.SyncPro
 	starterRepo := persistentMySQL.NewStarterRepository(db)
 	businessUnitRepo := persistentMySQL.NewBusinessUnitRepository(db)
 	departmentRepo := persistentMySQL.NewDepartmentRepository(db)
+	deadLetterRepo := persistentMySQL.NewSearchDeadLetterRepository(db)
+	piiAccessLogRepo := persistentMySQL.NewPIIAccessLogRepository(db)
 
 	orgHandler := initStarter.InitOrganization(
 		starterRepo,
 		departmentRepo,
 		businessUnitRepo,
+		piiAccessLogRepo,
 		notificationProducer,
 	)
 
-	starterHandler, searchRepo, starterEnrichService := initStarter.InitStarter(
+	starterHandler, searchRepo, starterEnrichService, reindexJobs := initStarter.InitStarter(
 		starterRepo,
 		departmentRepo,
 		businessUnitRepo,
+		deadLetterRepo,
+		piiAccessLogRepo,
 		esClient,
 		syncProducer,
+		notificationProducer,
+	)
+
+	searchAdminHandler, searchReconciler := initStarter.InitSearchAdmin(
+		starterRepo,
+		searchRepo,
+		syncProducer,
+		reindexJobs,
+		cfg.SearchReconcileInterval,
 	)
 
 	eventHandler := initBroker.InitEventHandler(searchRepo, starterRepo, starterEnrichService)
@@ -81,7 +109,13 @@ func Run() (*gin.Engine, string, domainMq.NotificationProducer, domainMq.SyncPro
 		requestURLResolver,
 		orgHandler,
 		starterHandler,
+		searchAdminHandler,
+		persistentMySQL.NewIdempotencyStore(db),
+		verifier,
+		cfg.CORSConfig(),
+		httpmw.NewMemoryRateLimitStore(),
+		rateLimits,
 	)
 
-	return r, cfg.ServerPort, notificationProducer, syncProducer, consumer
+	return r, cfg.ServerPort, notificationProducer, syncProducer, consumer, searchReconciler, reindexJobs
 }
@@ -4,6 +4,7 @@ import (
 	orgAppSvc "github.com/kiin21/go-rest/services/starter-service/internal/starter/application/service"
 	domainmessaging "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/messaging"
 	orgRepo "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository"
+	orgDomainSvc "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/service"
 	orgHttp "github.com/kiin21/go-rest/services/starter-service/internal/starter/presentation/http"
 )
 
@@ -11,9 +12,14 @@ func InitOrganization(
 	starterRepo orgRepo.StarterRepository,
 	deptRepo orgRepo.DepartmentRepository,
 	buRepo orgRepo.BusinessUnitRepository,
+	piiAccessLogRepo orgRepo.PIIAccessLogRepository,
 	notifPublisher domainmessaging.NotificationProducer,
 ) *orgHttp.OrganizationHandler {
 	organizationService := orgAppSvc.NewOrganizationApplicationService(deptRepo, buRepo, starterRepo, notifPublisher)
 
-	return orgHttp.NewOrganizationHandler(organizationService)
+	accessService := orgDomainSvc.NewDepartmentAccessService(starterRepo, deptRepo)
+
+	piiAccess := orgAppSvc.NewPIIAccessService(starterRepo, piiAccessLogRepo)
+
+	return orgHttp.NewOrganizationHandler(organizationService, accessService, piiAccess)
 }
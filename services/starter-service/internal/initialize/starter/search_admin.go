@@ -0,0 +1,38 @@
+package initialize
+
+import (
+	"log"
+	"time"
+
+	starterApp "github.com/kiin21/go-rest/services/starter-service/internal/starter/application/service"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/messaging"
+	starterDomainRepo "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository"
+	starterDomainSvc "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/service"
+	starterHttp "github.com/kiin21/go-rest/services/starter-service/internal/starter/presentation/http"
+)
+
+// InitSearchAdmin wires the search index reconciler and starts it when an interval is configured.
+// The reconciler is nil when Elasticsearch is disabled.
+func InitSearchAdmin(
+	starterRepo starterDomainRepo.StarterRepository,
+	starterSearchRepo starterDomainRepo.StarterSearchRepository,
+	syncProducer messaging.SyncProducer,
+	reindexJobs *starterApp.ReindexJobService,
+	reconcileInterval time.Duration,
+) (*starterHttp.SearchAdminHandler, *starterDomainSvc.SearchReconciler) {
+	if starterSearchRepo == nil {
+		log.Println("Elasticsearch is disabled, skipping search reconciler")
+		return starterHttp.NewSearchAdminHandler(nil, nil), nil
+	}
+
+	reconciler := starterDomainSvc.NewSearchReconciler(starterRepo, starterSearchRepo, syncProducer)
+
+	if reconcileInterval > 0 {
+		log.Printf("Starting search reconciler every %s", reconcileInterval)
+		reconciler.Start(reconcileInterval)
+	} else {
+		log.Println("SEARCH_RECONCILE_INTERVAL not set, search reconciler runs on demand only")
+	}
+
+	return starterHttp.NewSearchAdminHandler(reconciler, reindexJobs), reconciler
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/elastic/go-elasticsearch/v8"
 	starterApp "github.com/kiin21/go-rest/services/starter-service/internal/starter/application/service"
 	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/messaging"
+	starterDomainModel "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
 	starterDomainRepo "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository"
 	starterDomainSvc "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/service"
 	starterInfraSearch "github.com/kiin21/go-rest/services/starter-service/internal/starter/infrastructure/search/repository"
@@ -17,12 +18,16 @@ func InitStarter(
 	starterRepo starterDomainRepo.StarterRepository,
 	departmentRepo starterDomainRepo.DepartmentRepository,
 	businessUnitRepo starterDomainRepo.BusinessUnitRepository,
+	deadLetterRepo starterDomainRepo.SearchDeadLetterRepository,
+	piiAccessLogRepo starterDomainRepo.PIIAccessLogRepository,
 	esClient *elasticsearch.Client,
 	syncProducer messaging.SyncProducer,
-) (*starterHttp.StarterHandler, starterDomainRepo.StarterSearchRepository, *starterDomainSvc.StarterEnrichmentService) {
+	notificationProducer messaging.NotificationProducer,
+) (*starterHttp.StarterHandler, starterDomainRepo.StarterSearchRepository, *starterDomainSvc.StarterEnrichmentService, *starterApp.ReindexJobService) {
 	var (
 		starterSearchRepo    starterDomainRepo.StarterSearchRepository
 		starterSearchService *starterDomainSvc.StarterSearchService
+		needsReindex         bool
 	)
 
 	// Initialize Elasticsearch repository
@@ -34,10 +39,14 @@ func InitStarter(
 			log.Printf("Warning: failed to create index manager: %v", err)
 			log.Printf("Elasticsearch search will be disabled")
 		} else {
-			if err := indexManager.CreateIndex(context.Background()); err != nil {
+			if recreated, err := indexManager.EnsureIndex(context.Background()); err != nil {
 				log.Printf("Warning: failed to create Elasticsearch index: %v", err)
 				log.Printf("Elasticsearch search will be disabled")
 			} else {
+				if recreated {
+					log.Printf("Elasticsearch index recreated with the new mapping")
+					needsReindex = true
+				}
 				log.Printf("Elasticsearch index ready")
 
 				starterSearchRepo = starterInfraSearch.NewElasticsearchStarterRepository(esClient)
@@ -52,9 +61,9 @@ func InitStarter(
 				if err != nil {
 					log.Printf("Warning: failed to check if index is empty: %v", err)
 				} else if isEmpty {
-					log.Println("Elasticsearch index is empty, starting auto-reindex from MySQL...")
-					// Auto-reindex will be triggered below
-				} else {
+					log.Println("Elasticsearch index is empty, scheduling reindex from MySQL")
+					needsReindex = true
+				} else if !needsReindex {
 					log.Println("Elasticsearch index already contains data, skipping auto-reindex")
 				}
 			}
@@ -79,19 +88,30 @@ func InitStarter(
 		starterDomainService,
 		starterEnrichmentService,
 		starterSearchService,
+		deadLetterRepo,
+		departmentRepo,
+		notificationProducer,
 	)
 
-	// Auto-reindex on startup if ES is enabled
+	// Reindex in the background so the HTTP server is not blocked on startup
+	var reindexJobs *starterApp.ReindexJobService
 	if starterSearchRepo != nil {
-		log.Println("Starting auto-reindex...")
-		if err := starterAppService.ReindexAll(context.Background()); err != nil {
-			log.Printf("Auto-reindex failed: %v", err)
-		} else {
-			log.Println("Auto-reindex completed successfully")
+		reindexJobs = starterApp.NewReindexJobService(starterAppService)
+		if needsReindex {
+			if job, err := reindexJobs.Start(starterDomainModel.ReindexTriggerStartup); err != nil {
+				log.Printf("Failed to start reindex job: %v", err)
+			} else {
+				log.Printf("Reindex job %s started in background", job.ID)
+			}
 		}
 	}
 
-	starterHandler := starterHttp.NewStarterHandler(starterAppService, starterEnrichmentService)
+	starterHandler := starterHttp.NewStarterHandler(
+		starterAppService,
+		starterEnrichmentService,
+		starterDomainSvc.NewDepartmentAccessService(starterRepo, departmentRepo),
+		starterApp.NewPIIAccessService(starterRepo, piiAccessLogRepo),
+	)
 
-	return starterHandler, starterSearchRepo, starterEnrichmentService
+	return starterHandler, starterSearchRepo, starterEnrichmentService, reindexJobs
 }
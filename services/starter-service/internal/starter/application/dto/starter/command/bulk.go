@@ -0,0 +1,20 @@
+package command
+
+import "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+
+// BulkTarget selects the starters of a bulk change, by domain or by filter; only one is set.
+type BulkTarget struct {
+	Domains []string
+	Filter  *model.StarterListFilter
+}
+
+// BulkUpdateStartersCommand applies the same partial update to every targeted starter.
+// Changes.OriginalDomain and Changes.Domain are ignored: domains cannot be changed in bulk.
+type BulkUpdateStartersCommand struct {
+	Target  BulkTarget
+	Changes UpdateStarterCommand
+}
+
+type BulkDeleteStartersCommand struct {
+	Target BulkTarget
+}
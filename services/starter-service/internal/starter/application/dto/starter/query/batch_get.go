@@ -0,0 +1,16 @@
+package query
+
+import "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+
+// BatchGetStartersQuery looks starters up by domain or by id; only one of the lists is set.
+type BatchGetStartersQuery struct {
+	Domains []string
+	IDs     []int64
+}
+
+// BatchGetStartersResult holds the starters found, in request order, and the keys that matched nothing.
+type BatchGetStartersResult struct {
+	Starters        []*model.Starter
+	NotFoundDomains []string
+	NotFoundIDs     []int64
+}
@@ -1,6 +1,9 @@
 package query
 
-import "github.com/kiin21/go-rest/pkg/httputil"
+import (
+	"github.com/kiin21/go-rest/pkg/httputil"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+)
 
 type ListStartersQuery struct {
 	Pagination httputil.ReqPagination
@@ -9,6 +12,8 @@ type ListStartersQuery struct {
 
 	Keyword string
 
+	Filter *model.StarterListFilter
+
 	SortBy    string
 	SortOrder string
 }
@@ -47,6 +47,14 @@ func (s *OrganizationApplicationService) GetAllDepartments(ctx context.Context,
 		return nil, err
 	}
 
+	if query.Pagination.IsCursorMode() {
+		data, pagination := httputil.NewCursorPage(departments, query.Pagination.GetLimit(), query.Pagination.Cursor,
+			func(department *model.DepartmentWithDetails) (string, string) {
+				return "", strconv.FormatInt(department.ID, 10)
+			})
+		return &httputil.PaginatedResult[*model.DepartmentWithDetails]{Data: data, Pagination: pagination}, nil
+	}
+
 	totalPages := int(total) / query.Pagination.GetLimit()
 	if int(total)%(query.Pagination.GetLimit()) > 0 {
 		totalPages++
@@ -228,11 +236,14 @@ func (s *OrganizationApplicationService) publishLeaderAssignmentNotification(dep
 		fromDomain = "system"
 	}
 
-	message := fmt.Sprintf("You have been assigned as leader of %s", department.FullName)
+	// The notification service renders the recipient's text from the structured fields
 	payload := events.LeaderAssignmentEventPayload{
-		FromStarter: fromDomain,
-		ToStarter:   toDomain,
-		Message:     message,
+		FromStarter:    fromDomain,
+		ToStarter:      toDomain,
+		Message:        fmt.Sprintf("You have been assigned as leader of %s", department.FullName),
+		DepartmentID:   department.ID,
+		DepartmentName: department.FullName,
+		PreviousLeader: previousLeaderDomain,
 	}
 
 	// Create event
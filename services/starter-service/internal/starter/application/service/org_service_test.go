@@ -74,6 +74,52 @@ func TestGetAllDepartments(t *testing.T) {
 	}
 }
 
+func TestGetAllDepartmentsCursor(t *testing.T) {
+	limit := 1
+	departments := []*model.DepartmentWithDetails{
+		{Department: &model.Department{ID: 4, FullName: "Engineering"}},
+		{Department: &model.Department{ID: 7, FullName: "Sales"}},
+	}
+
+	mockDepartmentRepo := &mocks.MockDepartmentRepository{
+		ListWithDetailsFunc: func(ctx context.Context, filter *model.DepartmentListFilter, pagination *httputil.ReqPagination) ([]*model.DepartmentWithDetails, int64, error) {
+			if !pagination.IsCursorMode() {
+				t.Error("expected cursor pagination to reach the repository")
+			}
+			return departments, 0, nil
+		},
+	}
+
+	service := NewOrganizationApplicationService(mockDepartmentRepo, nil, nil, nil)
+
+	query := &departmentquery.ListDepartmentsQuery{
+		Pagination: httputil.ReqPagination{
+			Limit:  &limit,
+			Cursor: &httputil.Cursor{SortBy: "id", SortOrder: "asc"},
+		},
+	}
+
+	result, err := service.GetAllDepartments(context.Background(), query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Data) != 1 || result.Data[0].ID != 4 {
+		t.Fatalf("expected only department 4, got %d departments", len(result.Data))
+	}
+	if result.Pagination.Next == nil {
+		t.Fatal("expected a next cursor")
+	}
+
+	next, err := httputil.ParseCursor(*result.Pagination.Next, "id", "asc")
+	if err != nil {
+		t.Fatalf("next cursor does not parse: %v", err)
+	}
+	if id, _ := next.Int64ID(); id != 4 {
+		t.Errorf("expected next cursor after department 4, got %+v", next)
+	}
+}
+
 func TestGetOneDepartment(t *testing.T) {
 	tests := []struct {
 		name                      string
@@ -527,6 +573,66 @@ func TestAssignLeader(t *testing.T) {
 	}
 }
 
+func TestAssignLeader_PublishesStructuredPayload(t *testing.T) {
+	newLeaderID := int64(20)
+	calls := 0
+	mockDepartmentRepo := &mocks.MockDepartmentRepository{
+		FindByIDsWithDetailsFunc: func(ctx context.Context, ids []int64) ([]*model.DepartmentWithDetails, error) {
+			calls++
+			leader := &model.LineManagerNested{ID: 10, Domain: "old.leader"}
+			if calls > 1 {
+				leader = &model.LineManagerNested{ID: 20, Domain: "new.leader"}
+			}
+			return []*model.DepartmentWithDetails{
+				{
+					Department: &model.Department{ID: 1, FullName: "Engineering"},
+					Leader:     leader,
+				},
+			}, nil
+		},
+		UpdateFunc: func(ctx context.Context, department *model.Department) error { return nil },
+	}
+
+	var published *events.Event
+	mockNotificationPub := &messagingmocks.MockNotificationProducer{
+		SendNotificationFunc: func(event *events.Event) error {
+			published = event
+			return nil
+		},
+	}
+
+	service := NewOrganizationApplicationService(mockDepartmentRepo, nil, nil, mockNotificationPub)
+	_, err := service.AssignLeader(context.Background(), &departmentcommand.AssignLeaderCommand{
+		DepartmentID: 1,
+		LeaderID:     &newLeaderID,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if published == nil {
+		t.Fatal("expected a leader assignment event")
+	}
+	if published.Type != events.EventTypeNotificationLeaderAssignment {
+		t.Errorf("expected event type %s, got %s", events.EventTypeNotificationLeaderAssignment, published.Type)
+	}
+
+	var payload events.LeaderAssignmentEventPayload
+	if err := published.UnmarshalPayload(&payload); err != nil {
+		t.Fatalf("failed to unmarshal payload: %v", err)
+	}
+	expected := events.LeaderAssignmentEventPayload{
+		FromStarter:    "old.leader",
+		ToStarter:      "new.leader",
+		Message:        "You have been assigned as leader of Engineering",
+		DepartmentID:   1,
+		DepartmentName: "Engineering",
+		PreviousLeader: "old.leader",
+	}
+	if payload != expected {
+		t.Errorf("expected payload %+v, got %+v", expected, payload)
+	}
+}
+
 func TestGetBusinessUnit(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	sharedDomain "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/error"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+	repo "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository"
+)
+
+// PIIAccessService resolves who is reading starter contact details and keeps the access log
+// of the reads that were not masked.
+type PIIAccessService struct {
+	starterRepo repo.StarterRepository
+	logRepo     repo.PIIAccessLogRepository
+}
+
+func NewPIIAccessService(
+	starterRepo repo.StarterRepository,
+	logRepo repo.PIIAccessLogRepository,
+) *PIIAccessService {
+	return &PIIAccessService{
+		starterRepo: starterRepo,
+		logRepo:     logRepo,
+	}
+}
+
+// ViewerFor builds the viewer for the caller with the given starter domain. Callers without a
+// starter record only see contact details when privileged.
+func (s *PIIAccessService) ViewerFor(ctx context.Context, domain string, privileged bool) (*model.PIIViewer, error) {
+	viewer := &model.PIIViewer{Domain: domain, Privileged: privileged}
+	if privileged || domain == "" {
+		return viewer, nil
+	}
+
+	starter, err := s.starterRepo.FindByDomain(ctx, domain)
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		return viewer, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	viewer.Starter = starter
+	return viewer, nil
+}
+
+// Record writes unmasked reads to the access log. A response that could not be logged must
+// not be sent, so callers fail the request on error.
+func (s *PIIAccessService) Record(ctx context.Context, reads []*model.PIIAccessLog) error {
+	if len(reads) == 0 {
+		return nil
+	}
+	return s.logRepo.SaveAll(ctx, reads)
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	sharedDomain "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/error"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+)
+
+const (
+	reindexJobHistory      = 20
+	reindexJobMaxErrors    = 20
+	reindexJobMaxFailedIDs = 1000
+)
+
+// ReindexJobService runs search reindexing in the background, one job at a time, and keeps
+// the state of recent jobs in memory for the admin API.
+type ReindexJobService struct {
+	starterSvc *StarterApplicationService
+	now        func() time.Time
+
+	mu       sync.Mutex
+	jobs     map[string]*model.ReindexJob
+	activeID string
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func NewReindexJobService(starterSvc *StarterApplicationService) *ReindexJobService {
+	return &ReindexJobService{
+		starterSvc: starterSvc,
+		now:        time.Now,
+		jobs:       make(map[string]*model.ReindexJob),
+	}
+}
+
+// Start launches a reindex job, or returns ErrReindexInProgress if one is already running.
+func (s *ReindexJobService) Start(trigger string) (*model.ReindexJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.activeID != "" {
+		return nil, sharedDomain.ErrReindexInProgress
+	}
+
+	job := &model.ReindexJob{
+		ID:        uuid.NewString(),
+		Status:    model.ReindexJobPending,
+		Trigger:   trigger,
+		CreatedAt: s.now(),
+	}
+	s.jobs[job.ID] = job
+	s.activeID = job.ID
+	s.pruneLocked()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.wg.Add(1)
+	go s.run(ctx, job.ID)
+
+	return s.snapshotLocked(job), nil
+}
+
+// Get returns a copy of the job so callers never race with the running goroutine.
+func (s *ReindexJobService) Get(id string) (*model.ReindexJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, sharedDomain.ErrNotFound
+	}
+	return s.snapshotLocked(job), nil
+}
+
+// Cancel stops a running job; cancelling a finished job is a no-op that returns its final state.
+func (s *ReindexJobService) Cancel(id string) (*model.ReindexJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, sharedDomain.ErrNotFound
+	}
+	if id == s.activeID && s.cancel != nil {
+		s.cancel()
+	}
+	return s.snapshotLocked(job), nil
+}
+
+// Shutdown cancels the running job, if any, and waits for it to stop.
+func (s *ReindexJobService) Shutdown() {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+func (s *ReindexJobService) run(ctx context.Context, id string) {
+	defer s.wg.Done()
+
+	s.update(id, func(job *model.ReindexJob) {
+		startedAt := s.now()
+		job.Status = model.ReindexJobRunning
+		job.StartedAt = &startedAt
+	})
+	log.Printf("Reindex job %s started", id)
+
+	_, err := s.starterSvc.ReindexAll(ctx, &jobTracker{svc: s, id: id})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := s.jobs[id]
+	finishedAt := s.now()
+	job.FinishedAt = &finishedAt
+	switch {
+	case errors.Is(err, context.Canceled):
+		job.Status = model.ReindexJobCancelled
+	case err != nil:
+		job.Status = model.ReindexJobFailed
+		job.Errors = appendJobError(job.Errors, err)
+	default:
+		job.Status = model.ReindexJobCompleted
+	}
+
+	s.activeID = ""
+	s.cancel = nil
+	log.Printf("Reindex job %s %s: indexed=%d failed=%d", id, job.Status, job.Indexed, job.Failed)
+}
+
+func (s *ReindexJobService) update(id string, fn func(job *model.ReindexJob)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job, ok := s.jobs[id]; ok {
+		fn(job)
+	}
+}
+
+func (s *ReindexJobService) snapshotLocked(job *model.ReindexJob) *model.ReindexJob {
+	snapshot := *job
+	snapshot.Errors = append([]string(nil), job.Errors...)
+	snapshot.FailedIDs = append([]int64(nil), job.FailedIDs...)
+	return &snapshot
+}
+
+// pruneLocked drops the oldest finished jobs beyond the history limit.
+func (s *ReindexJobService) pruneLocked() {
+	if len(s.jobs) <= reindexJobHistory {
+		return
+	}
+
+	finished := make([]*model.ReindexJob, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		if job.IsFinished() {
+			finished = append(finished, job)
+		}
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].CreatedAt.Before(finished[j].CreatedAt)
+	})
+
+	for _, job := range finished {
+		if len(s.jobs) <= reindexJobHistory {
+			return
+		}
+		delete(s.jobs, job.ID)
+	}
+}
+
+func appendJobError(errs []string, err error) []string {
+	errs = append(errs, err.Error())
+	if len(errs) > reindexJobMaxErrors {
+		errs = errs[len(errs)-reindexJobMaxErrors:]
+	}
+	return errs
+}
+
+// jobTracker records ReindexAll progress on the job it belongs to.
+type jobTracker struct {
+	svc *ReindexJobService
+	id  string
+}
+
+func (t *jobTracker) SetTotal(total int64) {
+	t.svc.update(t.id, func(job *model.ReindexJob) { job.Total = total })
+}
+
+func (t *jobTracker) BatchIndexed(count int) {
+	t.svc.update(t.id, func(job *model.ReindexJob) { job.Indexed += int64(count) })
+}
+
+func (t *jobTracker) BatchFailed(ids []int64, err error) {
+	t.svc.update(t.id, func(job *model.ReindexJob) {
+		job.Failed += int64(len(ids))
+		job.Errors = appendJobError(job.Errors, err)
+		if room := reindexJobMaxFailedIDs - len(job.FailedIDs); room > 0 {
+			job.FailedIDs = append(job.FailedIDs, ids[:min(room, len(ids))]...)
+		}
+	})
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	sharedDomain "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/error"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository/mocks"
+	domainService "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/service"
+)
+
+type recordingTracker struct {
+	total   int64
+	indexed int
+	failed  []int64
+	errs    []error
+}
+
+func (t *recordingTracker) SetTotal(total int64)   { t.total = total }
+func (t *recordingTracker) BatchIndexed(count int) { t.indexed += count }
+func (t *recordingTracker) BatchFailed(ids []int64, err error) {
+	t.failed = append(t.failed, ids...)
+	t.errs = append(t.errs, err)
+}
+
+func newReindexTestService(
+	starters []*model.Starter,
+	bulkIndex func(ctx context.Context, docs []*model.StarterESDoc) (*model.BulkIndexResult, error),
+	afterIDs *[]int64,
+	deadLetters *[]*model.SearchDeadLetter,
+) *StarterApplicationService {
+	starterRepo := &mocks.MockStarterRepository{
+		CountActiveFunc: func(ctx context.Context) (int64, error) {
+			return int64(len(starters)), nil
+		},
+		ListAfterIDFunc: func(ctx context.Context, afterID int64, limit int) ([]*model.Starter, error) {
+			if afterIDs != nil {
+				*afterIDs = append(*afterIDs, afterID)
+			}
+			var page []*model.Starter
+			for _, starter := range starters {
+				if starter.ID > afterID && len(page) < limit {
+					page = append(page, starter)
+				}
+			}
+			return page, nil
+		},
+	}
+
+	deadLetterRepo := &mocks.MockSearchDeadLetterRepository{
+		SaveAllFunc: func(ctx context.Context, letters []*model.SearchDeadLetter) error {
+			if deadLetters != nil {
+				*deadLetters = append(*deadLetters, letters...)
+			}
+			return nil
+		},
+	}
+
+	return newTestService(testServiceDeps{
+		starterRepo:    starterRepo,
+		searchRepo:     &mocks.MockStarterSearchRepository{BulkIndexFunc: bulkIndex},
+		enrichment:     domainService.NewStarterEnrichmentService(starterRepo, &mocks.MockDepartmentRepository{}, &mocks.MockBusinessUnitRepository{}),
+		deadLetterRepo: deadLetterRepo,
+	})
+}
+
+func TestReindexAll(t *testing.T) {
+	starters := newTestStarters(t, 250)
+
+	var (
+		afterIDs    []int64
+		deadLetters []*model.SearchDeadLetter
+	)
+	calls := 0
+	service := newReindexTestService(starters, func(ctx context.Context, docs []*model.StarterESDoc) (*model.BulkIndexResult, error) {
+		calls++
+		switch calls {
+		case 2:
+			return nil, errors.New("bulk error")
+		case 3:
+			// One document rejected in the last batch
+			return &model.BulkIndexResult{
+				Indexed: len(docs) - 1,
+				Failures: []model.BulkIndexFailure{{
+					StarterID:  docs[0].ID(),
+					StatusCode: 400,
+					ErrorType:  "mapper_parsing_exception",
+					Reason:     "failed to parse field [phone]",
+					Attempts:   1,
+				}},
+			}, nil
+		}
+		return &model.BulkIndexResult{Indexed: len(docs)}, nil
+	}, &afterIDs, &deadLetters)
+
+	tracker := &recordingTracker{}
+	report, err := service.ReindexAll(context.Background(), tracker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedAfterIDs := []int64{0, 100, 200, 250}
+	if len(afterIDs) != len(expectedAfterIDs) {
+		t.Fatalf("expected keyset pages %v, got %v", expectedAfterIDs, afterIDs)
+	}
+	for i := range expectedAfterIDs {
+		if afterIDs[i] != expectedAfterIDs[i] {
+			t.Errorf("expected keyset pages %v, got %v", expectedAfterIDs, afterIDs)
+			break
+		}
+	}
+
+	if tracker.total != 250 {
+		t.Errorf("expected total 250, got %d", tracker.total)
+	}
+	if tracker.indexed != 149 {
+		t.Errorf("expected 149 indexed, got %d", tracker.indexed)
+	}
+	if len(tracker.failed) != 101 || len(tracker.errs) != 2 {
+		t.Errorf("expected 101 failed documents in 2 batches, got failed=%d errors=%d", len(tracker.failed), len(tracker.errs))
+	}
+
+	if report.Total != 250 || report.Indexed != 149 || len(report.FailedIDs) != 101 {
+		t.Errorf("unexpected report: total=%d indexed=%d failed=%d", report.Total, report.Indexed, len(report.FailedIDs))
+	}
+	if report.FailedIDs[0] != 101 || report.FailedIDs[100] != 201 {
+		t.Errorf("unexpected failed ids: first=%d last=%d", report.FailedIDs[0], report.FailedIDs[100])
+	}
+
+	if len(deadLetters) != 101 {
+		t.Fatalf("expected 101 dead letters, got %d", len(deadLetters))
+	}
+	rejected := deadLetters[100]
+	if rejected.StarterID != 201 || rejected.StatusCode != 400 || rejected.ErrorType != "mapper_parsing_exception" {
+		t.Errorf("unexpected dead letter: %+v", rejected)
+	}
+}
+
+func TestReindexAllCancelled(t *testing.T) {
+	service := newReindexTestService(newTestStarters(t, 10), nil, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := service.ReindexAll(ctx, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func waitForJob(t *testing.T, jobs *ReindexJobService, id string) *model.ReindexJob {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := jobs.Get(id)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if job.IsFinished() {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return nil
+}
+
+func TestReindexJobServiceCompletes(t *testing.T) {
+	jobs := NewReindexJobService(newReindexTestService(newTestStarters(t, 120), nil, nil, nil))
+
+	job, err := jobs.Start(model.ReindexTriggerAPI)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	finished := waitForJob(t, jobs, job.ID)
+	if finished.Status != model.ReindexJobCompleted {
+		t.Errorf("expected status completed, got %s", finished.Status)
+	}
+	if finished.Total != 120 || finished.Indexed != 120 || finished.Failed != 0 {
+		t.Errorf("unexpected counters: total=%d indexed=%d failed=%d", finished.Total, finished.Indexed, finished.Failed)
+	}
+	if finished.StartedAt == nil || finished.FinishedAt == nil {
+		t.Error("expected start and finish times to be set")
+	}
+
+	if _, err := jobs.Get("00000000-0000-0000-0000-000000000000"); !errors.Is(err, sharedDomain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown job, got %v", err)
+	}
+}
+
+func TestReindexJobServiceSingleJobAndCancel(t *testing.T) {
+	started := make(chan struct{})
+	var once sync.Once
+	var deadLetters []*model.SearchDeadLetter
+	service := newReindexTestService(newTestStarters(t, 10), func(ctx context.Context, docs []*model.StarterESDoc) (*model.BulkIndexResult, error) {
+		once.Do(func() { close(started) })
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, nil, &deadLetters)
+	jobs := NewReindexJobService(service)
+
+	job, err := jobs.Start(model.ReindexTriggerAPI)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-started
+
+	if _, err := jobs.Start(model.ReindexTriggerAPI); !errors.Is(err, sharedDomain.ErrReindexInProgress) {
+		t.Errorf("expected ErrReindexInProgress, got %v", err)
+	}
+
+	if _, err := jobs.Cancel(job.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	finished := waitForJob(t, jobs, job.ID)
+	if finished.Status != model.ReindexJobCancelled {
+		t.Errorf("expected status cancelled, got %s", finished.Status)
+	}
+	if len(deadLetters) != 0 {
+		t.Errorf("expected no dead letters for a cancelled batch, got %d", len(deadLetters))
+	}
+
+	if _, err := jobs.Start(model.ReindexTriggerAPI); err != nil {
+		t.Errorf("expected a new job to start after cancellation, got %v", err)
+	}
+	jobs.Shutdown()
+}
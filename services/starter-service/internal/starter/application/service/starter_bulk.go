@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	startercommand "github.com/kiin21/go-rest/services/starter-service/internal/starter/application/dto/starter/command"
+	starterquery "github.com/kiin21/go-rest/services/starter-service/internal/starter/application/dto/starter/query"
+	sharedDomain "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/error"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+)
+
+// BulkUpdateStarters applies the same changes to every targeted starter in one transaction.
+// Every starter is validated first; if any is invalid nothing is written and the valid ones
+// are reported as skipped. The search index is then refreshed with a single bulk request.
+func (s *StarterApplicationService) BulkUpdateStarters(
+	ctx context.Context,
+	command *startercommand.BulkUpdateStartersCommand,
+) (*model.BulkChangeResult, error) {
+	starters, notFound, err := s.resolveBulkTarget(ctx, command.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.BulkChangeResult{Items: make([]model.BulkItemResult, 0, len(starters)+len(notFound))}
+	changes := make([]starterChange, len(starters))
+	invalid := false
+	for i, starter := range starters {
+		changes[i] = starterChange{starter: starter, before: placementOf(starter)}
+		item := model.BulkItemResult{ID: starter.ID, Domain: starter.Domain, Status: model.BulkItemUpdated}
+		if err := s.applyBulkChanges(starter, &command.Changes); err != nil {
+			item.Status = model.BulkItemInvalid
+			item.Error = err.Error()
+			invalid = true
+		}
+		result.Items = append(result.Items, item)
+	}
+	result.Items = append(result.Items, notFound...)
+
+	if invalid {
+		for i := range result.Items {
+			if result.Items[i].Status == model.BulkItemUpdated {
+				result.Items[i].Status = model.BulkItemSkipped
+			}
+		}
+		return result, nil
+	}
+
+	if len(starters) > 0 {
+		if err := s.starterRepo.UpdateAll(ctx, starters); err != nil {
+			return nil, err
+		}
+	}
+	result.Applied = true
+
+	s.asyncIndexBatch(starters)
+	s.notifyStartersChanged(ctx, changes)
+	return result, nil
+}
+
+// BulkDeleteStarters soft deletes every targeted starter in one transaction and removes
+// them from the search index with a single request.
+func (s *StarterApplicationService) BulkDeleteStarters(
+	ctx context.Context,
+	command *startercommand.BulkDeleteStartersCommand,
+) (*model.BulkChangeResult, error) {
+	starters, notFound, err := s.resolveBulkTarget(ctx, command.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(starters))
+	result := &model.BulkChangeResult{Items: make([]model.BulkItemResult, 0, len(starters)+len(notFound))}
+	for i, starter := range starters {
+		ids[i] = starter.ID
+		result.Items = append(result.Items, model.BulkItemResult{
+			ID:     starter.ID,
+			Domain: starter.Domain,
+			Status: model.BulkItemDeleted,
+		})
+	}
+	result.Items = append(result.Items, notFound...)
+
+	if len(ids) > 0 {
+		if err := s.starterRepo.SoftDeleteAll(ctx, ids); err != nil {
+			return nil, err
+		}
+	}
+	result.Applied = true
+
+	s.asyncBulkDeleteFromIndex(ids)
+	s.notifyStartersDeleted(ctx, starters...)
+	return result, nil
+}
+
+// resolveBulkTarget loads the starters a bulk change applies to, plus a not_found item for
+// each requested domain without a live starter. A filter may match at most BulkChangeMaxItems.
+func (s *StarterApplicationService) resolveBulkTarget(
+	ctx context.Context,
+	target startercommand.BulkTarget,
+) ([]*model.Starter, []model.BulkItemResult, error) {
+	if len(target.Domains) > 0 {
+		batch, err := s.BatchGetStarters(ctx, &starterquery.BatchGetStartersQuery{Domains: target.Domains})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		notFound := make([]model.BulkItemResult, len(batch.NotFoundDomains))
+		for i, domain := range batch.NotFoundDomains {
+			notFound[i] = model.BulkItemResult{Domain: domain, Status: model.BulkItemNotFound}
+		}
+		return batch.Starters, notFound, nil
+	}
+
+	if target.Filter.IsEmpty() {
+		return nil, nil, fmt.Errorf("%w: a bulk change needs domains or a filter", sharedDomain.ErrInvalidInput)
+	}
+
+	starters, err := s.starterRepo.FindByFilter(ctx, target.Filter, model.BulkChangeMaxItems+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(starters) > model.BulkChangeMaxItems {
+		return nil, nil, fmt.Errorf("%w: the filter matches more than %d starters",
+			sharedDomain.ErrBulkTargetTooLarge, model.BulkChangeMaxItems)
+	}
+
+	return starters, nil, nil
+}
+
+func (s *StarterApplicationService) applyBulkChanges(starter *model.Starter, changes *startercommand.UpdateStarterCommand) error {
+	bulkChanges := *changes
+	bulkChanges.Domain = nil
+
+	domain, name, email, mobile, workPhone, jobTitle, departmentID, lineManagerID := s.applyUpdates(starter, &bulkChanges)
+	if lineManagerID != nil && *lineManagerID == starter.ID {
+		return errors.New("a starter cannot be their own line manager")
+	}
+
+	return starter.UpdateInfo(domain, name, email, mobile, workPhone, jobTitle, departmentID, lineManagerID)
+}
+
+// asyncIndexBatch refreshes the search documents of changed starters with one bulk request.
+func (s *StarterApplicationService) asyncIndexBatch(starters []*model.Starter) {
+	if s.searchRepo == nil || len(starters) == 0 {
+		return
+	}
+
+	go func() {
+		if _, err := s.indexBatch(context.Background(), starters); err != nil {
+			log.Printf("Failed to bulk index %d starters: %v", len(starters), err)
+		}
+	}()
+}
+
+func (s *StarterApplicationService) asyncBulkDeleteFromIndex(ids []int64) {
+	if s.searchRepo == nil || len(ids) == 0 {
+		return
+	}
+
+	go func() {
+		if err := s.searchRepo.BulkDelete(context.Background(), ids); err != nil {
+			log.Printf("Failed to bulk delete %d starters from Elasticsearch: %v", len(ids), err)
+		}
+	}()
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	startercommand "github.com/kiin21/go-rest/services/starter-service/internal/starter/application/dto/starter/command"
+	sharedDomain "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/error"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository/mocks"
+)
+
+func TestBulkUpdateStarters(t *testing.T) {
+	newDepartmentID := int64(7)
+
+	t.Run("applies changes in one call", func(t *testing.T) {
+		starters := newTestStarters(t, 2)
+		var saved []*model.Starter
+		mockStarterRepo := &mocks.MockStarterRepository{
+			FindByDomainsFunc: func(ctx context.Context, domains []string) ([]*model.Starter, error) {
+				return starters, nil
+			},
+			UpdateAllFunc: func(ctx context.Context, starters []*model.Starter) error {
+				saved = starters
+				return nil
+			},
+		}
+		service := newTestService(testServiceDeps{starterRepo: mockStarterRepo})
+
+		result, err := service.BulkUpdateStarters(context.Background(), &startercommand.BulkUpdateStartersCommand{
+			Target:  startercommand.BulkTarget{Domains: []string{"user1", "user2", "ghost"}},
+			Changes: startercommand.UpdateStarterCommand{DepartmentID: &newDepartmentID},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !result.Applied {
+			t.Fatal("expected the change to be applied")
+		}
+		if len(saved) != 2 {
+			t.Fatalf("expected 2 starters saved together, got %d", len(saved))
+		}
+		for _, starter := range saved {
+			if starter.DepartmentID == nil || *starter.DepartmentID != newDepartmentID {
+				t.Errorf("expected %s to move to department 7", starter.Domain)
+			}
+		}
+
+		statuses := []model.BulkItemStatus{model.BulkItemUpdated, model.BulkItemUpdated, model.BulkItemNotFound}
+		for i, item := range result.Items {
+			if item.Status != statuses[i] {
+				t.Errorf("item %d: expected %s, got %s", i, statuses[i], item.Status)
+			}
+		}
+	})
+
+	t.Run("invalid item rejects the whole change", func(t *testing.T) {
+		starters := newTestStarters(t, 2)
+		managerID := int64(2)
+		mockStarterRepo := &mocks.MockStarterRepository{
+			FindByDomainsFunc: func(ctx context.Context, domains []string) ([]*model.Starter, error) {
+				return starters, nil
+			},
+			UpdateAllFunc: func(ctx context.Context, starters []*model.Starter) error {
+				t.Error("expected nothing to be written")
+				return nil
+			},
+		}
+		service := newTestService(testServiceDeps{starterRepo: mockStarterRepo})
+
+		result, err := service.BulkUpdateStarters(context.Background(), &startercommand.BulkUpdateStartersCommand{
+			Target:  startercommand.BulkTarget{Domains: []string{"user1", "user2"}},
+			Changes: startercommand.UpdateStarterCommand{LineManagerID: &managerID},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.Applied {
+			t.Error("expected the change not to be applied")
+		}
+		if result.Items[0].Status != model.BulkItemSkipped || result.Items[1].Status != model.BulkItemInvalid {
+			t.Errorf("expected skipped and invalid items, got %+v", result.Items)
+		}
+	})
+
+	t.Run("filter matching too many starters", func(t *testing.T) {
+		mockStarterRepo := &mocks.MockStarterRepository{
+			FindByFilterFunc: func(ctx context.Context, filter *model.StarterListFilter, limit int) ([]*model.Starter, error) {
+				return make([]*model.Starter, limit), nil
+			},
+		}
+		service := newTestService(testServiceDeps{starterRepo: mockStarterRepo})
+
+		filter, _ := model.ParseStarterListFilter("department_id:eq:3")
+		_, err := service.BulkUpdateStarters(context.Background(), &startercommand.BulkUpdateStartersCommand{
+			Target:  startercommand.BulkTarget{Filter: filter},
+			Changes: startercommand.UpdateStarterCommand{DepartmentID: &newDepartmentID},
+		})
+		if !errors.Is(err, sharedDomain.ErrBulkTargetTooLarge) {
+			t.Errorf("expected ErrBulkTargetTooLarge, got %v", err)
+		}
+	})
+}
+
+func TestBulkDeleteStarters(t *testing.T) {
+	starters := newTestStarters(t, 2)
+	var deletedIDs []int64
+	mockStarterRepo := &mocks.MockStarterRepository{
+		FindByFilterFunc: func(ctx context.Context, filter *model.StarterListFilter, limit int) ([]*model.Starter, error) {
+			return starters, nil
+		},
+		SoftDeleteAllFunc: func(ctx context.Context, ids []int64) error {
+			deletedIDs = ids
+			return nil
+		},
+	}
+	service := newTestService(testServiceDeps{starterRepo: mockStarterRepo})
+
+	filter, _ := model.ParseStarterListFilter("department_id:eq:3")
+	result, err := service.BulkDeleteStarters(context.Background(), &startercommand.BulkDeleteStartersCommand{
+		Target: startercommand.BulkTarget{Filter: filter},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(deletedIDs) != 2 || deletedIDs[0] != 1 || deletedIDs[1] != 2 {
+		t.Errorf("expected ids [1 2] deleted together, got %v", deletedIDs)
+	}
+	if !result.Applied || len(result.Items) != 2 || result.Items[0].Status != model.BulkItemDeleted {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/kiin21/go-rest/pkg/events"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+)
+
+// notificationSender is the FromStarter of notifications no starter sent.
+const notificationSender = "system"
+
+// starterPlacement is where a starter works and whom they report to.
+type starterPlacement struct {
+	departmentID  *int64
+	lineManagerID *int64
+}
+
+func placementOf(starter *model.Starter) starterPlacement {
+	return starterPlacement{departmentID: starter.DepartmentID, lineManagerID: starter.LineManagerID}
+}
+
+// starterChange is a saved starter with their placement before the change.
+type starterChange struct {
+	starter *model.Starter
+	before  starterPlacement
+}
+
+// orgLookup holds the line managers and departments that notifications refer to.
+type orgLookup struct {
+	managers    map[int64]*model.Starter
+	departments map[int64]*model.DepartmentWithDetails
+}
+
+// lookupOrg loads the line managers and departments of the placements with one query each.
+// A failed query only leaves its names out, so a notification is never held up by it.
+func (s *StarterApplicationService) lookupOrg(ctx context.Context, placements []starterPlacement) *orgLookup {
+	lookup := &orgLookup{
+		managers:    make(map[int64]*model.Starter),
+		departments: make(map[int64]*model.DepartmentWithDetails),
+	}
+
+	var managerIDs, departmentIDs []int64
+	for _, placement := range placements {
+		if placement.lineManagerID != nil {
+			managerIDs = append(managerIDs, *placement.lineManagerID)
+		}
+		if placement.departmentID != nil {
+			departmentIDs = append(departmentIDs, *placement.departmentID)
+		}
+	}
+
+	if len(managerIDs) > 0 {
+		managers, err := s.starterRepo.FindByIDs(ctx, managerIDs)
+		if err != nil {
+			log.Printf("failed to load line managers for notifications: %v", err)
+		}
+		for _, manager := range managers {
+			lookup.managers[manager.ID] = manager
+		}
+	}
+	if len(departmentIDs) > 0 && s.departmentRepo != nil {
+		departments, err := s.departmentRepo.FindByIDsWithDetails(ctx, departmentIDs)
+		if err != nil {
+			log.Printf("failed to load departments for notifications: %v", err)
+		}
+		for _, department := range departments {
+			lookup.departments[department.ID] = department
+		}
+	}
+	return lookup
+}
+
+func (l *orgLookup) manager(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	if manager, ok := l.managers[*id]; ok {
+		return manager.Domain
+	}
+	return ""
+}
+
+// department returns the department's ID, name and leader's domain.
+func (l *orgLookup) department(id *int64) (int64, string, string) {
+	if id == nil {
+		return 0, "", ""
+	}
+	department, ok := l.departments[*id]
+	if !ok {
+		return *id, "", ""
+	}
+	leader := ""
+	if department.Leader != nil {
+		leader = department.Leader.Domain
+	}
+	return department.ID, department.FullName, leader
+}
+
+// notifyStartersCreated tells each new starter's line manager and department leader.
+func (s *StarterApplicationService) notifyStartersCreated(ctx context.Context, starters ...*model.Starter) {
+	if s.notificationPub == nil || len(starters) == 0 {
+		return
+	}
+
+	placements := make([]starterPlacement, len(starters))
+	for i, starter := range starters {
+		placements[i] = placementOf(starter)
+	}
+	lookup := s.lookupOrg(ctx, placements)
+
+	for _, starter := range starters {
+		lineManager := lookup.manager(starter.LineManagerID)
+		departmentID, departmentName, leader := lookup.department(starter.DepartmentID)
+
+		message := fmt.Sprintf("%s has joined", starter.Name)
+		if departmentName != "" {
+			message += " " + departmentName
+		}
+		for _, recipient := range notificationRecipients(starter.Domain, lineManager, leader) {
+			s.publishNotification(events.EventTypeNotificationStarterCreated, events.StarterCreatedEventPayload{
+				NotificationPayload: notificationTo(recipient, message),
+				StarterDomain:       starter.Domain,
+				StarterName:         starter.Name,
+				DepartmentID:        departmentID,
+				DepartmentName:      departmentName,
+				LineManager:         lineManager,
+			})
+		}
+	}
+}
+
+// notifyStartersChanged tells the previous and new line manager when a starter's line
+// manager changed, and the starter and both department leaders when they changed department.
+func (s *StarterApplicationService) notifyStartersChanged(ctx context.Context, changes []starterChange) {
+	if s.notificationPub == nil {
+		return
+	}
+
+	var placements []starterPlacement
+	for _, change := range changes {
+		placements = append(placements, change.before, placementOf(change.starter))
+	}
+	lookup := s.lookupOrg(ctx, placements)
+
+	for _, change := range changes {
+		starter := change.starter
+		if !sameID(change.before.lineManagerID, starter.LineManagerID) {
+			lineManager := lookup.manager(starter.LineManagerID)
+			previous := lookup.manager(change.before.lineManagerID)
+
+			message := fmt.Sprintf("%s now reports to %s", starter.Name, lineManager)
+			if lineManager == "" {
+				message = fmt.Sprintf("%s no longer has a line manager", starter.Name)
+			}
+			for _, recipient := range notificationRecipients(starter.Domain, previous, lineManager) {
+				s.publishNotification(events.EventTypeNotificationLineManagerChanged, events.LineManagerChangedEventPayload{
+					NotificationPayload: notificationTo(recipient, message),
+					StarterDomain:       starter.Domain,
+					StarterName:         starter.Name,
+					LineManager:         lineManager,
+					PreviousLineManager: previous,
+				})
+			}
+		}
+
+		if !sameID(change.before.departmentID, starter.DepartmentID) {
+			departmentID, departmentName, leader := lookup.department(starter.DepartmentID)
+			previousID, previousName, previousLeader := lookup.department(change.before.departmentID)
+
+			message := fmt.Sprintf("%s has moved to %s", starter.Name, departmentName)
+			if previousName != "" {
+				message = fmt.Sprintf("%s has moved from %s to %s", starter.Name, previousName, departmentName)
+			}
+			// The starter hears of their own transfer too
+			for _, recipient := range notificationRecipients("", starter.Domain, previousLeader, leader) {
+				s.publishNotification(events.EventTypeNotificationDepartmentTransfer, events.DepartmentTransferEventPayload{
+					NotificationPayload:    notificationTo(recipient, message),
+					StarterDomain:          starter.Domain,
+					StarterName:            starter.Name,
+					DepartmentID:           departmentID,
+					DepartmentName:         departmentName,
+					PreviousDepartmentID:   previousID,
+					PreviousDepartmentName: previousName,
+				})
+			}
+		}
+	}
+}
+
+// notifyStartersDeleted tells each deleted starter's line manager and department leader.
+func (s *StarterApplicationService) notifyStartersDeleted(ctx context.Context, starters ...*model.Starter) {
+	if s.notificationPub == nil || len(starters) == 0 {
+		return
+	}
+
+	placements := make([]starterPlacement, len(starters))
+	for i, starter := range starters {
+		placements[i] = placementOf(starter)
+	}
+	lookup := s.lookupOrg(ctx, placements)
+
+	for _, starter := range starters {
+		lineManager := lookup.manager(starter.LineManagerID)
+		departmentID, departmentName, leader := lookup.department(starter.DepartmentID)
+
+		message := fmt.Sprintf("%s has been removed", starter.Name)
+		if departmentName != "" {
+			message += " from " + departmentName
+		}
+		for _, recipient := range notificationRecipients(starter.Domain, lineManager, leader) {
+			s.publishNotification(events.EventTypeNotificationStarterDeleted, events.StarterDeletedEventPayload{
+				NotificationPayload: notificationTo(recipient, message),
+				StarterDomain:       starter.Domain,
+				StarterName:         starter.Name,
+				DepartmentID:        departmentID,
+				DepartmentName:      departmentName,
+				LineManager:         lineManager,
+			})
+		}
+	}
+}
+
+func (s *StarterApplicationService) publishNotification(eventType string, payload interface{}) {
+	event, err := events.NewEvent(eventType, payload)
+	if err != nil {
+		log.Printf("failed to create %s notification event: %v", eventType, err)
+		return
+	}
+
+	if err := s.notificationPub.SendNotification(event); err != nil {
+		log.Printf("failed to publish %s notification: %v", eventType, err)
+	}
+}
+
+func notificationTo(recipient, message string) events.NotificationPayload {
+	return events.NotificationPayload{FromStarter: notificationSender, ToStarter: recipient, Message: message}
+}
+
+// notificationRecipients drops empty and repeated domains, and the starter the notification
+// is about unless starter is empty.
+func notificationRecipients(starter string, domains ...string) []string {
+	recipients := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		if domain == "" || domain == starter || slices.Contains(recipients, domain) {
+			continue
+		}
+		recipients = append(recipients, domain)
+	}
+	return recipients
+}
+
+func sameID(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
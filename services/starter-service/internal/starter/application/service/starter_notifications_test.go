@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kiin21/go-rest/pkg/events"
+	startercommand "github.com/kiin21/go-rest/services/starter-service/internal/starter/application/dto/starter/command"
+	messagingmocks "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/messaging/mocks"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository/mocks"
+	domainService "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/service"
+)
+
+// newNotifyingService returns a service whose org has managers 10 (manager.a) and 11
+// (manager.b), and departments 1 (Engineering, led by lead.eng) and 2 (Sales, led by
+// lead.sales).
+func newNotifyingService(starterRepo *mocks.MockStarterRepository, published *[]*events.Event) *StarterApplicationService {
+	managers := map[int64]*model.Starter{
+		10: {ID: 10, Domain: "manager.a"},
+		11: {ID: 11, Domain: "manager.b"},
+	}
+	starterRepo.FindByIDsFunc = func(ctx context.Context, ids []int64) ([]*model.Starter, error) {
+		var found []*model.Starter
+		for _, id := range ids {
+			if manager, ok := managers[id]; ok {
+				found = append(found, manager)
+			}
+		}
+		return found, nil
+	}
+
+	departments := map[int64]*model.DepartmentWithDetails{
+		1: {Department: &model.Department{ID: 1, FullName: "Engineering"}, Leader: &model.LineManagerNested{Domain: "lead.eng"}},
+		2: {Department: &model.Department{ID: 2, FullName: "Sales"}, Leader: &model.LineManagerNested{Domain: "lead.sales"}},
+	}
+	departmentRepo := &mocks.MockDepartmentRepository{
+		FindByIDsWithDetailsFunc: func(ctx context.Context, ids []int64) ([]*model.DepartmentWithDetails, error) {
+			var found []*model.DepartmentWithDetails
+			for _, id := range ids {
+				if department, ok := departments[id]; ok {
+					found = append(found, department)
+				}
+			}
+			return found, nil
+		},
+	}
+
+	publisher := &messagingmocks.MockNotificationProducer{
+		SendNotificationFunc: func(event *events.Event) error {
+			*published = append(*published, event)
+			return nil
+		},
+	}
+
+	return newTestService(testServiceDeps{
+		starterRepo:    starterRepo,
+		domainService:  domainService.NewStarterDomainService(starterRepo),
+		departmentRepo: departmentRepo,
+		publisher:      publisher,
+	})
+}
+
+func recipientsOf(t *testing.T, published []*events.Event, eventType string) []string {
+	t.Helper()
+	var recipients []string
+	for _, event := range published {
+		if event.Type != eventType {
+			continue
+		}
+		var payload events.NotificationPayload
+		if err := event.UnmarshalPayload(&payload); err != nil {
+			t.Fatalf("failed to unmarshal payload: %v", err)
+		}
+		recipients = append(recipients, payload.ToStarter)
+	}
+	return recipients
+}
+
+func assertRecipients(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected recipients %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected recipients %v, got %v", want, got)
+		}
+	}
+}
+
+func TestCreateStarter_NotifiesLineManagerAndDepartmentLeader(t *testing.T) {
+	var published []*events.Event
+	starterRepo := &mocks.MockStarterRepository{
+		FindByDomainFunc: func(ctx context.Context, domain string) (*model.Starter, error) {
+			return nil, errors.New("resource not found")
+		},
+	}
+	service := newNotifyingService(starterRepo, &published)
+
+	_, err := service.CreateStarter(context.Background(), &startercommand.CreateStarterCommand{
+		Domain:        "new.starter",
+		Name:          "New Starter",
+		Email:         "new.starter@vng.com.vn",
+		Mobile:        "0123456789",
+		JobTitle:      "Developer",
+		DepartmentID:  int64Ptr(1),
+		LineManagerID: int64Ptr(10),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertRecipients(t, recipientsOf(t, published, events.EventTypeNotificationStarterCreated), "manager.a", "lead.eng")
+
+	var payload events.StarterCreatedEventPayload
+	if err := published[0].UnmarshalPayload(&payload); err != nil {
+		t.Fatalf("failed to unmarshal payload: %v", err)
+	}
+	expected := events.StarterCreatedEventPayload{
+		NotificationPayload: events.NotificationPayload{
+			FromStarter: "system",
+			ToStarter:   "manager.a",
+			Message:     "New Starter has joined Engineering",
+		},
+		StarterDomain:  "new.starter",
+		StarterName:    "New Starter",
+		DepartmentID:   1,
+		DepartmentName: "Engineering",
+		LineManager:    "manager.a",
+	}
+	if payload != expected {
+		t.Errorf("expected payload %+v, got %+v", expected, payload)
+	}
+}
+
+func TestUpdateStarter_NotifiesLineManagerChangeAndTransfer(t *testing.T) {
+	renamed := "Renamed Starter"
+	tests := []struct {
+		name          string
+		command       *startercommand.UpdateStarterCommand
+		wantManagers  []string
+		wantTransfers []string
+	}{
+		{
+			name:         "line manager changed",
+			command:      &startercommand.UpdateStarterCommand{LineManagerID: int64Ptr(11)},
+			wantManagers: []string{"manager.a", "manager.b"},
+		},
+		{
+			name:          "department changed",
+			command:       &startercommand.UpdateStarterCommand{DepartmentID: int64Ptr(2)},
+			wantTransfers: []string{"some.starter", "lead.eng", "lead.sales"},
+		},
+		{
+			name:    "nothing relevant changed",
+			command: &startercommand.UpdateStarterCommand{Name: &renamed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var published []*events.Event
+			starterRepo := &mocks.MockStarterRepository{
+				FindByDomainFunc: func(ctx context.Context, domain string) (*model.Starter, error) {
+					starter, err := model.NewStarter("some.starter", "Some Starter", "some.starter@vng.com.vn",
+						"0123456789", "", "Developer", int64Ptr(1), int64Ptr(10))
+					if err != nil {
+						return nil, err
+					}
+					starter.ID = 5
+					return starter, nil
+				},
+				UpdateFunc: func(ctx context.Context, starter *model.Starter) error { return nil },
+			}
+			service := newNotifyingService(starterRepo, &published)
+
+			tt.command.OriginalDomain = "some.starter"
+			if _, err := service.UpdateStarter(context.Background(), tt.command); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertRecipients(t, recipientsOf(t, published, events.EventTypeNotificationLineManagerChanged), tt.wantManagers...)
+			assertRecipients(t, recipientsOf(t, published, events.EventTypeNotificationDepartmentTransfer), tt.wantTransfers...)
+		})
+	}
+}
+
+func TestSoftDeleteStarter_NotifiesLineManagerAndDepartmentLeader(t *testing.T) {
+	var published []*events.Event
+	starterRepo := &mocks.MockStarterRepository{
+		SoftDeleteFunc: func(ctx context.Context, domain string) (*model.Starter, error) {
+			// The starter leads their own department, so only their line manager is told
+			return &model.Starter{
+				ID:            5,
+				Domain:        "lead.eng",
+				Name:          "Eng Lead",
+				DepartmentID:  int64Ptr(1),
+				LineManagerID: int64Ptr(10),
+			}, nil
+		},
+	}
+	service := newNotifyingService(starterRepo, &published)
+
+	if err := service.SoftDeleteStarter(context.Background(), "lead.eng"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertRecipients(t, recipientsOf(t, published, events.EventTypeNotificationStarterDeleted), "manager.a")
+}
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 
 	"github.com/kiin21/go-rest/pkg/httputil"
 	startercommand "github.com/kiin21/go-rest/services/starter-service/internal/starter/application/dto/starter/command"
 	starterquery "github.com/kiin21/go-rest/services/starter-service/internal/starter/application/dto/starter/query"
 	sharedDomain "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/error"
+	domainmessaging "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/messaging"
 	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
 	repo "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository"
 	domainService "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/service"
@@ -22,6 +24,9 @@ type StarterApplicationService struct {
 	domainService     *domainService.StarterDomainService
 	enrichmentService *domainService.StarterEnrichmentService
 	searchService     *domainService.StarterSearchService
+	deadLetterRepo    repo.SearchDeadLetterRepository
+	departmentRepo    repo.DepartmentRepository
+	notificationPub   domainmessaging.NotificationProducer
 }
 
 func NewStarterApplicationService(
@@ -30,6 +35,9 @@ func NewStarterApplicationService(
 	domainService *domainService.StarterDomainService,
 	enrichmentService *domainService.StarterEnrichmentService,
 	searchService *domainService.StarterSearchService,
+	deadLetterRepo repo.SearchDeadLetterRepository,
+	departmentRepo repo.DepartmentRepository,
+	notificationPublisher domainmessaging.NotificationProducer,
 ) *StarterApplicationService {
 	return &StarterApplicationService{
 		starterRepo:       starterRepo,
@@ -37,6 +45,9 @@ func NewStarterApplicationService(
 		domainService:     domainService,
 		searchService:     searchService,
 		enrichmentService: enrichmentService,
+		deadLetterRepo:    deadLetterRepo,
+		departmentRepo:    departmentRepo,
+		notificationPub:   notificationPublisher,
 	}
 }
 
@@ -86,6 +97,7 @@ func (s *StarterApplicationService) CreateStarter(
 	}
 
 	s.asyncIndexStarter(starter)
+	s.notifyStartersCreated(ctx, starter)
 	return starter, nil
 }
 
@@ -96,6 +108,75 @@ func (s *StarterApplicationService) GetStarterByDomain(
 	return s.starterRepo.FindByDomain(ctx, domainName)
 }
 
+// BatchGetStarters resolves many starters with a single query. Starters come back in request
+// order without duplicates; domains match case-insensitively, as they do in MySQL.
+func (s *StarterApplicationService) BatchGetStarters(
+	ctx context.Context,
+	query *starterquery.BatchGetStartersQuery,
+) (*starterquery.BatchGetStartersResult, error) {
+	result := &starterquery.BatchGetStartersResult{
+		Starters:        make([]*model.Starter, 0),
+		NotFoundDomains: make([]string, 0),
+		NotFoundIDs:     make([]int64, 0),
+	}
+
+	if len(query.Domains) > 0 {
+		starters, err := s.starterRepo.FindByDomains(ctx, query.Domains)
+		if err != nil {
+			return nil, err
+		}
+
+		byDomain := make(map[string]*model.Starter, len(starters))
+		for _, starter := range starters {
+			byDomain[strings.ToLower(starter.Domain)] = starter
+		}
+
+		seen := make(map[string]bool, len(query.Domains))
+		for _, domain := range query.Domains {
+			key := strings.ToLower(domain)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			if starter, ok := byDomain[key]; ok {
+				result.Starters = append(result.Starters, starter)
+			} else {
+				result.NotFoundDomains = append(result.NotFoundDomains, domain)
+			}
+		}
+		return result, nil
+	}
+
+	if len(query.IDs) > 0 {
+		starters, err := s.starterRepo.FindByIDs(ctx, query.IDs)
+		if err != nil {
+			return nil, err
+		}
+
+		byID := make(map[int64]*model.Starter, len(starters))
+		for _, starter := range starters {
+			byID[starter.ID] = starter
+		}
+
+		seen := make(map[int64]bool, len(query.IDs))
+		for _, id := range query.IDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			if starter, ok := byID[id]; ok {
+				result.Starters = append(result.Starters, starter)
+			} else {
+				result.NotFoundIDs = append(result.NotFoundIDs, id)
+			}
+		}
+	}
+
+	return result, nil
+}
+
 func (s *StarterApplicationService) UpdateStarter(
 	ctx context.Context,
 	command *startercommand.UpdateStarterCommand,
@@ -105,6 +186,7 @@ func (s *StarterApplicationService) UpdateStarter(
 		return nil, err
 	}
 
+	before := placementOf(starter)
 	domain, name, email, mobile, workPhone, jobTitle, departmentID, lineManagerID := s.applyUpdates(starter, command)
 
 	if err := starter.UpdateInfo(domain, name, email, mobile, workPhone, jobTitle, departmentID, lineManagerID); err != nil {
@@ -116,6 +198,7 @@ func (s *StarterApplicationService) UpdateStarter(
 	}
 
 	s.asyncIndexStarter(starter)
+	s.notifyStartersChanged(ctx, []starterChange{{starter: starter, before: before}})
 	return starter, nil
 }
 
@@ -129,54 +212,125 @@ func (s *StarterApplicationService) SoftDeleteStarter(
 	}
 
 	s.asyncDeleteFromIndex(entity)
+	s.notifyStartersDeleted(ctx, entity)
 	return nil
 }
 
-func (s *StarterApplicationService) ReindexAll(ctx context.Context) error {
+// ReindexTracker receives progress while ReindexAll runs.
+type ReindexTracker interface {
+	SetTotal(total int64)
+	BatchIndexed(count int)
+	BatchFailed(ids []int64, err error)
+}
+
+// ReindexAll rebuilds the search index from MySQL, paging by id so that rows created or
+// deleted during the run do not shift the pages. Documents Elasticsearch rejects are
+// dead-lettered and listed in the report; only a MySQL read error or cancellation stops the run.
+func (s *StarterApplicationService) ReindexAll(ctx context.Context, tracker ReindexTracker) (*model.ReindexReport, error) {
 	const batchSize = 100
-	var totalIndexed int
-
-	for page := 1; ; page++ {
-		query := &starterquery.ListStartersQuery{
-			Pagination: httputil.ReqPagination{
-				Page:  &page,
-				Limit: func() *int { v := batchSize; return &v }(),
-			},
+
+	if s.searchRepo == nil {
+		return nil, errors.New("search repository is not configured")
+	}
+
+	total, err := s.starterRepo.CountActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tracker != nil {
+		tracker.SetTotal(total)
+	}
+
+	report := &model.ReindexReport{Total: total}
+	var afterID int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
 		}
 
-		starters, total, err := s.starterRepo.SearchByKeyword(ctx, query)
+		starters, err := s.starterRepo.ListAfterID(ctx, afterID, batchSize)
 		if err != nil {
-			return fmt.Errorf("failed to fetch starters page %d: %w", page, err)
+			return report, fmt.Errorf("failed to fetch starters after id %d: %w", afterID, err)
 		}
-
 		if len(starters) == 0 {
 			break
 		}
+		afterID = starters[len(starters)-1].ID
 
-		enriched, err := s.enrichmentService.EnrichStarters(ctx, starters)
+		result, err := s.indexBatch(ctx, starters)
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
 		if err != nil {
-			return fmt.Errorf("failed to enrich starters batch: %w", err)
+			log.Printf("Failed to reindex batch ending at id %d: %v", afterID, err)
 		}
 
-		esDocs := make([]*model.StarterESDoc, len(starters))
-		for i, starter := range starters {
-			esDocs[i] = model.NewStarterESDocFromStarter(starter, enriched)
+		report.Indexed += int64(result.Indexed)
+		if failedIDs := result.FailedIDs(); len(failedIDs) > 0 {
+			report.FailedIDs = append(report.FailedIDs, failedIDs...)
+			if err == nil {
+				err = fmt.Errorf("%d documents rejected by Elasticsearch", len(failedIDs))
+			}
+			if tracker != nil {
+				tracker.BatchFailed(failedIDs, err)
+			}
 		}
-
-		if err := s.searchRepo.BulkIndex(ctx, esDocs); err != nil {
-			return fmt.Errorf("failed to bulk index batch: %w", err)
+		if tracker != nil && result.Indexed > 0 {
+			tracker.BatchIndexed(result.Indexed)
 		}
 
-		totalIndexed += len(starters)
-		log.Printf("Reindexed %d/%d starters", totalIndexed, total)
+		log.Printf("Reindexed %d/%d starters (%d failed)", report.Indexed, total, len(report.FailedIDs))
+	}
 
-		if int64(totalIndexed) >= total {
-			break
+	log.Printf("Reindexing completed: %d starters indexed, %d failed", report.Indexed, len(report.FailedIDs))
+	return report, nil
+}
+
+// indexBatch always returns a result covering every starter in the batch: when the bulk
+// request fails as a whole, all of them are reported as failures.
+func (s *StarterApplicationService) indexBatch(ctx context.Context, starters []*model.Starter) (*model.BulkIndexResult, error) {
+	result, err := s.bulkIndex(ctx, starters)
+	if err != nil {
+		result = &model.BulkIndexResult{}
+		for _, starter := range starters {
+			result.Failures = append(result.Failures, model.BulkIndexFailure{
+				StarterID: starter.ID,
+				Reason:    err.Error(),
+				Attempts:  1,
+			})
 		}
 	}
 
-	log.Printf("Reindexing completed: %d starters indexed", totalIndexed)
-	return nil
+	// A cancelled run is not a permanent failure
+	if len(result.Failures) > 0 && s.deadLetterRepo != nil && ctx.Err() == nil {
+		letters := make([]*model.SearchDeadLetter, len(result.Failures))
+		for i, failure := range result.Failures {
+			letters[i] = model.NewSearchDeadLetter(failure)
+		}
+		if dlErr := s.deadLetterRepo.SaveAll(ctx, letters); dlErr != nil {
+			log.Printf("Failed to save %d search dead letters: %v", len(letters), dlErr)
+		}
+	}
+
+	return result, err
+}
+
+func (s *StarterApplicationService) bulkIndex(ctx context.Context, starters []*model.Starter) (*model.BulkIndexResult, error) {
+	enriched, err := s.enrichmentService.EnrichStarters(ctx, starters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enrich starters batch: %w", err)
+	}
+
+	esDocs := make([]*model.StarterESDoc, len(starters))
+	for i, starter := range starters {
+		esDocs[i] = model.NewStarterESDocFromStarter(starter, enriched)
+	}
+
+	result, err := s.searchRepo.BulkIndex(ctx, esDocs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bulk index batch: %w", err)
+	}
+	return result, nil
 }
 
 func (s *StarterApplicationService) asyncIndexStarter(starter *model.Starter) {
@@ -212,6 +366,12 @@ func (s *StarterApplicationService) listFromMySQL(
 		return nil, err
 	}
 
+	if query.Pagination.IsCursorMode() {
+		data, pagination := httputil.NewCursorPage(starters, query.Pagination.GetLimit(), query.Pagination.Cursor,
+			func(starter *model.Starter) (string, string) { return starter.CursorKey(query.SortBy) })
+		return &httputil.PaginatedResult[*model.Starter]{Data: data, Pagination: pagination}, nil
+	}
+
 	limit := query.Pagination.GetLimit()
 	totalPages := int(total) / limit
 	if int(total)%limit > 0 {
@@ -103,6 +103,9 @@ func TestCreateStarter(t *testing.T) {
 				domainSvc,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
 			)
 
 			starter, err := service.CreateStarter(context.Background(), tt.command)
@@ -183,6 +186,9 @@ func TestGetStarterByDomain(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
 			)
 
 			starter, err := service.GetStarterByDomain(context.Background(), tt.domain)
@@ -223,6 +229,8 @@ func TestUpdateStarter(t *testing.T) {
 
 	newName := "Updated User"
 	newEmail := "updated@vng.com.vn"
+	testDomain := "testuser"
+	nonexistentDomain := "nonexistent"
 
 	tests := []struct {
 		name        string
@@ -234,9 +242,10 @@ func TestUpdateStarter(t *testing.T) {
 		{
 			name: "successful update",
 			command: &startercommand.UpdateStarterCommand{
-				Domain: "testuser",
-				Name:   &newName,
-				Email:  &newEmail,
+				OriginalDomain: "testuser",
+				Domain:         &testDomain,
+				Name:           &newName,
+				Email:          &newEmail,
 			},
 			mockFind: func(ctx context.Context, domain string) (*model.Starter, error) {
 				return existingStarter, nil
@@ -249,8 +258,9 @@ func TestUpdateStarter(t *testing.T) {
 		{
 			name: "starter not found",
 			command: &startercommand.UpdateStarterCommand{
-				Domain: "nonexistent",
-				Name:   &newName,
+				OriginalDomain: "nonexistent",
+				Domain:         &nonexistentDomain,
+				Name:           &newName,
 			},
 			mockFind: func(ctx context.Context, domain string) (*model.Starter, error) {
 				return nil, sharedDomain.ErrNotFound
@@ -261,8 +271,9 @@ func TestUpdateStarter(t *testing.T) {
 		{
 			name: "update error",
 			command: &startercommand.UpdateStarterCommand{
-				Domain: "testuser",
-				Name:   &newName,
+				OriginalDomain: "testuser",
+				Domain:         &testDomain,
+				Name:           &newName,
 			},
 			mockFind: func(ctx context.Context, domain string) (*model.Starter, error) {
 				return existingStarter, nil
@@ -287,6 +298,9 @@ func TestUpdateStarter(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
 			)
 
 			starter, err := service.UpdateStarter(context.Background(), tt.command)
@@ -358,6 +372,9 @@ func TestSoftDeleteStarter(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
 			)
 
 			err := service.SoftDeleteStarter(context.Background(), tt.domain)
@@ -397,6 +414,9 @@ func TestListStartersFromMySQL(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	query := &starterquery.ListStartersQuery{
@@ -425,6 +445,133 @@ func TestListStartersFromMySQL(t *testing.T) {
 	}
 }
 
+func TestListStartersFromMySQLCursor(t *testing.T) {
+	starters := make([]*model.Starter, 0, 3)
+	for i, domain := range []string{"user1", "user2", "user3"} {
+		starter, _ := model.NewStarter(domain, "User", domain+"@vng.com.vn", "0123456789", "", "Developer", nil, nil)
+		starter.ID = int64(i + 1)
+		starters = append(starters, starter)
+	}
+
+	limit := 2
+	var requestedLimit int
+
+	mockStarterRepo := &mocks.MockStarterRepository{
+		SearchByKeywordFunc: func(ctx context.Context, query *starterquery.ListStartersQuery) ([]*model.Starter, int64, error) {
+			// The repository fetches one extra starter to detect the next page
+			requestedLimit = query.Pagination.GetLimit() + 1
+			return starters[:min(requestedLimit, len(starters))], 0, nil
+		},
+	}
+
+	service := NewStarterApplicationService(mockStarterRepo, nil, nil, nil, nil, nil, nil, nil)
+
+	cursor, err := httputil.ParseCursor("", "domain", "asc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query := &starterquery.ListStartersQuery{
+		SortBy:    "domain",
+		SortOrder: "asc",
+		Pagination: httputil.ReqPagination{
+			Limit:  &limit,
+			Cursor: cursor,
+		},
+	}
+
+	result, err := service.ListStarters(context.Background(), query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Data) != 2 {
+		t.Fatalf("expected 2 starters, got %d", len(result.Data))
+	}
+	if result.Pagination.Prev != nil || result.Pagination.TotalItems != 0 {
+		t.Errorf("expected no prev link and no total in cursor mode, got %+v", result.Pagination)
+	}
+	if result.Pagination.Next == nil {
+		t.Fatal("expected a next cursor")
+	}
+
+	next, err := httputil.ParseCursor(*result.Pagination.Next, "domain", "asc")
+	if err != nil {
+		t.Fatalf("next cursor does not parse: %v", err)
+	}
+	if next.Value != "user2" || next.ID != "2" {
+		t.Errorf("expected next cursor after user2 (id 2), got %+v", next)
+	}
+
+	if _, err := httputil.ParseCursor(*result.Pagination.Next, "created_at", "asc"); err == nil {
+		t.Error("expected cursor to be rejected for a different sort")
+	}
+}
+
+func TestBatchGetStarters(t *testing.T) {
+	starter1, _ := model.NewStarter("user1", "User One", "user1@vng.com.vn", "0123456789", "", "Developer", nil, nil)
+	starter1.ID = 1
+	starter2, _ := model.NewStarter("user2", "User Two", "user2@vng.com.vn", "0987654321", "", "Developer", nil, nil)
+	starter2.ID = 2
+
+	mockStarterRepo := &mocks.MockStarterRepository{
+		FindByDomainsFunc: func(ctx context.Context, domains []string) ([]*model.Starter, error) {
+			// MySQL returns matches in its own order
+			return []*model.Starter{starter2, starter1}, nil
+		},
+		FindByIDsFunc: func(ctx context.Context, ids []int64) ([]*model.Starter, error) {
+			return []*model.Starter{starter2}, nil
+		},
+	}
+
+	service := NewStarterApplicationService(mockStarterRepo, nil, nil, nil, nil, nil, nil, nil)
+
+	t.Run("by domains", func(t *testing.T) {
+		result, err := service.BatchGetStarters(context.Background(), &starterquery.BatchGetStartersQuery{
+			Domains: []string{"USER1", "ghost", "user2", "user1"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(result.Starters) != 2 || result.Starters[0].ID != 1 || result.Starters[1].ID != 2 {
+			t.Errorf("expected starters 1 and 2 in request order, got %v", result.Starters)
+		}
+		if len(result.NotFoundDomains) != 1 || result.NotFoundDomains[0] != "ghost" {
+			t.Errorf("expected ghost to be not found, got %v", result.NotFoundDomains)
+		}
+	})
+
+	t.Run("by ids", func(t *testing.T) {
+		result, err := service.BatchGetStarters(context.Background(), &starterquery.BatchGetStartersQuery{
+			IDs: []int64{2, 9, 2},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(result.Starters) != 1 || result.Starters[0].ID != 2 {
+			t.Errorf("expected only starter 2, got %v", result.Starters)
+		}
+		if len(result.NotFoundIDs) != 1 || result.NotFoundIDs[0] != 9 {
+			t.Errorf("expected id 9 to be not found, got %v", result.NotFoundIDs)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		failing := &mocks.MockStarterRepository{
+			FindByDomainsFunc: func(ctx context.Context, domains []string) ([]*model.Starter, error) {
+				return nil, errors.New("database error")
+			},
+		}
+		_, err := NewStarterApplicationService(failing, nil, nil, nil, nil, nil, nil, nil).
+			BatchGetStarters(context.Background(), &starterquery.BatchGetStartersQuery{Domains: []string{"user1"}})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
 func TestApplyUpdates(t *testing.T) {
 	existingStarter, _ := model.Rehydrate(
 		1,
@@ -446,12 +593,16 @@ func TestApplyUpdates(t *testing.T) {
 	newEmail := "updated@vng.com.vn"
 
 	command := &startercommand.UpdateStarterCommand{
-		Domain: "testuser",
-		Name:   &newName,
-		Email:  &newEmail,
+		OriginalDomain: "testuser",
+		Name:           &newName,
+		Email:          &newEmail,
 	}
 
-	name, email, mobile, workPhone, jobTitle, deptID, lineManagerID := service.applyUpdates(existingStarter, command)
+	domain, name, email, mobile, workPhone, jobTitle, deptID, lineManagerID := service.applyUpdates(existingStarter, command)
+
+	if domain != existingStarter.Domain {
+		t.Errorf("expected domain %s, got %s", existingStarter.Domain, domain)
+	}
 
 	if name != newName {
 		t.Errorf("expected name %s, got %s", newName, name)
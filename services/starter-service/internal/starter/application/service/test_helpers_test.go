@@ -0,0 +1,58 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	domainmessaging "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/messaging"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+	repo "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository"
+	domainService "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/service"
+)
+
+// testServiceDeps are what a StarterApplicationService under test is built with. They are
+// interfaces so that the ones left out stay nil, which the service checks for.
+type testServiceDeps struct {
+	starterRepo    repo.StarterRepository
+	searchRepo     repo.StarterSearchRepository
+	domainService  *domainService.StarterDomainService
+	enrichment     *domainService.StarterEnrichmentService
+	searchService  *domainService.StarterSearchService
+	deadLetterRepo repo.SearchDeadLetterRepository
+	departmentRepo repo.DepartmentRepository
+	publisher      domainmessaging.NotificationProducer
+}
+
+func newTestService(deps testServiceDeps) *StarterApplicationService {
+	return NewStarterApplicationService(
+		deps.starterRepo,
+		deps.searchRepo,
+		deps.domainService,
+		deps.enrichment,
+		deps.searchService,
+		deps.deadLetterRepo,
+		deps.departmentRepo,
+		deps.publisher,
+	)
+}
+
+// newTestStarters returns the developers user1 ... userN, with IDs 1 ... N.
+func newTestStarters(t *testing.T, count int) []*model.Starter {
+	t.Helper()
+	starters := make([]*model.Starter, 0, count)
+	for i := 1; i <= count; i++ {
+		domain := fmt.Sprintf("user%d", i)
+		starter, err := model.Rehydrate(int64(i), domain, fmt.Sprintf("User %d", i), domain+"@vng.com.vn",
+			"0123456789", "", "Developer", nil, nil, time.Now(), time.Now())
+		if err != nil {
+			t.Fatalf("failed to build starter: %v", err)
+		}
+		starters = append(starters, starter)
+	}
+	return starters
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
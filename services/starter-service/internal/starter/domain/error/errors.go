@@ -10,4 +10,8 @@ var (
 	ErrEmailInvalidDomain  = errors.New("email must end with @vng.com.vn")
 
 	ErrInvalidInput = errors.New("invalid input")
+
+	ErrReindexInProgress = errors.New("a reindex job is already running")
+
+	ErrBulkTargetTooLarge = errors.New("bulk change matches too many starters")
 )
@@ -12,6 +12,20 @@ type LineManagerNested struct {
 	Name     string
 	Email    string
 	JobTitle string
+	// DepartmentID and LineManagerID place the person, so that callers' access to their
+	// contact details can be decided as for any starter
+	DepartmentID  *int64
+	LineManagerID *int64
+}
+
+// AsStarter returns the person as far as their starter record is known.
+func (m *LineManagerNested) AsStarter() *Starter {
+	return &Starter{
+		ID:            m.ID,
+		Domain:        m.Domain,
+		DepartmentID:  m.DepartmentID,
+		LineManagerID: m.LineManagerID,
+	}
 }
 
 type GroupDepartmentNested struct {
@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+type ReindexJobStatus string
+
+const (
+	ReindexJobPending   ReindexJobStatus = "pending"
+	ReindexJobRunning   ReindexJobStatus = "running"
+	ReindexJobCompleted ReindexJobStatus = "completed"
+	ReindexJobFailed    ReindexJobStatus = "failed"
+	ReindexJobCancelled ReindexJobStatus = "cancelled"
+)
+
+const (
+	ReindexTriggerAPI     = "api"
+	ReindexTriggerStartup = "startup"
+)
+
+// ReindexJob tracks one background rebuild of the search index from MySQL.
+type ReindexJob struct {
+	ID      string
+	Status  ReindexJobStatus
+	Trigger string
+
+	Total   int64
+	Indexed int64
+	Failed  int64
+	// Errors holds the most recent batch errors and FailedIDs the first rejected starters,
+	// both capped to keep the job small; the full list is in the dead-letter table.
+	Errors    []string
+	FailedIDs []int64
+
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+func (j *ReindexJob) IsFinished() bool {
+	switch j.Status {
+	case ReindexJobCompleted, ReindexJobFailed, ReindexJobCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Throughput returns processed documents per second, measured up to now for a running job.
+func (j *ReindexJob) Throughput(now time.Time) float64 {
+	if j.StartedAt == nil {
+		return 0
+	}
+	end := now
+	if j.FinishedAt != nil {
+		end = *j.FinishedAt
+	}
+	elapsed := end.Sub(*j.StartedAt).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(j.Indexed+j.Failed) / elapsed
+}
@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// StarterSyncState is the slice of a MySQL starter row needed to check it against the search index.
+// Soft-deleted rows are included so that their leftover documents can be detected.
+type StarterSyncState struct {
+	ID        int64
+	Domain    string
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+}
+
+func (s *StarterSyncState) IsDeleted() bool { return s.DeletedAt != nil }
+
+// StarterIndexState is the slice of an indexed starter document needed for the same check.
+// UpdatedAt is nil for documents indexed before the field was stored.
+type StarterIndexState struct {
+	ID        int64
+	Domain    string
+	UpdatedAt *time.Time
+}
+
+// SearchConsistencyReport summarizes one comparison between MySQL and the search index.
+type SearchConsistencyReport struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+
+	DatabaseCount int64
+	IndexCount    int64
+
+	// Missing: live rows without a document. Stale: documents older than their row.
+	// Orphaned: documents whose row is soft-deleted or gone.
+	Missing  int64
+	Stale    int64
+	Orphaned int64
+
+	RepairsEmitted int64
+	RepairsFailed  int64
+
+	// Sample IDs for each kind of drift, capped to keep the report small.
+	MissingIDs  []int64
+	StaleIDs    []int64
+	OrphanedIDs []int64
+
+	Error string
+}
+
+func (r *SearchConsistencyReport) DriftCount() int64 {
+	return r.Missing + r.Stale + r.Orphaned
+}
+
+func (r *SearchConsistencyReport) IsConsistent() bool {
+	return r.Error == "" && r.DriftCount() == 0
+}
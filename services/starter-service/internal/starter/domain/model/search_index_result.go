@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// BulkIndexFailure describes a document Elasticsearch did not accept.
+type BulkIndexFailure struct {
+	StarterID  int64
+	StatusCode int
+	ErrorType  string
+	Reason     string
+	Attempts   int
+}
+
+// BulkIndexResult is the per-item outcome of a bulk request once retries are exhausted.
+type BulkIndexResult struct {
+	Indexed  int
+	Failures []BulkIndexFailure
+}
+
+func (r *BulkIndexResult) FailedIDs() []int64 {
+	ids := make([]int64, 0, len(r.Failures))
+	for _, failure := range r.Failures {
+		ids = append(ids, failure.StarterID)
+	}
+	return ids
+}
+
+// SearchDeadLetter records a starter that could not be indexed, for later inspection or replay.
+type SearchDeadLetter struct {
+	ID         int64
+	StarterID  int64
+	StatusCode int
+	ErrorType  string
+	Reason     string
+	Attempts   int
+	CreatedAt  time.Time
+}
+
+func NewSearchDeadLetter(failure BulkIndexFailure) *SearchDeadLetter {
+	return &SearchDeadLetter{
+		StarterID:  failure.StarterID,
+		StatusCode: failure.StatusCode,
+		ErrorType:  failure.ErrorType,
+		Reason:     failure.Reason,
+		Attempts:   failure.Attempts,
+	}
+}
+
+// ReindexReport summarizes a full reindex; FailedIDs lists every starter left out of the index.
+type ReindexReport struct {
+	Total     int64
+	Indexed   int64
+	FailedIDs []int64
+}
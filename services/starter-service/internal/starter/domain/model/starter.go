@@ -2,19 +2,12 @@ package model
 
 import (
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/valueobject"
 )
 
-type StarterListFilter struct {
-	DepartmentID   *int64
-	BusinessUnitID *int64
-	LineManagerID  *int64
-	SortBy         string
-	SortOrder      string
-}
-
 type Starter struct {
 	ID            int64
 	Domain        string
@@ -99,6 +92,33 @@ func Rehydrate(
 // Email returns the email value as a string
 func (s *Starter) GetEmail() string { return s.Email.Value() }
 
+// CursorKey returns the starter's value for a list sort field and its id, in the form
+// kept in pagination cursors.
+func (s *Starter) CursorKey(sortBy string) (value, id string) {
+	id = strconv.FormatInt(s.ID, 10)
+	switch sortBy {
+	case "domain":
+		return s.Domain, id
+	case "created_at":
+		return s.CreatedAt.Format(time.RFC3339Nano), id
+	default:
+		return id, id
+	}
+}
+
+// StarterSearchHit is a starter matched in the search index, with the fields its cursor key
+// is built from, so that a listing can continue after a hit whose row no longer exists.
+type StarterSearchHit struct {
+	ID        int64
+	Domain    string
+	CreatedAt time.Time
+}
+
+// CursorKey returns the key Starter.CursorKey returns for the starter of the hit.
+func (h *StarterSearchHit) CursorKey(sortBy string) (value, id string) {
+	return (&Starter{ID: h.ID, Domain: h.Domain, CreatedAt: h.CreatedAt}).CursorKey(sortBy)
+}
+
 func (s *Starter) UpdateInfo(domain, name, email, mobile, workPhone, jobTitle string, departmentID, lineManagerID *int64) error {
 	emailVO, err := valueobject.NewEmail(email)
 	if err != nil {
@@ -119,25 +139,48 @@ func (s *Starter) UpdateInfo(domain, name, email, mobile, workPhone, jobTitle st
 }
 
 type StarterESDoc struct {
-	id       int64
-	domain   string
-	name     string
-	deptName string
-	buName   string
+	id        int64
+	domain    string
+	name      string
+	email     string
+	mobile    string
+	workPhone string
+	jobTitle  string
+	deptName  string
+	buName    string
+
+	departmentID   *int64
+	businessUnitID *int64
+	lineManagerID  *int64
+
+	createdAt time.Time
+	updatedAt time.Time
 }
 
 func (s *StarterESDoc) ID() int64                { return s.id }
 func (s *StarterESDoc) Domain() string           { return s.domain }
 func (s *StarterESDoc) Name() string             { return s.name }
+func (s *StarterESDoc) Email() string            { return s.email }
+func (s *StarterESDoc) Mobile() string           { return s.mobile }
+func (s *StarterESDoc) WorkPhone() string        { return s.workPhone }
+func (s *StarterESDoc) JobTitle() string         { return s.jobTitle }
 func (s *StarterESDoc) DepartmentName() string   { return s.deptName }
 func (s *StarterESDoc) BusinessUnitName() string { return s.buName }
+func (s *StarterESDoc) DepartmentID() *int64     { return s.departmentID }
+func (s *StarterESDoc) BusinessUnitID() *int64   { return s.businessUnitID }
+func (s *StarterESDoc) LineManagerID() *int64    { return s.lineManagerID }
+func (s *StarterESDoc) CreatedAt() time.Time     { return s.createdAt }
+func (s *StarterESDoc) UpdatedAt() time.Time     { return s.updatedAt }
 
 func NewStarterESDocFromStarter(starter *Starter, enriched *EnrichedData) *StarterESDoc {
 	if starter == nil {
 		return nil
 	}
 
-	var deptName, buName string
+	var (
+		deptName, buName string
+		buID             *int64
+	)
 
 	if enriched != nil {
 		if depIDPtr := starter.DepartmentID; depIDPtr != nil {
@@ -149,16 +192,28 @@ func NewStarterESDocFromStarter(starter *Starter, enriched *EnrichedData) *Start
 
 				if bu, ok := enriched.BusinessUnits[dep.ID]; ok && bu != nil {
 					buName = bu.Name
+					buID = &bu.ID
 				}
 			}
 		}
 	}
 
 	return &StarterESDoc{
-		id:       starter.ID,
-		domain:   starter.Domain,
-		name:     starter.Name,
-		deptName: deptName,
-		buName:   buName,
+		id:        starter.ID,
+		domain:    starter.Domain,
+		name:      starter.Name,
+		email:     starter.GetEmail(),
+		mobile:    starter.Mobile,
+		workPhone: starter.WorkPhone,
+		jobTitle:  starter.JobTitle,
+		deptName:  deptName,
+		buName:    buName,
+
+		departmentID:   starter.DepartmentID,
+		businessUnitID: buID,
+		lineManagerID:  starter.LineManagerID,
+
+		createdAt: starter.CreatedAt,
+		updatedAt: starter.UpdatedAt,
 	}
 }
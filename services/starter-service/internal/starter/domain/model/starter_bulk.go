@@ -0,0 +1,30 @@
+package model
+
+// BulkChangeMaxItems caps how many starters one bulk update or delete may touch.
+const BulkChangeMaxItems = 500
+
+type BulkItemStatus string
+
+const (
+	BulkItemUpdated  BulkItemStatus = "updated"
+	BulkItemDeleted  BulkItemStatus = "deleted"
+	BulkItemNotFound BulkItemStatus = "not_found"
+	BulkItemInvalid  BulkItemStatus = "invalid"
+	// BulkItemSkipped marks a valid item left unchanged because another item was invalid.
+	BulkItemSkipped BulkItemStatus = "skipped"
+)
+
+// BulkItemResult is the outcome of a bulk change for one starter.
+type BulkItemResult struct {
+	ID     int64
+	Domain string
+	Status BulkItemStatus
+	Error  string
+}
+
+// BulkChangeResult lists the outcome per starter. Changes are all or nothing: when any
+// item is invalid, Applied is false and nothing was written.
+type BulkChangeResult struct {
+	Items   []BulkItemResult
+	Applied bool
+}
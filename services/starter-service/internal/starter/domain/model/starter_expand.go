@@ -0,0 +1,59 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	RelationDepartment   = "department"
+	RelationLineManager  = "line_manager"
+	RelationBusinessUnit = "business_unit"
+)
+
+// StarterExpand selects which related records are loaded alongside starters.
+type StarterExpand struct {
+	Department   bool
+	LineManager  bool
+	BusinessUnit bool
+}
+
+// ExpandAll loads every relation; it is what callers get when they do not choose.
+func ExpandAll() StarterExpand {
+	return StarterExpand{Department: true, LineManager: true, BusinessUnit: true}
+}
+
+func (e StarterExpand) IsEmpty() bool {
+	return !e.Department && !e.LineManager && !e.BusinessUnit
+}
+
+// Set turns on the named relation and reports whether the name is a known relation.
+func (e *StarterExpand) Set(relation string) bool {
+	switch relation {
+	case RelationDepartment:
+		e.Department = true
+	case RelationLineManager:
+		e.LineManager = true
+	case RelationBusinessUnit:
+		e.BusinessUnit = true
+	default:
+		return false
+	}
+	return true
+}
+
+// ParseStarterExpand parses a comma separated relation list such as "department,line_manager".
+func ParseStarterExpand(raw string) (StarterExpand, error) {
+	var expand StarterExpand
+	for _, relation := range strings.Split(raw, ",") {
+		relation = strings.ToLower(strings.TrimSpace(relation))
+		if relation == "" {
+			continue
+		}
+		if !expand.Set(relation) {
+			return StarterExpand{}, fmt.Errorf("unknown relation %q, expected one of %s, %s, %s",
+				relation, RelationDepartment, RelationLineManager, RelationBusinessUnit)
+		}
+	}
+	return expand, nil
+}
@@ -0,0 +1,22 @@
+package model
+
+import "testing"
+
+func TestParseStarterExpand(t *testing.T) {
+	expand, err := ParseStarterExpand("department, LINE_MANAGER,,department")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !expand.Department || !expand.LineManager || expand.BusinessUnit {
+		t.Errorf("expected department and line_manager, got %+v", expand)
+	}
+
+	empty, err := ParseStarterExpand("")
+	if err != nil || !empty.IsEmpty() {
+		t.Errorf("expected empty expand without error, got %+v, %v", empty, err)
+	}
+
+	if _, err := ParseStarterExpand("department,salary"); err == nil {
+		t.Error("expected error for unknown relation")
+	}
+}
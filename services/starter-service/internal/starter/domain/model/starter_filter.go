@@ -0,0 +1,214 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter expressions look like "department_id:in:3,4;created_at:gte:2026-01-01;job_title:eq:Engineer":
+// conditions are separated by ";", each one is field:operator:value and "in" takes a comma list.
+const (
+	filterConditionSeparator = ";"
+	filterPartSeparator      = ":"
+	filterValueSeparator     = ","
+
+	filterMaxConditions = 10
+	filterMaxValues     = 100
+)
+
+type FilterOperator string
+
+const (
+	FilterEq  FilterOperator = "eq"
+	FilterNe  FilterOperator = "ne"
+	FilterIn  FilterOperator = "in"
+	FilterGt  FilterOperator = "gt"
+	FilterGte FilterOperator = "gte"
+	FilterLt  FilterOperator = "lt"
+	FilterLte FilterOperator = "lte"
+)
+
+type FilterValueType int
+
+const (
+	FilterInt FilterValueType = iota
+	FilterString
+	FilterTime
+)
+
+// FilterField describes a field that can be filtered on and the operators it accepts.
+type FilterField struct {
+	Name      string
+	Type      FilterValueType
+	Nullable  bool
+	Operators []FilterOperator
+}
+
+var (
+	filterEqualityOperators = []FilterOperator{FilterEq, FilterNe, FilterIn}
+	filterRangeOperators    = []FilterOperator{FilterGt, FilterGte, FilterLt, FilterLte}
+	filterAllOperators      = append(append([]FilterOperator{}, filterEqualityOperators...), filterRangeOperators...)
+)
+
+// starterFilterFields lists the fields of GET /starters that can be filtered on.
+var starterFilterFields = map[string]FilterField{
+	"id":               {Name: "id", Type: FilterInt, Operators: filterAllOperators},
+	"department_id":    {Name: "department_id", Type: FilterInt, Nullable: true, Operators: filterEqualityOperators},
+	"business_unit_id": {Name: "business_unit_id", Type: FilterInt, Nullable: true, Operators: filterEqualityOperators},
+	"line_manager_id":  {Name: "line_manager_id", Type: FilterInt, Nullable: true, Operators: filterEqualityOperators},
+	"domain":           {Name: "domain", Type: FilterString, Operators: filterEqualityOperators},
+	"email":            {Name: "email", Type: FilterString, Operators: filterEqualityOperators},
+	"job_title":        {Name: "job_title", Type: FilterString, Operators: filterEqualityOperators},
+	"created_at":       {Name: "created_at", Type: FilterTime, Operators: filterRangeOperators},
+	"updated_at":       {Name: "updated_at", Type: FilterTime, Operators: filterRangeOperators},
+}
+
+// FilterCondition is one parsed condition. Values hold int64, string or time.Time
+// according to Field.Type; every operator except "in" has exactly one value.
+type FilterCondition struct {
+	Field    FilterField
+	Operator FilterOperator
+	Values   []any
+}
+
+// StarterListFilter is the parsed form of the filter query parameter; all conditions must match.
+type StarterListFilter struct {
+	Conditions []FilterCondition
+}
+
+func (f *StarterListFilter) IsEmpty() bool {
+	return f == nil || len(f.Conditions) == 0
+}
+
+// Uses reports whether any condition of the filter is on the named field.
+func (f *StarterListFilter) Uses(field string) bool {
+	if f == nil {
+		return false
+	}
+	for _, condition := range f.Conditions {
+		if condition.Field.Name == field {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterError reports an invalid filter expression together with the offending condition.
+type FilterError struct {
+	Condition string
+	Message   string
+}
+
+func (e *FilterError) Error() string {
+	if e.Condition == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Condition, e.Message)
+}
+
+// ParseStarterListFilter parses a filter expression; an empty expression yields an empty filter.
+func ParseStarterListFilter(raw string) (*StarterListFilter, error) {
+	filter := &StarterListFilter{}
+	if strings.TrimSpace(raw) == "" {
+		return filter, nil
+	}
+
+	for _, expr := range strings.Split(raw, filterConditionSeparator) {
+		expr = strings.TrimSpace(expr)
+		if expr == "" {
+			continue
+		}
+		if len(filter.Conditions) == filterMaxConditions {
+			return nil, &FilterError{Message: fmt.Sprintf("at most %d conditions are allowed", filterMaxConditions)}
+		}
+
+		condition, err := parseFilterCondition(expr)
+		if err != nil {
+			return nil, err
+		}
+		filter.Conditions = append(filter.Conditions, condition)
+	}
+
+	return filter, nil
+}
+
+func parseFilterCondition(expr string) (FilterCondition, error) {
+	// Values may contain ":" themselves (RFC 3339 timestamps), so split at most twice
+	parts := strings.SplitN(expr, filterPartSeparator, 3)
+	if len(parts) != 3 {
+		return FilterCondition{}, &FilterError{Condition: expr, Message: "expected field:operator:value"}
+	}
+
+	name := strings.ToLower(strings.TrimSpace(parts[0]))
+	field, ok := starterFilterFields[name]
+	if !ok {
+		return FilterCondition{}, &FilterError{Condition: expr, Message: fmt.Sprintf("unknown field %q", name)}
+	}
+
+	op := FilterOperator(strings.ToLower(strings.TrimSpace(parts[1])))
+	if !field.supports(op) {
+		return FilterCondition{}, &FilterError{
+			Condition: expr,
+			Message:   fmt.Sprintf("operator %q is not supported for %s", op, field.Name),
+		}
+	}
+
+	rawValues := []string{parts[2]}
+	if op == FilterIn {
+		rawValues = strings.Split(parts[2], filterValueSeparator)
+		if len(rawValues) > filterMaxValues {
+			return FilterCondition{}, &FilterError{
+				Condition: expr,
+				Message:   fmt.Sprintf("at most %d values are allowed", filterMaxValues),
+			}
+		}
+	}
+
+	values := make([]any, 0, len(rawValues))
+	for _, rawValue := range rawValues {
+		value, err := field.parseValue(strings.TrimSpace(rawValue))
+		if err != nil {
+			return FilterCondition{}, &FilterError{Condition: expr, Message: err.Error()}
+		}
+		values = append(values, value)
+	}
+
+	return FilterCondition{Field: field, Operator: op, Values: values}, nil
+}
+
+func (f FilterField) supports(op FilterOperator) bool {
+	for _, supported := range f.Operators {
+		if supported == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (f FilterField) parseValue(raw string) (any, error) {
+	if raw == "" {
+		return nil, fmt.Errorf("empty value for %s", f.Name)
+	}
+
+	switch f.Type {
+	case FilterInt:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be an integer", f.Name)
+		}
+		return value, nil
+	case FilterTime:
+		if value, err := time.Parse(time.RFC3339, raw); err == nil {
+			return value, nil
+		}
+		value, err := time.ParseInLocation(time.DateOnly, raw, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a date (YYYY-MM-DD) or an RFC 3339 timestamp", f.Name)
+		}
+		return value, nil
+	default:
+		return raw, nil
+	}
+}
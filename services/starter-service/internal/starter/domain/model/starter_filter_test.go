@@ -0,0 +1,107 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseStarterListFilter(t *testing.T) {
+	filter, err := ParseStarterListFilter("department_id:in:3, 4;created_at:gte:2026-01-01;job_title:eq:Engineer;updated_at:lt:2026-02-01T10:30:00Z")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(filter.Conditions) != 4 {
+		t.Fatalf("expected 4 conditions, got %d", len(filter.Conditions))
+	}
+
+	in := filter.Conditions[0]
+	if in.Field.Name != "department_id" || in.Operator != FilterIn || len(in.Values) != 2 {
+		t.Errorf("unexpected in condition: %+v", in)
+	} else if in.Values[0] != int64(3) || in.Values[1] != int64(4) {
+		t.Errorf("expected int64 values [3 4], got %v", in.Values)
+	}
+
+	gte := filter.Conditions[1]
+	expectedDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+	if gte.Operator != FilterGte || !gte.Values[0].(time.Time).Equal(expectedDate) {
+		t.Errorf("unexpected gte condition: %+v", gte)
+	}
+
+	eq := filter.Conditions[2]
+	if eq.Field.Type != FilterString || eq.Values[0] != "Engineer" {
+		t.Errorf("unexpected eq condition: %+v", eq)
+	}
+
+	lt := filter.Conditions[3]
+	expectedTime := time.Date(2026, 2, 1, 10, 30, 0, 0, time.UTC)
+	if !lt.Values[0].(time.Time).Equal(expectedTime) {
+		t.Errorf("expected RFC 3339 timestamp to keep its colons, got %v", lt.Values[0])
+	}
+}
+
+func TestParseStarterListFilterEmpty(t *testing.T) {
+	for _, raw := range []string{"", "  ", ";;"} {
+		filter, err := ParseStarterListFilter(raw)
+		if err != nil {
+			t.Errorf("ParseStarterListFilter(%q) unexpected error: %v", raw, err)
+			continue
+		}
+		if !filter.IsEmpty() {
+			t.Errorf("ParseStarterListFilter(%q) expected empty filter, got %+v", raw, filter)
+		}
+	}
+}
+
+func TestStarterListFilterUses(t *testing.T) {
+	filter, err := ParseStarterListFilter("job_title:eq:Engineer;email:in:a@vng.com.vn,b@vng.com.vn")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !filter.Uses("email") {
+		t.Error("expected the filter to use email")
+	}
+	if filter.Uses("domain") {
+		t.Error("expected the filter not to use domain")
+	}
+
+	var none *StarterListFilter
+	if none.Uses("email") {
+		t.Error("expected a nil filter not to use any field")
+	}
+}
+
+func TestParseStarterListFilterErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		message string
+	}{
+		{"missing parts", "department_id:eq", "expected field:operator:value"},
+		{"unknown field", "salary:gt:100", "unknown field"},
+		{"unsupported operator", "created_at:in:2026-01-01", "not supported"},
+		{"range on id field", "department_id:gt:3", "not supported"},
+		{"invalid integer", "department_id:eq:abc", "must be an integer"},
+		{"invalid date", "created_at:gte:01/01/2026", "must be a date"},
+		{"empty value", "job_title:eq:", "empty value"},
+		{"empty in value", "department_id:in:1,,2", "empty value"},
+		{"too many conditions", strings.Repeat("id:gt:1;", filterMaxConditions+1), "at most"},
+		{"too many values", "id:in:" + strings.Repeat("1,", filterMaxValues) + "1", "at most"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseStarterListFilter(tt.raw)
+			var filterErr *FilterError
+			if !errors.As(err, &filterErr) {
+				t.Fatalf("expected FilterError, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("expected error containing %q, got %q", tt.message, err.Error())
+			}
+		})
+	}
+}
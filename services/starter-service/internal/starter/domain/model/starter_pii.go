@@ -0,0 +1,80 @@
+package model
+
+import "time"
+
+// PII fields of a starter: contact details that are masked for most callers.
+const (
+	PIIFieldEmail     = "email"
+	PIIFieldMobile    = "mobile"
+	PIIFieldWorkPhone = "work_phone"
+)
+
+// PIIAccess is how much of a starter's contact details a caller may see.
+type PIIAccess int
+
+const (
+	// PIIHidden omits the contact details.
+	PIIHidden PIIAccess = iota
+	// PIIMasked shows enough of the contact details to recognise them, e.g. a***@vng.com.vn.
+	PIIMasked
+	// PIIFull shows the contact details as stored; such reads are recorded in the access log.
+	PIIFull
+)
+
+// PIIAccessReason explains why a caller was shown contact details unmasked.
+type PIIAccessReason string
+
+const (
+	PIIReasonRole        PIIAccessReason = "role"
+	PIIReasonSelf        PIIAccessReason = "self"
+	PIIReasonLineManager PIIAccessReason = "line_manager"
+)
+
+// PIIViewer is the caller starter responses are built for.
+type PIIViewer struct {
+	// Domain is the caller's starter domain, as authenticated
+	Domain string
+	// Starter is the caller's own starter record, nil when they have none
+	Starter *Starter
+	// Privileged callers, such as HR admins, see every starter's contact details
+	Privileged bool
+}
+
+// AccessTo decides how much of starter's contact details the viewer may see: in full for
+// privileged callers, the starter themselves and their line manager, masked for colleagues in
+// the same department, and not at all for anyone else.
+func (v *PIIViewer) AccessTo(starter *Starter) (PIIAccess, PIIAccessReason) {
+	if v == nil || starter == nil {
+		return PIIHidden, ""
+	}
+	if v.Privileged {
+		return PIIFull, PIIReasonRole
+	}
+
+	self := v.Starter
+	if self == nil {
+		return PIIHidden, ""
+	}
+	if self.ID == starter.ID {
+		return PIIFull, PIIReasonSelf
+	}
+	if starter.LineManagerID != nil && *starter.LineManagerID == self.ID {
+		return PIIFull, PIIReasonLineManager
+	}
+	if starter.DepartmentID != nil && self.DepartmentID != nil && *starter.DepartmentID == *self.DepartmentID {
+		return PIIMasked, ""
+	}
+	return PIIHidden, ""
+}
+
+// PIIAccessLog records that a caller was shown a starter's contact details unmasked.
+type PIIAccessLog struct {
+	ID            int64
+	ViewerDomain  string
+	StarterID     int64
+	StarterDomain string
+	Fields        []string
+	Reason        PIIAccessReason
+	Route         string
+	AccessedAt    time.Time
+}
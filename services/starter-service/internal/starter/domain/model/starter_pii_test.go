@@ -0,0 +1,39 @@
+package model
+
+import "testing"
+
+func TestPIIViewerAccessTo(t *testing.T) {
+	engineering, sales := int64(1), int64(2)
+	managerID := int64(10)
+
+	viewer := &PIIViewer{
+		Domain:  "manager",
+		Starter: &Starter{ID: managerID, Domain: "manager", DepartmentID: &engineering},
+	}
+
+	cases := []struct {
+		name       string
+		viewer     *PIIViewer
+		starter    *Starter
+		wantAccess PIIAccess
+		wantReason PIIAccessReason
+	}{
+		{"privileged", &PIIViewer{Domain: "hr", Privileged: true}, &Starter{ID: 1}, PIIFull, PIIReasonRole},
+		{"self", viewer, &Starter{ID: managerID, DepartmentID: &engineering}, PIIFull, PIIReasonSelf},
+		{"line manager", viewer, &Starter{ID: 2, DepartmentID: &sales, LineManagerID: &managerID}, PIIFull, PIIReasonLineManager},
+		{"same department", viewer, &Starter{ID: 3, DepartmentID: &engineering}, PIIMasked, ""},
+		{"other department", viewer, &Starter{ID: 4, DepartmentID: &sales}, PIIHidden, ""},
+		{"no department", viewer, &Starter{ID: 5}, PIIHidden, ""},
+		{"viewer without starter", &PIIViewer{Domain: "outsider"}, &Starter{ID: 6, DepartmentID: &engineering}, PIIHidden, ""},
+		{"no viewer", nil, &Starter{ID: 7}, PIIHidden, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			access, reason := tc.viewer.AccessTo(tc.starter)
+			if access != tc.wantAccess || reason != tc.wantReason {
+				t.Errorf("AccessTo() = %v, %q, want %v, %q", access, reason, tc.wantAccess, tc.wantReason)
+			}
+		})
+	}
+}
@@ -246,6 +246,7 @@ func TestStarterUpdateInfo(t *testing.T) {
 	newLineManagerID := int64(4)
 
 	err = starter.UpdateInfo(
+		"testdomain",
 		newName,
 		newEmail,
 		newMobile,
@@ -294,6 +295,7 @@ func TestStarterUpdateInfoInvalidEmail(t *testing.T) {
 	)
 
 	err := starter.UpdateInfo(
+		starter.Domain,
 		"Updated User",
 		"invalid@gmail.com",
 		"0123456789",
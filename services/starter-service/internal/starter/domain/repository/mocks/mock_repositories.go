@@ -16,7 +16,14 @@ type MockStarterRepository struct {
 	SoftDeleteFunc       func(ctx context.Context, domain string) (*model.Starter, error)
 	FindByDomainFunc     func(ctx context.Context, domain string) (*model.Starter, error)
 	FindByIDsFunc        func(ctx context.Context, ids []int64) ([]*model.Starter, error)
+	FindByDomainsFunc    func(ctx context.Context, domains []string) ([]*model.Starter, error)
 	SearchByKeywordFunc  func(ctx context.Context, query *starterquery.ListStartersQuery) ([]*model.Starter, int64, error)
+	FindByFilterFunc     func(ctx context.Context, filter *model.StarterListFilter, limit int) ([]*model.Starter, error)
+	UpdateAllFunc        func(ctx context.Context, starters []*model.Starter) error
+	SoftDeleteAllFunc    func(ctx context.Context, ids []int64) error
+	ListAfterIDFunc      func(ctx context.Context, afterID int64, limit int) ([]*model.Starter, error)
+	CountActiveFunc      func(ctx context.Context) (int64, error)
+	ListSyncStatesFunc   func(ctx context.Context, afterID int64, limit int) ([]*model.StarterSyncState, error)
 }
 
 func (m *MockStarterRepository) Create(ctx context.Context, starter *model.Starter) error {
@@ -47,6 +54,34 @@ func (m *MockStarterRepository) FindByDomain(ctx context.Context, domain string)
 	return nil, nil
 }
 
+func (m *MockStarterRepository) FindByFilter(ctx context.Context, filter *model.StarterListFilter, limit int) ([]*model.Starter, error) {
+	if m.FindByFilterFunc != nil {
+		return m.FindByFilterFunc(ctx, filter, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockStarterRepository) UpdateAll(ctx context.Context, starters []*model.Starter) error {
+	if m.UpdateAllFunc != nil {
+		return m.UpdateAllFunc(ctx, starters)
+	}
+	return nil
+}
+
+func (m *MockStarterRepository) SoftDeleteAll(ctx context.Context, ids []int64) error {
+	if m.SoftDeleteAllFunc != nil {
+		return m.SoftDeleteAllFunc(ctx, ids)
+	}
+	return nil
+}
+
+func (m *MockStarterRepository) FindByDomains(ctx context.Context, domains []string) ([]*model.Starter, error) {
+	if m.FindByDomainsFunc != nil {
+		return m.FindByDomainsFunc(ctx, domains)
+	}
+	return nil, nil
+}
+
 func (m *MockStarterRepository) FindByIDs(ctx context.Context, ids []int64) ([]*model.Starter, error) {
 	if m.FindByIDsFunc != nil {
 		return m.FindByIDsFunc(ctx, ids)
@@ -61,15 +96,38 @@ func (m *MockStarterRepository) SearchByKeyword(ctx context.Context, query *star
 	return nil, 0, nil
 }
 
+func (m *MockStarterRepository) ListAfterID(ctx context.Context, afterID int64, limit int) ([]*model.Starter, error) {
+	if m.ListAfterIDFunc != nil {
+		return m.ListAfterIDFunc(ctx, afterID, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockStarterRepository) CountActive(ctx context.Context) (int64, error) {
+	if m.CountActiveFunc != nil {
+		return m.CountActiveFunc(ctx)
+	}
+	return 0, nil
+}
+
+func (m *MockStarterRepository) ListSyncStates(ctx context.Context, afterID int64, limit int) ([]*model.StarterSyncState, error) {
+	if m.ListSyncStatesFunc != nil {
+		return m.ListSyncStatesFunc(ctx, afterID, limit)
+	}
+	return nil, nil
+}
+
 // MockStarterSearchRepository is a mock implementation of StarterSearchRepository
 type MockStarterSearchRepository struct {
-	SearchFunc           func(ctx context.Context, query *starterquery.ListStartersQuery, buildSearchQuery repository.SearchQueryBuilder) ([]int64, int64, error)
+	SearchFunc           func(ctx context.Context, query *starterquery.ListStartersQuery, buildSearchQuery repository.SearchQueryBuilder) ([]*model.StarterSearchHit, int64, error)
 	IndexStarterFunc     func(ctx context.Context, doc *model.StarterESDoc) error
-	BulkIndexFunc        func(ctx context.Context, docs []*model.StarterESDoc) error
+	BulkIndexFunc        func(ctx context.Context, docs []*model.StarterESDoc) (*model.BulkIndexResult, error)
 	DeleteFromIndexFunc  func(ctx context.Context, domain string) error
+	BulkDeleteFunc       func(ctx context.Context, ids []int64) error
+	ListIndexStatesFunc  func(ctx context.Context, afterID int64, limit int) ([]*model.StarterIndexState, error)
 }
 
-func (m *MockStarterSearchRepository) Search(ctx context.Context, query *starterquery.ListStartersQuery, buildSearchQuery repository.SearchQueryBuilder) ([]int64, int64, error) {
+func (m *MockStarterSearchRepository) Search(ctx context.Context, query *starterquery.ListStartersQuery, buildSearchQuery repository.SearchQueryBuilder) ([]*model.StarterSearchHit, int64, error) {
 	if m.SearchFunc != nil {
 		return m.SearchFunc(ctx, query, buildSearchQuery)
 	}
@@ -83,11 +141,11 @@ func (m *MockStarterSearchRepository) IndexStarter(ctx context.Context, doc *mod
 	return nil
 }
 
-func (m *MockStarterSearchRepository) BulkIndex(ctx context.Context, docs []*model.StarterESDoc) error {
+func (m *MockStarterSearchRepository) BulkIndex(ctx context.Context, docs []*model.StarterESDoc) (*model.BulkIndexResult, error) {
 	if m.BulkIndexFunc != nil {
 		return m.BulkIndexFunc(ctx, docs)
 	}
-	return nil
+	return &model.BulkIndexResult{Indexed: len(docs)}, nil
 }
 
 func (m *MockStarterSearchRepository) DeleteFromIndex(ctx context.Context, domain string) error {
@@ -97,6 +155,20 @@ func (m *MockStarterSearchRepository) DeleteFromIndex(ctx context.Context, domai
 	return nil
 }
 
+func (m *MockStarterSearchRepository) BulkDelete(ctx context.Context, ids []int64) error {
+	if m.BulkDeleteFunc != nil {
+		return m.BulkDeleteFunc(ctx, ids)
+	}
+	return nil
+}
+
+func (m *MockStarterSearchRepository) ListIndexStates(ctx context.Context, afterID int64, limit int) ([]*model.StarterIndexState, error) {
+	if m.ListIndexStatesFunc != nil {
+		return m.ListIndexStatesFunc(ctx, afterID, limit)
+	}
+	return nil, nil
+}
+
 // MockDepartmentRepository is a mock implementation of DepartmentRepository
 type MockDepartmentRepository struct {
 	CreateFunc                func(ctx context.Context, department *model.Department) error
@@ -193,3 +265,27 @@ func (m *MockBusinessUnitRepository) ListWithDetails(ctx context.Context, pagina
 	return nil, 0, nil
 }
 
+
+// MockSearchDeadLetterRepository is a mock implementation of SearchDeadLetterRepository
+type MockSearchDeadLetterRepository struct {
+	SaveAllFunc func(ctx context.Context, letters []*model.SearchDeadLetter) error
+}
+
+func (m *MockSearchDeadLetterRepository) SaveAll(ctx context.Context, letters []*model.SearchDeadLetter) error {
+	if m.SaveAllFunc != nil {
+		return m.SaveAllFunc(ctx, letters)
+	}
+	return nil
+}
+
+// MockPIIAccessLogRepository is a mock implementation of PIIAccessLogRepository
+type MockPIIAccessLogRepository struct {
+	SaveAllFunc func(ctx context.Context, logs []*model.PIIAccessLog) error
+}
+
+func (m *MockPIIAccessLogRepository) SaveAll(ctx context.Context, logs []*model.PIIAccessLog) error {
+	if m.SaveAllFunc != nil {
+		return m.SaveAllFunc(ctx, logs)
+	}
+	return nil
+}
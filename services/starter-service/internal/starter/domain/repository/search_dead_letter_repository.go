@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+)
+
+type SearchDeadLetterRepository interface {
+	SaveAll(ctx context.Context, letters []*model.SearchDeadLetter) error
+}
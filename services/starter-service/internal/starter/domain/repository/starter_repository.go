@@ -10,18 +10,38 @@ import (
 type StarterRepository interface {
 	FindByIDs(ctx context.Context, ids []int64) ([]*model.Starter, error)
 	FindByDomain(ctx context.Context, domain string) (*model.Starter, error)
+	// FindByDomains returns the live starters among domains, in no particular order.
+	FindByDomains(ctx context.Context, domains []string) ([]*model.Starter, error)
 	SearchByKeyword(ctx context.Context, listStarterQuery *starterquery.ListStartersQuery) ([]*model.Starter, int64, error)
+	// FindByFilter returns up to limit live starters matching filter, ordered by id.
+	FindByFilter(ctx context.Context, filter *model.StarterListFilter, limit int) ([]*model.Starter, error)
 	Create(ctx context.Context, starter *model.Starter) error
 	Update(ctx context.Context, starter *model.Starter) error
 	SoftDelete(ctx context.Context, domain string) (*model.Starter, error)
+	// UpdateAll saves every starter in a single transaction.
+	UpdateAll(ctx context.Context, starters []*model.Starter) error
+	// SoftDeleteAll soft deletes the starters with the given ids in a single transaction.
+	SoftDeleteAll(ctx context.Context, ids []int64) error
+	// ListAfterID returns up to limit live starters with id > afterID ordered by id.
+	ListAfterID(ctx context.Context, afterID int64, limit int) ([]*model.Starter, error)
+	CountActive(ctx context.Context) (int64, error)
+	// ListSyncStates returns up to limit rows with id > afterID ordered by id, soft-deleted rows included.
+	ListSyncStates(ctx context.Context, afterID int64, limit int) ([]*model.StarterSyncState, error)
 }
 
 // TODO:: remove type alias
 type SearchQueryBuilder func(*starterquery.ListStartersQuery) map[string]interface{}
 
 type StarterSearchRepository interface {
-	Search(ctx context.Context, listStarterQuery *starterquery.ListStartersQuery) ([]int64, int64, error)
+	// Search returns the hits of one page of the query and the total number of hits. In cursor
+	// mode the page holds one extra hit when there are more, and the total is not counted.
+	Search(ctx context.Context, listStarterQuery *starterquery.ListStartersQuery, buildSearchQuery SearchQueryBuilder) ([]*model.StarterSearchHit, int64, error)
 	IndexStarter(ctx context.Context, starter *model.StarterESDoc) error
 	DeleteFromIndex(ctx context.Context, domain string) error
-	BulkIndex(ctx context.Context, starters []*model.StarterESDoc) error
+	// BulkDelete removes the documents of the given starters in one request.
+	BulkDelete(ctx context.Context, ids []int64) error
+	// BulkIndex returns per-document failures in the result; an error means the request itself failed.
+	BulkIndex(ctx context.Context, starters []*model.StarterESDoc) (*model.BulkIndexResult, error)
+	// ListIndexStates returns up to limit documents with id > afterID ordered by id.
+	ListIndexStates(ctx context.Context, afterID int64, limit int) ([]*model.StarterIndexState, error)
 }
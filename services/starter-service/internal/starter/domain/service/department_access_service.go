@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	sharedDomain "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/error"
+	repo "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository"
+)
+
+// DepartmentAccessService decides what a department leader may edit: the departments they
+// lead, every department below them, and the starters in those departments.
+type DepartmentAccessService struct {
+	starterRepo    repo.StarterRepository
+	departmentRepo repo.DepartmentRepository
+}
+
+func NewDepartmentAccessService(
+	starterRepo repo.StarterRepository,
+	departmentRepo repo.DepartmentRepository,
+) *DepartmentAccessService {
+	return &DepartmentAccessService{
+		starterRepo:    starterRepo,
+		departmentRepo: departmentRepo,
+	}
+}
+
+// CanManageDepartment reports whether the starter with leaderDomain leads departmentID or one
+// of its ancestors. Unknown leaders and departments are not manageable.
+func (s *DepartmentAccessService) CanManageDepartment(ctx context.Context, leaderDomain string, departmentID int64) (bool, error) {
+	leaderID, err := s.findLeaderID(ctx, leaderDomain)
+	if err != nil || leaderID == 0 {
+		return false, err
+	}
+	return s.leadsSubtree(ctx, leaderID, departmentID)
+}
+
+// CanManageStarter reports whether starterDomain belongs to a department in the leader's
+// subtree. A missing starter is reported as ErrNotFound so callers can answer 404 as before.
+func (s *DepartmentAccessService) CanManageStarter(ctx context.Context, leaderDomain, starterDomain string) (bool, error) {
+	starter, err := s.starterRepo.FindByDomain(ctx, starterDomain)
+	if err != nil {
+		return false, err
+	}
+	if starter.DepartmentID == nil {
+		return false, nil
+	}
+	return s.CanManageDepartment(ctx, leaderDomain, *starter.DepartmentID)
+}
+
+func (s *DepartmentAccessService) findLeaderID(ctx context.Context, leaderDomain string) (int64, error) {
+	leader, err := s.starterRepo.FindByDomain(ctx, leaderDomain)
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return leader.ID, nil
+}
+
+// leadsSubtree walks from departmentID up through its parent departments until it finds one
+// led by leaderID or reaches the top.
+func (s *DepartmentAccessService) leadsSubtree(ctx context.Context, leaderID, departmentID int64) (bool, error) {
+	visited := make(map[int64]bool)
+	for id := departmentID; !visited[id]; {
+		visited[id] = true
+
+		departments, err := s.departmentRepo.FindByIDs(ctx, []int64{id})
+		if err != nil {
+			return false, err
+		}
+		if len(departments) == 0 {
+			return false, nil
+		}
+
+		department := departments[0]
+		if department.LeaderID != nil && *department.LeaderID == leaderID {
+			return true, nil
+		}
+		if department.GroupDepartmentID == nil {
+			return false, nil
+		}
+		id = *department.GroupDepartmentID
+	}
+	// The parent chain loops back on itself without reaching a department of this leader
+	return false, nil
+}
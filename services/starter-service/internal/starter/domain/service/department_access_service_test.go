@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sharedDomain "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/error"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository/mocks"
+)
+
+func newDepartmentAccessService(departments map[int64]*model.Department, starters map[string]*model.Starter) *DepartmentAccessService {
+	starterRepo := &mocks.MockStarterRepository{
+		FindByDomainFunc: func(ctx context.Context, domain string) (*model.Starter, error) {
+			if starter, ok := starters[domain]; ok {
+				return starter, nil
+			}
+			return nil, sharedDomain.ErrNotFound
+		},
+	}
+	departmentRepo := &mocks.MockDepartmentRepository{
+		FindByIDsFunc: func(ctx context.Context, ids []int64) ([]*model.Department, error) {
+			result := make([]*model.Department, 0, len(ids))
+			for _, id := range ids {
+				if department, ok := departments[id]; ok {
+					result = append(result, department)
+				}
+			}
+			return result, nil
+		},
+	}
+	return NewDepartmentAccessService(starterRepo, departmentRepo)
+}
+
+func TestDepartmentAccessService(t *testing.T) {
+	leaderID, otherLeaderID := int64(10), int64(20)
+	root, engineering, backend, sales := int64(1), int64(2), int64(3), int64(4)
+
+	departments := map[int64]*model.Department{
+		root:        {ID: root, LeaderID: &otherLeaderID},
+		engineering: {ID: engineering, GroupDepartmentID: &root, LeaderID: &leaderID},
+		backend:     {ID: backend, GroupDepartmentID: &engineering},
+		sales:       {ID: sales, GroupDepartmentID: &root},
+	}
+	starters := map[string]*model.Starter{
+		"leader":    {ID: leaderID, Domain: "leader", DepartmentID: &engineering},
+		"developer": {ID: 30, Domain: "developer", DepartmentID: &backend},
+		"seller":    {ID: 40, Domain: "seller", DepartmentID: &sales},
+		"floating":  {ID: 50, Domain: "floating"},
+	}
+	svc := newDepartmentAccessService(departments, starters)
+	ctx := context.Background()
+
+	departmentCases := []struct {
+		name         string
+		leader       string
+		departmentID int64
+		want         bool
+	}{
+		{"own department", "leader", engineering, true},
+		{"child department", "leader", backend, true},
+		{"parent department", "leader", root, false},
+		{"sibling department", "leader", sales, false},
+		{"unknown department", "leader", 99, false},
+		{"unknown leader", "nobody", backend, false},
+	}
+	for _, tc := range departmentCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := svc.CanManageDepartment(ctx, tc.leader, tc.departmentID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("CanManageDepartment(%s, %d) = %v, want %v", tc.leader, tc.departmentID, got, tc.want)
+			}
+		})
+	}
+
+	starterCases := []struct {
+		name    string
+		starter string
+		want    bool
+	}{
+		{"starter in subtree", "developer", true},
+		{"starter outside subtree", "seller", false},
+		{"starter without department", "floating", false},
+	}
+	for _, tc := range starterCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := svc.CanManageStarter(ctx, "leader", tc.starter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("CanManageStarter(%s) = %v, want %v", tc.starter, got, tc.want)
+			}
+		})
+	}
+
+	t.Run("missing starter", func(t *testing.T) {
+		if _, err := svc.CanManageStarter(ctx, "leader", "ghost"); !errors.Is(err, sharedDomain.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func TestDepartmentAccessServiceStopsOnCycle(t *testing.T) {
+	a, b := int64(1), int64(2)
+	departments := map[int64]*model.Department{
+		a: {ID: a, GroupDepartmentID: &b},
+		b: {ID: b, GroupDepartmentID: &a},
+	}
+	starters := map[string]*model.Starter{"leader": {ID: 10, Domain: "leader"}}
+
+	got, err := newDepartmentAccessService(departments, starters).CanManageDepartment(context.Background(), "leader", a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got {
+		t.Error("expected a department cycle without the leader to be denied")
+	}
+}
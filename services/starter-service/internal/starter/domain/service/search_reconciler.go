@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/kiin21/go-rest/pkg/events"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/messaging"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+	repo "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository"
+)
+
+const (
+	reconcileBatchSize = 500
+	// Rows touched within the grace period may still have their sync event in flight.
+	reconcileGracePeriod = time.Minute
+	// MySQL TIMESTAMP has second precision while ES dates keep milliseconds.
+	reconcileStaleTolerance = time.Second
+	reconcileSampleSize     = 20
+)
+
+// SearchReconciler compares MySQL starters with the search index and emits sync events
+// to repair the drift left behind by the best-effort async indexing.
+type SearchReconciler struct {
+	starterRepo  repo.StarterRepository
+	searchRepo   repo.StarterSearchRepository
+	syncProducer messaging.SyncProducer
+
+	batchSize   int
+	gracePeriod time.Duration
+	now         func() time.Time
+
+	runMu      sync.Mutex
+	reportMu   sync.RWMutex
+	lastReport *model.SearchConsistencyReport
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewSearchReconciler(
+	starterRepo repo.StarterRepository,
+	searchRepo repo.StarterSearchRepository,
+	syncProducer messaging.SyncProducer,
+) *SearchReconciler {
+	return &SearchReconciler{
+		starterRepo:  starterRepo,
+		searchRepo:   searchRepo,
+		syncProducer: syncProducer,
+		batchSize:    reconcileBatchSize,
+		gracePeriod:  reconcileGracePeriod,
+		now:          time.Now,
+	}
+}
+
+// Start runs a reconciliation immediately and then every interval until Stop is called.
+func (r *SearchReconciler) Start(interval time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := r.Reconcile(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Search reconciliation failed: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop cancels the running reconciliation, if any, and waits for the loop to exit.
+func (r *SearchReconciler) Stop() {
+	if r.cancel == nil {
+		return
+	}
+	r.cancel()
+	<-r.done
+}
+
+// LastReport returns the result of the latest reconciliation, or nil if none has run yet.
+func (r *SearchReconciler) LastReport() *model.SearchConsistencyReport {
+	r.reportMu.RLock()
+	defer r.reportMu.RUnlock()
+	return r.lastReport
+}
+
+// Reconcile walks both sides ordered by id, counts the drift and emits a repair event for
+// every mismatch: an index event for missing or stale documents, a delete event for orphans.
+func (r *SearchReconciler) Reconcile(ctx context.Context) (*model.SearchConsistencyReport, error) {
+	r.runMu.Lock()
+	defer r.runMu.Unlock()
+
+	report := &model.SearchConsistencyReport{StartedAt: r.now()}
+	err := r.compare(ctx, report)
+	report.FinishedAt = r.now()
+	if err != nil {
+		report.Error = err.Error()
+	}
+
+	r.reportMu.Lock()
+	r.lastReport = report
+	r.reportMu.Unlock()
+
+	log.Printf(
+		"Search reconciliation finished: db=%d index=%d missing=%d stale=%d orphaned=%d repairs=%d failed=%d",
+		report.DatabaseCount, report.IndexCount, report.Missing, report.Stale, report.Orphaned,
+		report.RepairsEmitted, report.RepairsFailed,
+	)
+
+	return report, err
+}
+
+func (r *SearchReconciler) compare(ctx context.Context, report *model.SearchConsistencyReport) error {
+	cutoff := report.StartedAt.Add(-r.gracePeriod)
+
+	rows := &stateCursor[*model.StarterSyncState]{
+		fetch: func(ctx context.Context, afterID int64) ([]*model.StarterSyncState, error) {
+			return r.starterRepo.ListSyncStates(ctx, afterID, r.batchSize)
+		},
+		idOf: func(s *model.StarterSyncState) int64 { return s.ID },
+	}
+	docs := &stateCursor[*model.StarterIndexState]{
+		fetch: func(ctx context.Context, afterID int64) ([]*model.StarterIndexState, error) {
+			return r.searchRepo.ListIndexStates(ctx, afterID, r.batchSize)
+		},
+		idOf: func(s *model.StarterIndexState) int64 { return s.ID },
+	}
+
+	for {
+		row, hasRow, err := rows.peek(ctx)
+		if err != nil {
+			return err
+		}
+		doc, hasDoc, err := docs.peek(ctx)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case !hasRow && !hasDoc:
+			return nil
+
+		case !hasDoc || (hasRow && row.ID < doc.ID):
+			rows.advance()
+			if row.IsDeleted() {
+				continue
+			}
+			report.DatabaseCount++
+			if row.UpdatedAt.After(cutoff) {
+				continue
+			}
+			report.Missing++
+			report.MissingIDs = appendSample(report.MissingIDs, row.ID)
+			r.emitRepair(report, events.EventTypeStarterIndex, row.ID, row.Domain)
+
+		case !hasRow || doc.ID < row.ID:
+			docs.advance()
+			report.IndexCount++
+			// Once MySQL is exhausted, higher ids belong to rows created after the scan passed them.
+			if !hasRow && doc.ID > rows.afterID {
+				continue
+			}
+			report.Orphaned++
+			report.OrphanedIDs = appendSample(report.OrphanedIDs, doc.ID)
+			r.emitRepair(report, events.EventTypeStarterDelete, doc.ID, doc.Domain)
+
+		default:
+			rows.advance()
+			docs.advance()
+			report.IndexCount++
+			if !row.IsDeleted() {
+				report.DatabaseCount++
+			}
+			if row.UpdatedAt.After(cutoff) {
+				continue
+			}
+
+			if row.IsDeleted() {
+				report.Orphaned++
+				report.OrphanedIDs = appendSample(report.OrphanedIDs, doc.ID)
+				r.emitRepair(report, events.EventTypeStarterDelete, doc.ID, doc.Domain)
+			} else if isStale(row, doc) {
+				report.Stale++
+				report.StaleIDs = appendSample(report.StaleIDs, row.ID)
+				r.emitRepair(report, events.EventTypeStarterIndex, row.ID, row.Domain)
+			}
+		}
+	}
+}
+
+func (r *SearchReconciler) emitRepair(report *model.SearchConsistencyReport, eventType string, id int64, domain string) {
+	if r.syncProducer == nil {
+		return
+	}
+
+	event, err := events.NewEvent(eventType, events.IndexStarterPayload{
+		StarterID: id,
+		Domain:    domain,
+	})
+	if err == nil {
+		err = r.syncProducer.SendSyncEvent(event)
+	}
+	if err != nil {
+		log.Printf("Failed to emit %s repair for starter %d: %v", eventType, id, err)
+		report.RepairsFailed++
+		return
+	}
+	report.RepairsEmitted++
+}
+
+func isStale(row *model.StarterSyncState, doc *model.StarterIndexState) bool {
+	if doc.UpdatedAt == nil || doc.Domain != row.Domain {
+		return true
+	}
+	return row.UpdatedAt.Sub(*doc.UpdatedAt) > reconcileStaleTolerance
+}
+
+func appendSample(ids []int64, id int64) []int64 {
+	if len(ids) >= reconcileSampleSize {
+		return ids
+	}
+	return append(ids, id)
+}
+
+// stateCursor pages through an id-ordered source one batch at a time.
+type stateCursor[T any] struct {
+	fetch   func(ctx context.Context, afterID int64) ([]T, error)
+	idOf    func(T) int64
+	batch   []T
+	pos     int
+	afterID int64
+	done    bool
+}
+
+func (c *stateCursor[T]) peek(ctx context.Context) (T, bool, error) {
+	var zero T
+	if c.pos >= len(c.batch) && !c.done {
+		batch, err := c.fetch(ctx, c.afterID)
+		if err != nil {
+			return zero, false, err
+		}
+		c.batch, c.pos = batch, 0
+		if len(batch) == 0 {
+			c.done = true
+		}
+	}
+	if c.pos >= len(c.batch) {
+		return zero, false, nil
+	}
+	return c.batch[c.pos], true, nil
+}
+
+func (c *stateCursor[T]) advance() {
+	c.afterID = c.idOf(c.batch[c.pos])
+	c.pos++
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/kiin21/go-rest/pkg/events"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/messaging/mocks"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+	repomocks "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository/mocks"
+)
+
+func newTestReconciler(
+	rows []*model.StarterSyncState,
+	docs []*model.StarterIndexState,
+	sent *[]*events.Event,
+	now time.Time,
+) *SearchReconciler {
+	starterRepo := &repomocks.MockStarterRepository{
+		ListSyncStatesFunc: func(ctx context.Context, afterID int64, limit int) ([]*model.StarterSyncState, error) {
+			var page []*model.StarterSyncState
+			for _, row := range rows {
+				if row.ID > afterID && len(page) < limit {
+					page = append(page, row)
+				}
+			}
+			return page, nil
+		},
+	}
+	searchRepo := &repomocks.MockStarterSearchRepository{
+		ListIndexStatesFunc: func(ctx context.Context, afterID int64, limit int) ([]*model.StarterIndexState, error) {
+			var page []*model.StarterIndexState
+			for _, doc := range docs {
+				if doc.ID > afterID && len(page) < limit {
+					page = append(page, doc)
+				}
+			}
+			return page, nil
+		},
+	}
+	producer := &mocks.MockSyncProducer{
+		SendSyncEventFunc: func(event *events.Event) error {
+			*sent = append(*sent, event)
+			return nil
+		},
+	}
+
+	reconciler := NewSearchReconciler(starterRepo, searchRepo, producer)
+	reconciler.batchSize = 2
+	reconciler.now = func() time.Time { return now }
+	return reconciler
+}
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-time.Hour)
+	older := now.Add(-2 * time.Hour)
+	recent := now.Add(-10 * time.Second)
+	withinTolerance := old.Add(-500 * time.Millisecond)
+
+	rows := []*model.StarterSyncState{
+		{ID: 1, Domain: "in.sync", UpdatedAt: old},
+		{ID: 2, Domain: "missing", UpdatedAt: old},
+		{ID: 3, Domain: "stale", UpdatedAt: old},
+		{ID: 4, Domain: "deleted", UpdatedAt: old, DeletedAt: &old},
+		{ID: 5, Domain: "recent", UpdatedAt: recent},
+		{ID: 6, Domain: "no.timestamp", UpdatedAt: old},
+		{ID: 7, Domain: "renamed", UpdatedAt: old},
+		{ID: 8, Domain: "deleted.unindexed", UpdatedAt: old, DeletedAt: &old},
+		{ID: 10, Domain: "tolerance", UpdatedAt: old},
+	}
+	docs := []*model.StarterIndexState{
+		{ID: 1, Domain: "in.sync", UpdatedAt: &old},
+		{ID: 3, Domain: "stale", UpdatedAt: &older},
+		{ID: 4, Domain: "deleted", UpdatedAt: &older},
+		{ID: 6, Domain: "no.timestamp"},
+		{ID: 7, Domain: "old.name", UpdatedAt: &old},
+		{ID: 9, Domain: "hard.deleted", UpdatedAt: &old},
+		{ID: 10, Domain: "tolerance", UpdatedAt: &withinTolerance},
+		{ID: 11, Domain: "created.after.scan", UpdatedAt: &recent},
+	}
+
+	var sent []*events.Event
+	reconciler := newTestReconciler(rows, docs, &sent, now)
+
+	report, err := reconciler.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.DatabaseCount != 7 {
+		t.Errorf("expected database count 7, got %d", report.DatabaseCount)
+	}
+	if report.IndexCount != 8 {
+		t.Errorf("expected index count 8, got %d", report.IndexCount)
+	}
+	assertIDs(t, "missing", report.MissingIDs, []int64{2})
+	assertIDs(t, "stale", report.StaleIDs, []int64{3, 6, 7})
+	assertIDs(t, "orphaned", report.OrphanedIDs, []int64{4, 9})
+
+	if report.Missing != 1 || report.Stale != 3 || report.Orphaned != 2 {
+		t.Errorf("unexpected drift counts: missing=%d stale=%d orphaned=%d", report.Missing, report.Stale, report.Orphaned)
+	}
+	if report.RepairsEmitted != 6 || report.RepairsFailed != 0 {
+		t.Errorf("expected 6 repairs emitted, got emitted=%d failed=%d", report.RepairsEmitted, report.RepairsFailed)
+	}
+
+	var indexed, deleted []string
+	for _, event := range sent {
+		var payload events.IndexStarterPayload
+		if err := event.UnmarshalPayload(&payload); err != nil {
+			t.Fatalf("failed to unmarshal payload: %v", err)
+		}
+		switch event.Type {
+		case events.EventTypeStarterIndex:
+			indexed = append(indexed, payload.Domain)
+		case events.EventTypeStarterDelete:
+			deleted = append(deleted, payload.Domain)
+		default:
+			t.Errorf("unexpected event type %s", event.Type)
+		}
+	}
+	assertDomains(t, "indexed", indexed, []string{"missing", "no.timestamp", "renamed", "stale"})
+	assertDomains(t, "deleted", deleted, []string{"deleted", "hard.deleted"})
+
+	if reconciler.LastReport() != report {
+		t.Error("expected last report to be stored")
+	}
+	if report.IsConsistent() {
+		t.Error("expected report to be inconsistent")
+	}
+}
+
+func TestReconcileConsistent(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-time.Hour)
+
+	rows := []*model.StarterSyncState{
+		{ID: 1, Domain: "a", UpdatedAt: old},
+		{ID: 2, Domain: "b", UpdatedAt: old},
+		{ID: 3, Domain: "c", UpdatedAt: old},
+	}
+	docs := []*model.StarterIndexState{
+		{ID: 1, Domain: "a", UpdatedAt: &old},
+		{ID: 2, Domain: "b", UpdatedAt: &old},
+		{ID: 3, Domain: "c", UpdatedAt: &old},
+	}
+
+	var sent []*events.Event
+	report, err := newTestReconciler(rows, docs, &sent, now).Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !report.IsConsistent() {
+		t.Errorf("expected consistent report, got drift %d", report.DriftCount())
+	}
+	if len(sent) != 0 {
+		t.Errorf("expected no repair events, got %d", len(sent))
+	}
+}
+
+func TestReconcileSearchError(t *testing.T) {
+	reconciler := NewSearchReconciler(
+		&repomocks.MockStarterRepository{},
+		&repomocks.MockStarterSearchRepository{
+			ListIndexStatesFunc: func(ctx context.Context, afterID int64, limit int) ([]*model.StarterIndexState, error) {
+				return nil, errors.New("elasticsearch error")
+			},
+		},
+		nil,
+	)
+
+	report, err := reconciler.Reconcile(context.Background())
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+	if report.Error == "" {
+		t.Error("expected error to be recorded on the report")
+	}
+	if report.IsConsistent() {
+		t.Error("expected failed report to be inconsistent")
+	}
+}
+
+func TestReconcileWithoutProducer(t *testing.T) {
+	old := time.Now().Add(-time.Hour)
+	reconciler := NewSearchReconciler(
+		&repomocks.MockStarterRepository{
+			ListSyncStatesFunc: func(ctx context.Context, afterID int64, limit int) ([]*model.StarterSyncState, error) {
+				if afterID > 0 {
+					return nil, nil
+				}
+				return []*model.StarterSyncState{{ID: 1, Domain: "missing", UpdatedAt: old}}, nil
+			},
+		},
+		&repomocks.MockStarterSearchRepository{},
+		nil,
+	)
+
+	report, err := reconciler.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Missing != 1 {
+		t.Errorf("expected 1 missing document, got %d", report.Missing)
+	}
+	if report.RepairsEmitted != 0 {
+		t.Errorf("expected no repairs without a producer, got %d", report.RepairsEmitted)
+	}
+}
+
+func assertIDs(t *testing.T, name string, got, want []int64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s ids: expected %v, got %v", name, want, got)
+		return
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s ids: expected %v, got %v", name, want, got)
+			return
+		}
+	}
+}
+
+func assertDomains(t *testing.T, name string, got, want []string) {
+	t.Helper()
+	sort.Strings(got)
+	if len(got) != len(want) {
+		t.Errorf("%s domains: expected %v, got %v", name, want, got)
+		return
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s domains: expected %v, got %v", name, want, got)
+			return
+		}
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"log"
 	"strconv"
+	"strings"
 
 	"github.com/kiin21/go-rest/pkg/events"
 	"github.com/kiin21/go-rest/pkg/httputil"
+	"github.com/kiin21/go-rest/pkg/utils"
 	starterquery "github.com/kiin21/go-rest/services/starter-service/internal/starter/application/dto/starter/query"
 	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/messaging"
 	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
@@ -88,12 +90,45 @@ func (s *StarterSearchService) Search(
 	query *starterquery.ListStartersQuery,
 ) (*httputil.PaginatedResult[*model.Starter], error) {
 	// Elasticsearch search with query builder
-	starterIds, total, err := s.searchRepo.Search(ctx, query)
+	hits, total, err := s.searchRepo.Search(ctx, query, s.buildSearchQuery)
 	if err != nil {
 		return nil, err
 	}
 
-	starters, err := s.repo.FindByIDs(ctx, starterIds)
+	// In cursor mode the next page is decided on the hits rather than on the rows found for
+	// them: a hit whose row is gone from MySQL must not end the listing early
+	var cursorPagination *httputil.RespPagination
+	if query.Pagination.IsCursorMode() {
+		var pagination httputil.RespPagination
+		hits, pagination = httputil.NewCursorPage(hits, query.Pagination.GetLimit(), query.Pagination.Cursor,
+			func(hit *model.StarterSearchHit) (string, string) { return hit.CursorKey(query.SortBy) })
+		cursorPagination = &pagination
+	}
+
+	starterIds := make([]int64, 0, len(hits))
+	for _, hit := range hits {
+		starterIds = append(starterIds, hit.ID)
+	}
+	found, err := s.repo.FindByIDs(ctx, starterIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// FindByIDs does not keep the search order; restore it
+	byID := make(map[int64]*model.Starter, len(found))
+	for _, starter := range found {
+		byID[starter.ID] = starter
+	}
+	starters := make([]*model.Starter, 0, len(starterIds))
+	for _, id := range starterIds {
+		if starter, ok := byID[id]; ok {
+			starters = append(starters, starter)
+		}
+	}
+
+	if cursorPagination != nil {
+		return &httputil.PaginatedResult[*model.Starter]{Data: starters, Pagination: *cursorPagination}, nil
+	}
 
 	totalPages := int(total) / query.Pagination.GetLimit()
 	if int(total)%(query.Pagination.GetLimit()) > 0 {
@@ -120,3 +155,213 @@ func (s *StarterSearchService) Search(
 		},
 	}, nil
 }
+
+func (s *StarterSearchService) buildSearchQuery(q *starterquery.ListStartersQuery) map[string]interface{} {
+	if q == nil {
+		return nil
+	}
+
+	kw := strings.TrimSpace(q.Keyword)
+	if kw == "" {
+		return nil
+	}
+
+	// Determine search fields
+	field := s.mapSearchByToFieldName(q.SearchBy)
+	searchFields := s.getSearchFields(field)
+
+	// Build query
+	must := []any{
+		map[string]any{
+			"multi_match": map[string]any{
+				"query":     kw,
+				"type":      "best_fields",
+				"fuzziness": "AUTO",
+				"operator":  "and",
+				"fields":    searchFields,
+			},
+		},
+	}
+	if field == "phone" {
+		must = []any{s.buildPhoneQuery(kw, searchFields)}
+	}
+
+	boolQuery := map[string]any{
+		"must": must,
+	}
+	filter, mustNot := s.buildFilterClauses(q.Filter)
+	if len(filter) > 0 {
+		boolQuery["filter"] = filter
+	}
+	if len(mustNot) > 0 {
+		boolQuery["must_not"] = mustNot
+	}
+
+	// Build final query structure
+	es := map[string]any{
+		"query": map[string]any{
+			"bool": boolQuery,
+		},
+	}
+
+	// Add sorting if specified
+	if sort := s.buildSortClause(q.SortBy, q.SortOrder); len(sort) > 0 {
+		es["sort"] = sort
+	}
+
+	return es
+}
+
+// buildFilterClauses compiles the list filter to bool filter and must_not clauses.
+// Filters do not affect scoring, so the keyword relevance ordering is preserved.
+func (s *StarterSearchService) buildFilterClauses(f *model.StarterListFilter) (filter []any, mustNot []any) {
+	if f.IsEmpty() {
+		return nil, nil
+	}
+
+	for _, condition := range f.Conditions {
+		field := s.mapFilterFieldToESField(condition.Field.Name)
+
+		switch condition.Operator {
+		case model.FilterEq:
+			filter = append(filter, map[string]any{"term": map[string]any{field: condition.Values[0]}})
+		case model.FilterNe:
+			mustNot = append(mustNot, map[string]any{"term": map[string]any{field: condition.Values[0]}})
+		case model.FilterIn:
+			filter = append(filter, map[string]any{"terms": map[string]any{field: condition.Values}})
+		case model.FilterGt, model.FilterGte, model.FilterLt, model.FilterLte:
+			filter = append(filter, map[string]any{
+				"range": map[string]any{
+					field: map[string]any{string(condition.Operator): condition.Values[0]},
+				},
+			})
+		}
+	}
+
+	return filter, mustNot
+}
+
+// mapFilterFieldToESField returns the indexed field for a filter; text fields are
+// matched exactly through their keyword subfield.
+func (s *StarterSearchService) mapFilterFieldToESField(name string) string {
+	switch name {
+	case "domain", "email", "job_title":
+		return name + ".keyword"
+	default:
+		return name
+	}
+}
+
+// buildPhoneQuery matches the keyword digits anywhere in the normalized phone numbers;
+// fuzziness makes no sense for digits, so a plain wildcard on the keyword subfields is used.
+func (s *StarterSearchService) buildPhoneQuery(keyword string, fields []string) map[string]any {
+	digits := utils.NormalizePhone(keyword)
+	if digits == "" {
+		return map[string]any{"match_none": map[string]any{}}
+	}
+
+	should := make([]any, 0, len(fields))
+	for _, f := range fields {
+		should = append(should, map[string]any{
+			"wildcard": map[string]any{
+				f: map[string]any{"value": "*" + digits + "*"},
+			},
+		})
+	}
+
+	return map[string]any{
+		"bool": map[string]any{
+			"should":               should,
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+// getSearchFields returns the appropriate fields based on the search type
+func (s *StarterSearchService) getSearchFields(field string) []string {
+	switch field {
+	case "domain":
+		return []string{"domain"}
+	case "name":
+		// Folded name matches regardless of accents; the exact subfield ranks
+		// correctly accented hits first ("Nguyễn" above "Nguyên").
+		return []string{"name", "name.exact^2"}
+	case "email":
+		return []string{"email"}
+	case "phone":
+		return []string{"mobile.keyword", "work_phone.keyword"}
+	case "job_title":
+		return []string{"job_title"}
+	case "business_unit_name":
+		return []string{"business_unit_name"}
+	case "department_name":
+		return []string{"department_name"}
+	default:
+		return []string{"full_text"}
+	}
+}
+
+func (s *StarterSearchService) mapSearchByToFieldName(searchBy string) string {
+	switch strings.ToLower(strings.TrimSpace(searchBy)) {
+	case "domain":
+		return "domain"
+	case "fullname", "name":
+		return "name"
+	case "email":
+		return "email"
+	case "phone", "mobile":
+		return "phone"
+	case "job_title":
+		return "job_title"
+	case "dept_name":
+		return "department_name"
+	case "bu_name":
+		return "business_unit_name"
+	default:
+		return "" // Multi-field search
+	}
+}
+
+func (s *StarterSearchService) buildSortClause(sortBy, sortOrder string) []interface{} {
+	field := s.mapSortFieldToESField(sortBy)
+	if field == "" {
+		return nil
+	}
+	order := strings.ToLower(strings.TrimSpace(sortOrder))
+	if order != "desc" {
+		order = "asc"
+	}
+	sort := []interface{}{
+		map[string]interface{}{
+			field: map[string]interface{}{"order": order},
+		},
+	}
+	// Break ties on id so that the order is total, which search_after relies on
+	if field != "id" {
+		sort = append(sort, map[string]interface{}{
+			"id": map[string]interface{}{"order": order},
+		})
+	}
+	return sort
+}
+
+// mapSortFieldToESField returns a sortable field; text fields sort on their keyword subfield
+// because analyzed text has no doc values.
+func (s *StarterSearchService) mapSortFieldToESField(sortBy string) string {
+	switch strings.ToLower(strings.TrimSpace(sortBy)) {
+	case "id":
+		return "id"
+	case "domain":
+		return "domain.keyword"
+	case "name", "fullname":
+		return "name.keyword"
+	case "dept_name":
+		return "department_name.keyword"
+	case "bu_name":
+		return "business_unit_name.keyword"
+	case "created_at":
+		return "created_at"
+	default:
+		return "id" // Default sort by id
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -28,6 +29,9 @@ func TestMapSearchByToFieldName(t *testing.T) {
 		{"name", "name"},
 		{"dept_name", "department_name"},
 		{"bu_name", "business_unit_name"},
+		{"email", "email"},
+		{"phone", "phone"},
+		{"job_title", "job_title"},
 		{"", ""},
 		{"unknown", ""},
 	}
@@ -50,11 +54,12 @@ func TestMapSortFieldToESField(t *testing.T) {
 		expected string
 	}{
 		{"id", "id"},
-		{"domain", "domain"},
-		{"name", "name"},
-		{"fullname", "name"},
-		{"dept_name", "department_name"},
-		{"bu_name", "business_unit_name"},
+		{"domain", "domain.keyword"},
+		{"name", "name.keyword"},
+		{"fullname", "name.keyword"},
+		{"dept_name", "department_name.keyword"},
+		{"bu_name", "business_unit_name.keyword"},
+		{"created_at", "created_at"},
 		{"", "id"},
 		{"unknown", "id"},
 	}
@@ -104,9 +109,9 @@ func TestBuildSearchQuery(t *testing.T) {
 	service := &StarterSearchService{}
 
 	tests := []struct {
-		name     string
-		query    *starterquery.ListStartersQuery
-		isNil    bool
+		name  string
+		query *starterquery.ListStartersQuery
+		isNil bool
 	}{
 		{
 			name:  "nil query",
@@ -170,6 +175,86 @@ func TestBuildSearchQuery(t *testing.T) {
 	}
 }
 
+func TestBuildSearchQueryPhone(t *testing.T) {
+	service := &StarterSearchService{}
+
+	tests := []struct {
+		name     string
+		keyword  string
+		expected string
+	}{
+		{"formatted number", "0912 345-678", "*0912345678*"},
+		{"country code", "+84 912 345 678", "*0912345678*"},
+		{"partial digits", "345.678", "*345678*"},
+		{"no digits", "abc", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := service.buildSearchQuery(&starterquery.ListStartersQuery{Keyword: tt.keyword, SearchBy: "phone"})
+			must := result["query"].(map[string]any)["bool"].(map[string]any)["must"].([]any)
+			clause := must[0].(map[string]any)
+
+			if tt.expected == "" {
+				if _, ok := clause["match_none"]; !ok {
+					t.Errorf("expected match_none, got %v", clause)
+				}
+				return
+			}
+
+			should := clause["bool"].(map[string]any)["should"].([]any)
+			if len(should) != 2 {
+				t.Fatalf("expected mobile and work phone clauses, got %v", should)
+			}
+			for i, field := range []string{"mobile.keyword", "work_phone.keyword"} {
+				wildcard := should[i].(map[string]any)["wildcard"].(map[string]any)
+				value := wildcard[field].(map[string]any)["value"]
+				if value != tt.expected {
+					t.Errorf("%s wildcard = %v; want %s", field, value, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildSearchQueryFilter(t *testing.T) {
+	service := &StarterSearchService{}
+
+	filter, err := model.ParseStarterListFilter("department_id:in:3,4;job_title:eq:Engineer;line_manager_id:ne:7;created_at:gte:2026-01-01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := service.buildSearchQuery(&starterquery.ListStartersQuery{Keyword: "test", Filter: filter})
+	boolQuery := result["query"].(map[string]any)["bool"].(map[string]any)
+
+	filterClauses, ok := boolQuery["filter"].([]any)
+	if !ok || len(filterClauses) != 3 {
+		t.Fatalf("expected 3 filter clauses, got %v", boolQuery["filter"])
+	}
+
+	terms := filterClauses[0].(map[string]any)["terms"].(map[string]any)
+	if values := terms["department_id"].([]any); len(values) != 2 || values[0] != int64(3) {
+		t.Errorf("unexpected terms clause: %v", terms)
+	}
+	term := filterClauses[1].(map[string]any)["term"].(map[string]any)
+	if term["job_title.keyword"] != "Engineer" {
+		t.Errorf("expected job_title to be matched on the keyword subfield, got %v", term)
+	}
+	rangeClause := filterClauses[2].(map[string]any)["range"].(map[string]any)
+	if _, ok := rangeClause["created_at"].(map[string]any)["gte"]; !ok {
+		t.Errorf("unexpected range clause: %v", rangeClause)
+	}
+
+	mustNot, ok := boolQuery["must_not"].([]any)
+	if !ok || len(mustNot) != 1 {
+		t.Fatalf("expected 1 must_not clause, got %v", boolQuery["must_not"])
+	}
+	if mustNot[0].(map[string]any)["term"].(map[string]any)["line_manager_id"] != int64(7) {
+		t.Errorf("unexpected must_not clause: %v", mustNot[0])
+	}
+}
+
 func TestIndexStarter(t *testing.T) {
 	starter, _ := model.NewStarter(
 		"testuser",
@@ -287,11 +372,11 @@ func TestSearch(t *testing.T) {
 	)
 
 	tests := []struct {
-		name             string
-		query            *starterquery.ListStartersQuery
-		mockSearchRepo   *repomocks.MockStarterSearchRepository
-		mockStarterRepo  *repomocks.MockStarterRepository
-		expectError      bool
+		name            string
+		query           *starterquery.ListStartersQuery
+		mockSearchRepo  *repomocks.MockStarterSearchRepository
+		mockStarterRepo *repomocks.MockStarterRepository
+		expectError     bool
 	}{
 		{
 			name: "successful search",
@@ -303,8 +388,8 @@ func TestSearch(t *testing.T) {
 				},
 			},
 			mockSearchRepo: &repomocks.MockStarterSearchRepository{
-				SearchFunc: func(ctx context.Context, query *starterquery.ListStartersQuery, buildSearchQuery repository.SearchQueryBuilder) ([]int64, int64, error) {
-					return []int64{1}, 1, nil
+				SearchFunc: func(ctx context.Context, query *starterquery.ListStartersQuery, buildSearchQuery repository.SearchQueryBuilder) ([]*model.StarterSearchHit, int64, error) {
+					return []*model.StarterSearchHit{{ID: 1}}, 1, nil
 				},
 			},
 			mockStarterRepo: &repomocks.MockStarterRepository{
@@ -324,7 +409,7 @@ func TestSearch(t *testing.T) {
 				},
 			},
 			mockSearchRepo: &repomocks.MockStarterSearchRepository{
-				SearchFunc: func(ctx context.Context, query *starterquery.ListStartersQuery, buildSearchQuery repository.SearchQueryBuilder) ([]int64, int64, error) {
+				SearchFunc: func(ctx context.Context, query *starterquery.ListStartersQuery, buildSearchQuery repository.SearchQueryBuilder) ([]*model.StarterSearchHit, int64, error) {
 					return nil, 0, errors.New("elasticsearch error")
 				},
 			},
@@ -362,3 +447,88 @@ func TestSearch(t *testing.T) {
 	}
 }
 
+func TestSearchCursor(t *testing.T) {
+	limit := 2
+	hit := func(id int64) *model.StarterSearchHit {
+		return &model.StarterSearchHit{ID: id, Domain: fmt.Sprintf("user%d", id)}
+	}
+
+	tests := []struct {
+		name     string
+		hits     []*model.StarterSearchHit
+		missing  int64
+		wantIDs  []int64
+		wantNext string
+	}{
+		{"more hits", []*model.StarterSearchHit{hit(1), hit(2), hit(3)}, 0, []int64{1, 2}, "2"},
+		{"last page", []*model.StarterSearchHit{hit(1), hit(2)}, 0, []int64{1, 2}, ""},
+		// The row of hit 2 is gone from MySQL but still indexed; the listing goes on after it
+		{"row of the last hit missing", []*model.StarterSearchHit{hit(1), hit(2), hit(3)}, 2, []int64{1}, "2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested []int64
+			service := NewStarterSearchService(
+				&repomocks.MockStarterSearchRepository{
+					SearchFunc: func(ctx context.Context, query *starterquery.ListStartersQuery, buildSearchQuery repository.SearchQueryBuilder) ([]*model.StarterSearchHit, int64, error) {
+						return tt.hits, 0, nil
+					},
+				},
+				&repomocks.MockStarterRepository{
+					FindByIDsFunc: func(ctx context.Context, ids []int64) ([]*model.Starter, error) {
+						requested = ids
+						var found []*model.Starter
+						for _, id := range ids {
+							if id != tt.missing {
+								found = append(found, &model.Starter{ID: id, Domain: fmt.Sprintf("user%d", id)})
+							}
+						}
+						return found, nil
+					},
+				},
+				nil,
+			)
+
+			cursor := &httputil.Cursor{SortBy: "domain", SortOrder: "asc"}
+			result, err := service.Search(context.Background(), &starterquery.ListStartersQuery{
+				Keyword:    "user",
+				SortBy:     "domain",
+				SortOrder:  "asc",
+				Pagination: httputil.ReqPagination{Limit: &limit, Cursor: cursor},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if fmt.Sprint(requested) != "[1 2]" {
+				t.Errorf("looked up rows %v; want only the page's [1 2]", requested)
+			}
+			var ids []int64
+			for _, starter := range result.Data {
+				ids = append(ids, starter.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantIDs) {
+				t.Errorf("page = %v; want %v", ids, tt.wantIDs)
+			}
+
+			next := result.Pagination.Next
+			if tt.wantNext == "" {
+				if next != nil {
+					t.Errorf("expected no next page, got %s", *next)
+				}
+				return
+			}
+			if next == nil {
+				t.Fatal("expected a next page")
+			}
+			decoded, err := httputil.ParseCursor(*next, "domain", "asc")
+			if err != nil {
+				t.Fatalf("failed to decode next cursor: %v", err)
+			}
+			if decoded.ID != tt.wantNext || decoded.Value != "user"+tt.wantNext {
+				t.Errorf("next cursor = %+v; want the key of hit %s", decoded, tt.wantNext)
+			}
+		})
+	}
+}
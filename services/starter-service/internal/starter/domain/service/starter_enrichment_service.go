@@ -27,23 +27,36 @@ func NewStarterEnrichmentService(
 }
 
 func (s *StarterEnrichmentService) EnrichStarters(ctx context.Context, starters []*model.Starter) (*model.EnrichedData, error) {
+	return s.EnrichStartersWith(ctx, starters, model.ExpandAll())
+}
+
+// EnrichStartersWith loads only the relations selected by expand. Business units hang off
+// departments, so either one costs the department query but is only filled in when requested.
+func (s *StarterEnrichmentService) EnrichStartersWith(
+	ctx context.Context,
+	starters []*model.Starter,
+	expand model.StarterExpand,
+) (*model.EnrichedData, error) {
 	enriched := &model.EnrichedData{
 		Departments:   make(map[int64]*model.DepartmentNested),
 		LineManagers:  make(map[int64]*model.LineManagerNested),
 		BusinessUnits: make(map[int64]*model.BusinessUnitNested),
 	}
 
-	// Collect unique IDs.
-	departmentIDs := s.collectDepartmentIDs(starters)
-	lineManagerIDs := s.collectLineManagerIDs(starters)
-
-	if len(departmentIDs) > 0 {
-		if err := s.loadDepartments(ctx, departmentIDs, enriched); err != nil {
-			return nil, err
+	if expand.Department || expand.BusinessUnit {
+		departmentIDs := s.collectDepartmentIDs(starters)
+		if len(departmentIDs) > 0 {
+			if err := s.loadDepartments(ctx, departmentIDs, expand, enriched); err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	// Load line managers
+	var lineManagerIDs map[int64]bool
+	if expand.LineManager {
+		lineManagerIDs = s.collectLineManagerIDs(starters)
+	}
 	if len(lineManagerIDs) > 0 {
 		if err := s.loadLineManagers(ctx, lineManagerIDs, enriched); err != nil {
 			return nil, err
@@ -76,6 +89,7 @@ func (s *StarterEnrichmentService) collectLineManagerIDs(starters []*model.Start
 func (s *StarterEnrichmentService) loadDepartments(
 	ctx context.Context,
 	departmentIDs map[int64]bool,
+	expand model.StarterExpand,
 	enriched *model.EnrichedData,
 ) error {
 	ids := make([]int64, 0, len(departmentIDs))
@@ -89,7 +103,7 @@ func (s *StarterEnrichmentService) loadDepartments(
 	}
 
 	for _, rel := range relations {
-		s.mapDepartmentRelation(rel, enriched)
+		s.mapDepartmentRelation(rel, expand, enriched)
 	}
 
 	return nil
@@ -97,6 +111,7 @@ func (s *StarterEnrichmentService) loadDepartments(
 
 func (s *StarterEnrichmentService) mapDepartmentRelation(
 	rel *model.DepartmentWithDetails,
+	expand model.StarterExpand,
 	enriched *model.EnrichedData,
 ) {
 	if rel == nil || rel.Department == nil {
@@ -104,6 +119,17 @@ func (s *StarterEnrichmentService) mapDepartmentRelation(
 	}
 
 	dept := rel.Department
+	if expand.BusinessUnit && rel.BusinessUnit != nil {
+		enriched.BusinessUnits[dept.ID] = &model.BusinessUnitNested{
+			ID:        rel.BusinessUnit.ID,
+			Name:      rel.BusinessUnit.Name,
+			Shortname: rel.BusinessUnit.Shortname,
+		}
+	}
+	if !expand.Department {
+		return
+	}
+
 	deptNested := &model.DepartmentNested{
 		ID:        dept.ID,
 		Name:      dept.FullName,
@@ -119,14 +145,6 @@ func (s *StarterEnrichmentService) mapDepartmentRelation(
 	}
 
 	enriched.Departments[dept.ID] = deptNested
-
-	if rel.BusinessUnit != nil {
-		enriched.BusinessUnits[dept.ID] = &model.BusinessUnitNested{
-			ID:        rel.BusinessUnit.ID,
-			Name:      rel.BusinessUnit.Name,
-			Shortname: rel.BusinessUnit.Shortname,
-		}
-	}
 }
 
 func (s *StarterEnrichmentService) loadLineManagers(
@@ -154,11 +172,13 @@ func (s *StarterEnrichmentService) loadLineManagers(
 	
 	for _, manager := range managers {
 		enriched.LineManagers[manager.ID] = &model.LineManagerNested{
-			ID:       manager.ID,
-			Domain:   manager.Domain,
-			Name:     manager.Name,
-			Email:    manager.GetEmail(),
-			JobTitle: manager.JobTitle,
+			ID:            manager.ID,
+			Domain:        manager.Domain,
+			Name:          manager.Name,
+			Email:         manager.GetEmail(),
+			JobTitle:      manager.JobTitle,
+			DepartmentID:  manager.DepartmentID,
+			LineManagerID: manager.LineManagerID,
 		}
 	}
 
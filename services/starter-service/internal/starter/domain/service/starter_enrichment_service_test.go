@@ -126,6 +126,67 @@ func TestEnrichStarters(t *testing.T) {
 	}
 }
 
+func TestEnrichStartersWithExpand(t *testing.T) {
+	deptID := int64(1)
+	lineManagerID := int64(2)
+	starter, _ := model.Rehydrate(1, "user1", "User One", "user1@vng.com.vn", "0123456789", "", "Developer",
+		&deptID, &lineManagerID, time.Now(), time.Now())
+
+	departmentCalls := 0
+	mockDepartmentRepo := &mocks.MockDepartmentRepository{
+		FindByIDsWithDetailsFunc: func(ctx context.Context, ids []int64) ([]*model.DepartmentWithDetails, error) {
+			departmentCalls++
+			return []*model.DepartmentWithDetails{
+				{
+					Department:   &model.Department{ID: 1, FullName: "Engineering", Shortname: "ENG"},
+					BusinessUnit: &model.BusinessUnit{ID: 1, Name: "Technology", Shortname: "TECH"},
+				},
+			}, nil
+		},
+	}
+	managerCalls := 0
+	mockStarterRepo := &mocks.MockStarterRepository{
+		FindByIDsFunc: func(ctx context.Context, ids []int64) ([]*model.Starter, error) {
+			managerCalls++
+			return nil, nil
+		},
+	}
+
+	service := NewStarterEnrichmentService(mockStarterRepo, mockDepartmentRepo, &mocks.MockBusinessUnitRepository{})
+
+	t.Run("no relations", func(t *testing.T) {
+		departmentCalls, managerCalls = 0, 0
+		enriched, err := service.EnrichStartersWith(context.Background(), []*model.Starter{starter}, model.StarterExpand{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if departmentCalls != 0 || managerCalls != 0 {
+			t.Errorf("expected no queries, got %d department and %d line manager", departmentCalls, managerCalls)
+		}
+		if len(enriched.Departments) != 0 || len(enriched.LineManagers) != 0 || len(enriched.BusinessUnits) != 0 {
+			t.Errorf("expected empty enrichment, got %+v", enriched)
+		}
+	})
+
+	t.Run("business unit only", func(t *testing.T) {
+		departmentCalls, managerCalls = 0, 0
+		enriched, err := service.EnrichStartersWith(context.Background(), []*model.Starter{starter},
+			model.StarterExpand{BusinessUnit: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if departmentCalls != 1 || managerCalls != 0 {
+			t.Errorf("expected only the department query, got %d department and %d line manager", departmentCalls, managerCalls)
+		}
+		if len(enriched.Departments) != 0 {
+			t.Errorf("expected departments to be left out, got %d", len(enriched.Departments))
+		}
+		if bu := enriched.BusinessUnits[deptID]; bu == nil || bu.Name != "Technology" {
+			t.Errorf("expected business unit Technology, got %+v", bu)
+		}
+	})
+}
+
 func TestEnrichStartersEmptyStarters(t *testing.T) {
 	mockDepartmentRepo := &mocks.MockDepartmentRepository{}
 	mockStarterRepo := &mocks.MockStarterRepository{}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type IdempotencyKeyEntity struct {
+	Subject      string    `gorm:"column:subject;primaryKey;type:varchar(255)"`
+	Key          string    `gorm:"column:idempotency_key;primaryKey;type:varchar(255)"`
+	Scope        string    `gorm:"column:scope;type:varchar(255);not null"`
+	RequestHash  string    `gorm:"column:request_hash;type:char(64);not null"`
+	StatusCode   int       `gorm:"column:status_code;not null"`
+	ResponseBody []byte    `gorm:"column:response_body;type:mediumblob"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+	ExpiresAt    time.Time `gorm:"column:expires_at;not null;index"`
+	LockedUntil  time.Time `gorm:"column:locked_until;not null"`
+}
+
+func (IdempotencyKeyEntity) TableName() string {
+	return "idempotency_keys"
+}
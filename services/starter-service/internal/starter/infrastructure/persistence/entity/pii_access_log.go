@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+type PIIAccessLogEntity struct {
+	ID            int64     `gorm:"column:id;primaryKey;autoIncrement"`
+	ViewerDomain  string    `gorm:"column:viewer_domain;type:varchar(255);not null"`
+	StarterID     int64     `gorm:"column:starter_id;not null"`
+	StarterDomain string    `gorm:"column:starter_domain;type:varchar(255);not null"`
+	Fields        string    `gorm:"column:fields;type:varchar(255);not null"`
+	Reason        string    `gorm:"column:reason;type:varchar(50);not null"`
+	Route         string    `gorm:"column:route;type:varchar(255);not null"`
+	AccessedAt    time.Time `gorm:"column:accessed_at;not null"`
+}
+
+func (PIIAccessLogEntity) TableName() string {
+	return "pii_access_logs"
+}
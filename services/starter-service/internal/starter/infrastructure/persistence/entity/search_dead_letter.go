@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+type SearchDeadLetterEntity struct {
+	ID         int64     `gorm:"column:id;primaryKey;autoIncrement"`
+	StarterID  int64     `gorm:"column:starter_id;not null;index"`
+	StatusCode int       `gorm:"column:status_code;not null"`
+	ErrorType  string    `gorm:"column:error_type;type:varchar(100);not null"`
+	Reason     string    `gorm:"column:reason;type:text;not null"`
+	Attempts   int       `gorm:"column:attempts;not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (SearchDeadLetterEntity) TableName() string {
+	return "search_index_dead_letters"
+}
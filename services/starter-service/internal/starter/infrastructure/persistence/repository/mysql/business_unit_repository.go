@@ -111,11 +111,13 @@ func (r *BusinessUnitRepository) toModelWithDetails(m *entity.BusinessUnitEntity
 
 	if m.Leader != nil {
 		bu.Leader = &model.LineManagerNested{
-			ID:       m.Leader.ID,
-			Domain:   m.Leader.Domain,
-			Name:     m.Leader.Name,
-			Email:    m.Leader.Email,
-			JobTitle: m.Leader.JobTitle,
+			ID:            m.Leader.ID,
+			Domain:        m.Leader.Domain,
+			Name:          m.Leader.Name,
+			Email:         m.Leader.Email,
+			JobTitle:      m.Leader.JobTitle,
+			DepartmentID:  m.Leader.DepartmentID,
+			LineManagerID: m.Leader.LineManagerID,
 		}
 	}
 
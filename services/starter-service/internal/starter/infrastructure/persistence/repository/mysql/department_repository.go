@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/kiin21/go-rest/pkg/httputil"
 	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
@@ -173,6 +174,21 @@ func (r *DepartmentRepository) fetchDepartmentsWithCounts(
 		baseQuery = baseQuery.Where("business_unit_id = ?", *filter.BusinessUnitID)
 	}
 
+	// Keyset pagination by id, with one extra row to detect the next page
+	if pg.IsCursorMode() {
+		if !pg.Cursor.IsFirstPage() {
+			afterID, err := pg.Cursor.Int64ID()
+			if err != nil {
+				return nil, 0, fmt.Errorf("invalid cursor id: %w", err)
+			}
+			baseQuery = baseQuery.Where("id > ?", afterID)
+		}
+		if err := baseQuery.Order("id ASC").Limit(pg.GetLimit() + 1).Find(&results).Error; err != nil {
+			return nil, 0, err
+		}
+		return results, 0, nil
+	}
+
 	if err := baseQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
@@ -375,7 +391,7 @@ func (r *DepartmentRepository) fetchLeaders(
 	var leaders []model.LineManagerNested
 	if err := r.db.WithContext(ctx).
 		Table("starters").
-		Select("id, domain, name, email, job_title").
+		Select("id, domain, name, email, job_title, department_id, line_manager_id").
 		Where("id IN ? AND deleted_at IS NULL", ids).
 		Find(&leaders).Error; err != nil {
 		return result
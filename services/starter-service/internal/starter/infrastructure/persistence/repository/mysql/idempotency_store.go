@@ -0,0 +1,112 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kiin21/go-rest/pkg/httputil"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/infrastructure/persistence/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyStore keeps idempotency records in the idempotency_keys table.
+type IdempotencyStore struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyStore(db *gorm.DB) httputil.IdempotencyStore {
+	return &IdempotencyStore{db: db}
+}
+
+func (s *IdempotencyStore) Reserve(ctx context.Context, record *httputil.IdempotencyRecord) (*httputil.IdempotencyRecord, error) {
+	reservation := &entity.IdempotencyKeyEntity{
+		Subject:     record.Subject,
+		Key:         record.Key,
+		Scope:       record.Scope,
+		RequestHash: record.RequestHash,
+		ExpiresAt:   record.ExpiresAt,
+		LockedUntil: record.LockedUntil,
+	}
+
+	// The primary key makes concurrent reservations of one subject's key race safely: only one insert wins
+	res := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(reservation)
+	if res.Error != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", res.Error)
+	}
+	if res.RowsAffected == 1 {
+		return nil, nil
+	}
+
+	// Take over an expired record or a reservation whose lease has lapsed, guarded on both
+	// deadlines so that only one request can do so
+	now := time.Now()
+	res = s.db.WithContext(ctx).
+		Model(&entity.IdempotencyKeyEntity{}).
+		Where("subject = ? AND idempotency_key = ? AND (expires_at < ? OR (status_code = 0 AND locked_until < ?))",
+			record.Subject, record.Key, now, now).
+		Updates(map[string]any{
+			"scope":         record.Scope,
+			"request_hash":  record.RequestHash,
+			"status_code":   0,
+			"response_body": nil,
+			"created_at":    now,
+			"expires_at":    record.ExpiresAt,
+			"locked_until":  record.LockedUntil,
+		})
+	if res.Error != nil {
+		return nil, fmt.Errorf("failed to reserve expired idempotency key: %w", res.Error)
+	}
+	if res.RowsAffected == 1 {
+		return nil, nil
+	}
+
+	var existing entity.IdempotencyKeyEntity
+	err := s.db.WithContext(ctx).
+		Where("subject = ? AND idempotency_key = ?", record.Subject, record.Key).
+		First(&existing).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Released between our insert and this read; the client can simply retry
+			return nil, fmt.Errorf("idempotency key %q was released concurrently", record.Key)
+		}
+		return nil, fmt.Errorf("failed to load idempotency key: %w", err)
+	}
+
+	return &httputil.IdempotencyRecord{
+		Subject:     existing.Subject,
+		Key:         existing.Key,
+		Scope:       existing.Scope,
+		RequestHash: existing.RequestHash,
+		StatusCode:  existing.StatusCode,
+		Body:        existing.ResponseBody,
+		ExpiresAt:   existing.ExpiresAt,
+		LockedUntil: existing.LockedUntil,
+	}, nil
+}
+
+func (s *IdempotencyStore) Complete(ctx context.Context, subject, key string, statusCode int, body []byte) error {
+	err := s.db.WithContext(ctx).
+		Model(&entity.IdempotencyKeyEntity{}).
+		Where("subject = ? AND idempotency_key = ?", subject, key).
+		Updates(map[string]any{
+			"status_code":   statusCode,
+			"response_body": body,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (s *IdempotencyStore) Release(ctx context.Context, subject, key string) error {
+	err := s.db.WithContext(ctx).
+		Where("subject = ? AND idempotency_key = ? AND status_code = 0", subject, key).
+		Delete(&entity.IdempotencyKeyEntity{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kiin21/go-rest/pkg/utils"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/infrastructure/persistence/entity"
+	"gorm.io/gorm"
+)
+
+// nameSearchBackfillBatch is how many starters BackfillNameSearch reads at a time.
+const nameSearchBackfillBatch = 500
+
+// BackfillNameSearch recomputes name_search for every starter, deleted ones included, with the
+// folding applied on every write. Rows already in sync are left alone, so it can be run again
+// safely. It returns the number of rows it updated.
+func BackfillNameSearch(ctx context.Context, db *gorm.DB) (int, error) {
+	updated := 0
+	var lastID int64
+	for {
+		var starters []entity.StarterEntity
+		err := db.WithContext(ctx).
+			Select("id", "name", "name_search").
+			Where("id > ?", lastID).
+			Order("id").
+			Limit(nameSearchBackfillBatch).
+			Find(&starters).Error
+		if err != nil {
+			return updated, fmt.Errorf("failed to load starters after id %d: %w", lastID, err)
+		}
+		if len(starters) == 0 {
+			return updated, nil
+		}
+
+		for _, starter := range starters {
+			nameSearch := utils.NormalizeSearchText(starter.Name)
+			if nameSearch == starter.NameSearch {
+				continue
+			}
+			// UpdateColumn leaves updated_at alone: the starter itself has not changed
+			err := db.WithContext(ctx).
+				Model(&entity.StarterEntity{}).
+				Where("id = ?", starter.ID).
+				UpdateColumn("name_search", nameSearch).Error
+			if err != nil {
+				return updated, fmt.Errorf("failed to update name_search of starter %d: %w", starter.ID, err)
+			}
+			updated++
+		}
+		lastID = starters[len(starters)-1].ID
+	}
+}
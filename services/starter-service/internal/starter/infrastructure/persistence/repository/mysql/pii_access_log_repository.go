@@ -0,0 +1,48 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+	repo "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/infrastructure/persistence/entity"
+	"gorm.io/gorm"
+)
+
+type PIIAccessLogRepository struct {
+	db *gorm.DB
+}
+
+func NewPIIAccessLogRepository(db *gorm.DB) repo.PIIAccessLogRepository {
+	return &PIIAccessLogRepository{db: db}
+}
+
+func (r *PIIAccessLogRepository) SaveAll(ctx context.Context, logs []*model.PIIAccessLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	entities := make([]*entity.PIIAccessLogEntity, len(logs))
+	for i, entry := range logs {
+		entities[i] = &entity.PIIAccessLogEntity{
+			ViewerDomain:  entry.ViewerDomain,
+			StarterID:     entry.StarterID,
+			StarterDomain: entry.StarterDomain,
+			Fields:        strings.Join(entry.Fields, ","),
+			Reason:        string(entry.Reason),
+			Route:         entry.Route,
+			AccessedAt:    entry.AccessedAt,
+		}
+	}
+
+	if err := r.db.WithContext(ctx).Create(&entities).Error; err != nil {
+		return fmt.Errorf("failed to save PII access logs: %w", err)
+	}
+
+	for i := range logs {
+		logs[i].ID = entities[i].ID
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+	repo "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/infrastructure/persistence/entity"
+	"gorm.io/gorm"
+)
+
+type SearchDeadLetterRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchDeadLetterRepository(db *gorm.DB) repo.SearchDeadLetterRepository {
+	return &SearchDeadLetterRepository{db: db}
+}
+
+func (r *SearchDeadLetterRepository) SaveAll(ctx context.Context, letters []*model.SearchDeadLetter) error {
+	if len(letters) == 0 {
+		return nil
+	}
+
+	entities := make([]*entity.SearchDeadLetterEntity, len(letters))
+	for i, letter := range letters {
+		entities[i] = &entity.SearchDeadLetterEntity{
+			StarterID:  letter.StarterID,
+			StatusCode: letter.StatusCode,
+			ErrorType:  letter.ErrorType,
+			Reason:     letter.Reason,
+			Attempts:   letter.Attempts,
+		}
+	}
+
+	if err := r.db.WithContext(ctx).Create(&entities).Error; err != nil {
+		return fmt.Errorf("failed to save search dead letters: %w", err)
+	}
+
+	for i := range letters {
+		letters[i].ID = entities[i].ID
+		letters[i].CreatedAt = entities[i].CreatedAt
+	}
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kiin21/go-rest/pkg/httputil"
+	"github.com/kiin21/go-rest/pkg/utils"
 	starterquery "github.com/kiin21/go-rest/services/starter-service/internal/starter/application/dto/starter/query"
 	sharedDomain "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/error"
 	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
@@ -57,6 +59,27 @@ func (r *StarterRepository) FindByDomain(ctx context.Context, domain string) (*m
 
 	return r.toModel(&starterEntity)
 }
+func (r *StarterRepository) FindByDomains(ctx context.Context, domains []string) ([]*model.Starter, error) {
+	var starterEntities []entity.StarterEntity
+	err := r.db.WithContext(ctx).
+		Where("domain IN ? AND deleted_at IS NULL", domains).
+		Find(&starterEntities).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find starters by domains: %w", err)
+	}
+
+	starters := make([]*model.Starter, 0, len(starterEntities))
+	for _, starterEntity := range starterEntities {
+		starter, err := r.toModel(&starterEntity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert starterEntity to model: %w", err)
+		}
+		starters = append(starters, starter)
+	}
+
+	return starters, nil
+}
+
 func (r *StarterRepository) SearchByKeyword(ctx context.Context, listStarterQuery *starterquery.ListStartersQuery) ([]*model.Starter, int64, error) {
 	query := r.db.WithContext(ctx).Model(&entity.StarterEntity{}).Where("starters.deleted_at IS NULL")
 
@@ -68,7 +91,13 @@ func (r *StarterRepository) SearchByKeyword(ctx context.Context, listStarterQuer
 		case "domain":
 			query = query.Where("starters.domain LIKE ?", searchPattern)
 		case "fullname":
-			query = query.Where("starters.name LIKE ?", searchPattern)
+			query = applyNameSearch(query, listStarterQuery.Keyword)
+		case "email":
+			query = query.Where("starters.email LIKE ?", searchPattern)
+		case "phone":
+			query = applyPhoneSearch(query, listStarterQuery.Keyword)
+		case "job_title":
+			query = query.Where("starters.job_title LIKE ?", searchPattern)
 		case "dept_name":
 			query = query.Joins("LEFT JOIN departments ON departments.id = starters.department_id AND departments.deleted_at IS NULL").
 				Where("departments.full_name LIKE ? OR departments.shortname LIKE ?", searchPattern, searchPattern)
@@ -79,18 +108,32 @@ func (r *StarterRepository) SearchByKeyword(ctx context.Context, listStarterQuer
 		}
 	}
 
-	// Count total
+	query = applyFilter(query, listStarterQuery.Filter)
+
+	pagination := listStarterQuery.Pagination
 	var total int64
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
+	if pagination.IsCursorMode() {
+		// Keyset pagination: continue after the cursor and fetch one extra row to detect the next page
+		var err error
+		query, err = applyCursor(query, pagination.Cursor, listStarterQuery.SortBy, listStarterQuery.SortOrder)
+		if err != nil {
+			return nil, 0, err
+		}
+		query = r.applySort(query, listStarterQuery.SortBy, listStarterQuery.SortOrder).
+			Limit(pagination.GetLimit() + 1)
+	} else {
+		// Count total
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
 
-	query = r.applySort(query, listStarterQuery.SortBy, listStarterQuery.SortOrder)
+		query = r.applySort(query, listStarterQuery.SortBy, listStarterQuery.SortOrder)
 
-	// Apply pagination
-	offset := listStarterQuery.Pagination.GetOffset()
-	limit := listStarterQuery.Pagination.GetLimit()
-	query = query.Offset(offset).Limit(limit)
+		// Apply pagination
+		offset := pagination.GetOffset()
+		limit := pagination.GetLimit()
+		query = query.Offset(offset).Limit(limit)
+	}
 
 	var models []entity.StarterEntity
 	if err := query.Select("starters.*").Find(&models).Error; err != nil {
@@ -156,23 +199,128 @@ func (r *StarterRepository) SoftDelete(ctx context.Context, domain string) (*mod
 	return r.toModel(&starterEntity)
 }
 
+func (r *StarterRepository) FindByFilter(ctx context.Context, filter *model.StarterListFilter, limit int) ([]*model.Starter, error) {
+	var starterEntities []entity.StarterEntity
+	query := r.db.WithContext(ctx).Model(&entity.StarterEntity{}).Where("starters.deleted_at IS NULL")
+	err := applyFilter(query, filter).
+		Order("starters.id ASC").
+		Limit(limit).
+		Find(&starterEntities).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find starters by filter: %w", err)
+	}
+
+	starters := make([]*model.Starter, 0, len(starterEntities))
+	for _, starterEntity := range starterEntities {
+		starter, err := r.toModel(&starterEntity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert starterEntity to model: %w", err)
+		}
+		starters = append(starters, starter)
+	}
+
+	return starters, nil
+}
+
+func (r *StarterRepository) UpdateAll(ctx context.Context, starters []*model.Starter) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, starter := range starters {
+			if err := tx.Save(r.toEntity(starter)).Error; err != nil {
+				return fmt.Errorf("failed to update starter %s: %w", starter.Domain, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *StarterRepository) SoftDeleteAll(ctx context.Context, ids []int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Model(&entity.StarterEntity{}).
+			Where("id IN ? AND deleted_at IS NULL", ids).
+			Update("deleted_at", time.Now()).Error
+	})
+}
+
+func (r *StarterRepository) ListAfterID(ctx context.Context, afterID int64, limit int) ([]*model.Starter, error) {
+	var starterEntities []entity.StarterEntity
+	err := r.db.WithContext(ctx).
+		Where("id > ? AND deleted_at IS NULL", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&starterEntities).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list starters after id %d: %w", afterID, err)
+	}
+
+	starters := make([]*model.Starter, 0, len(starterEntities))
+	for i := range starterEntities {
+		starter, err := r.toModel(&starterEntities[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert starterEntity to model: %w", err)
+		}
+		starters = append(starters, starter)
+	}
+
+	return starters, nil
+}
+
+func (r *StarterRepository) CountActive(ctx context.Context) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).
+		Model(&entity.StarterEntity{}).
+		Where("deleted_at IS NULL").
+		Count(&total).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count starters: %w", err)
+	}
+	return total, nil
+}
+
+func (r *StarterRepository) ListSyncStates(ctx context.Context, afterID int64, limit int) ([]*model.StarterSyncState, error) {
+	var starterEntities []entity.StarterEntity
+	err := r.db.WithContext(ctx).
+		Select("id", "domain", "updated_at", "deleted_at").
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&starterEntities).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list starter sync states: %w", err)
+	}
+
+	states := make([]*model.StarterSyncState, 0, len(starterEntities))
+	for _, e := range starterEntities {
+		states = append(states, &model.StarterSyncState{
+			ID:        e.ID,
+			Domain:    e.Domain,
+			UpdatedAt: e.UpdatedAt,
+			DeletedAt: e.DeletedAt,
+		})
+	}
+
+	return states, nil
+}
+
 func (r *StarterRepository) toModel(e *entity.StarterEntity) (*model.Starter, error) {
 	return model.Rehydrate(e.ID, e.Domain, e.Name, e.Email, e.Mobile, e.WorkPhone, e.JobTitle, e.DepartmentID, e.LineManagerID, e.CreatedAt, e.UpdatedAt)
 }
 
 func (r *StarterRepository) toEntity(starter *model.Starter) *entity.StarterEntity {
 	return &entity.StarterEntity{
-		ID:            starter.ID,
-		Domain:        starter.Domain,
-		Name:          starter.Name,
-		Email:         starter.Email.Value(),
-		Mobile:        starter.Mobile,
-		WorkPhone:     starter.WorkPhone,
-		JobTitle:      starter.JobTitle,
-		DepartmentID:  starter.DepartmentID,
-		LineManagerID: starter.LineManagerID,
-		CreatedAt:     starter.CreatedAt,
-		UpdatedAt:     starter.UpdatedAt,
+		ID:              starter.ID,
+		Domain:          starter.Domain,
+		Name:            starter.Name,
+		NameSearch:      utils.NormalizeSearchText(starter.Name),
+		Email:           starter.Email.Value(),
+		Mobile:          starter.Mobile,
+		MobileSearch:    utils.NormalizePhone(starter.Mobile),
+		WorkPhone:       starter.WorkPhone,
+		WorkPhoneSearch: utils.NormalizePhone(starter.WorkPhone),
+		JobTitle:        starter.JobTitle,
+		DepartmentID:    starter.DepartmentID,
+		LineManagerID:   starter.LineManagerID,
+		CreatedAt:       starter.CreatedAt,
+		UpdatedAt:       starter.UpdatedAt,
 	}
 }
 
@@ -183,7 +331,106 @@ func (r *StarterRepository) applySort(query *gorm.DB, sortBy, sortOrder string)
 		direction = "asc"
 	}
 
-	return query.Order(fmt.Sprintf("%s %s", column, direction))
+	query = query.Order(fmt.Sprintf("%s %s", column, direction))
+	// Break ties on id so that the order is total, which keyset pagination relies on
+	if column != "starters.id" {
+		query = query.Order(fmt.Sprintf("starters.id %s", direction))
+	}
+	return query
+}
+
+// applyCursor keeps the rows that come after the cursor in the list order: a greater sort
+// key, or the same key and a greater id (smaller for descending order).
+func applyCursor(query *gorm.DB, cursor *httputil.Cursor, sortBy, sortOrder string) (*gorm.DB, error) {
+	if cursor.IsFirstPage() {
+		return query, nil
+	}
+
+	id, err := cursor.Int64ID()
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor id: %w", err)
+	}
+
+	op := ">"
+	if strings.ToLower(sortOrder) == "desc" {
+		op = "<"
+	}
+
+	column := mapStarterSortColumn(sortBy)
+	if column == "starters.id" {
+		return query.Where("starters.id "+op+" ?", id), nil
+	}
+
+	var value any = cursor.Value
+	if column == "starters.created_at" {
+		if value, err = cursor.TimeValue(); err != nil {
+			return nil, fmt.Errorf("invalid cursor value: %w", err)
+		}
+	}
+
+	return query.Where(
+		fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND starters.id %[2]s ?))", column, op),
+		value, value, id,
+	), nil
+}
+
+// applyNameSearch matches every keyword token against the folded name_search column,
+// so "Nguyen Van A", "nguyễn văn a" and "A Nguyen" all find the same starter.
+func applyNameSearch(query *gorm.DB, keyword string) *gorm.DB {
+	for _, token := range strings.Fields(utils.NormalizeSearchText(keyword)) {
+		query = query.Where("starters.name_search LIKE ?", "%"+token+"%")
+	}
+	return query
+}
+
+// applyPhoneSearch matches the keyword digits against the normalized mobile and work phone
+// columns, so formatting differences ("0912 345 678" vs "0912-345-678") do not matter.
+// A keyword without any digit matches nothing.
+func applyPhoneSearch(query *gorm.DB, keyword string) *gorm.DB {
+	digits := utils.NormalizePhone(keyword)
+	if digits == "" {
+		return query.Where("1 = 0")
+	}
+	pattern := "%" + digits + "%"
+	return query.Where("starters.mobile_search LIKE ? OR starters.work_phone_search LIKE ?", pattern, pattern)
+}
+
+// applyFilter compiles every filter condition to a WHERE clause. business_unit_id goes
+// through the starter's department; "ne" keeps rows where a nullable column is NULL.
+func applyFilter(query *gorm.DB, filter *model.StarterListFilter) *gorm.DB {
+	if filter.IsEmpty() {
+		return query
+	}
+
+	for _, condition := range filter.Conditions {
+		column := "starters." + condition.Field.Name
+		if condition.Field.Name == "business_unit_id" {
+			column = "(SELECT departments.business_unit_id FROM departments WHERE departments.id = starters.department_id)"
+		}
+
+		switch condition.Operator {
+		case model.FilterEq:
+			query = query.Where(column+" = ?", condition.Values[0])
+		case model.FilterNe:
+			if condition.Field.Nullable {
+				query = query.Where("("+column+" <> ? OR "+column+" IS NULL)", condition.Values[0])
+			} else {
+				query = query.Where(column+" <> ?", condition.Values[0])
+			}
+		case model.FilterIn:
+			query = query.Where(column+" IN ?", condition.Values)
+		case model.FilterGt:
+			query = query.Where(column+" > ?", condition.Values[0])
+		case model.FilterGte:
+			query = query.Where(column+" >= ?", condition.Values[0])
+		case model.FilterLt:
+			query = query.Where(column+" < ?", condition.Values[0])
+		case model.FilterLte:
+			query = query.Where(column+" <= ?", condition.Values[0])
+		}
+	}
+
+	return query
 }
 
 func mapStarterSortColumn(sortBy string) string {
@@ -6,28 +6,35 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
+	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/kiin21/go-rest/pkg/httputil"
+	"github.com/kiin21/go-rest/pkg/utils"
 	starterquery "github.com/kiin21/go-rest/services/starter-service/internal/starter/application/dto/starter/query"
 	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
 	repo "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/repository"
 )
 
 type ElasticsearchStarterRepository struct {
-	client *elasticsearch.Client
+	client      *elasticsearch.Client
+	bulkBackoff time.Duration
 }
 
 func NewElasticsearchStarterRepository(client *elasticsearch.Client) repo.StarterSearchRepository {
-	return &ElasticsearchStarterRepository{client: client}
+	return &ElasticsearchStarterRepository{client: client, bulkBackoff: bulkInitialBackoff}
 }
 
 func (r *ElasticsearchStarterRepository) Search(
 	ctx context.Context,
 	listStarterQuery *starterquery.ListStartersQuery,
-) ([]int64, int64, error) {
+	buildSearchQuery repo.SearchQueryBuilder,
+) ([]*model.StarterSearchHit, int64, error) {
 
 	// 1) Build query
 	esQuery := buildSearchQuery(listStarterQuery)
@@ -60,12 +67,33 @@ func (r *ElasticsearchStarterRepository) Search(
 	}
 	from := (page - 1) * limit
 
+	// Cursor mode continues after the last hit with search_after and fetches one extra
+	// hit to detect the next page
+	pagination := listStarterQuery.Pagination
+	if pagination.IsCursorMode() {
+		from, limit = 0, pagination.GetLimit()+1
+		if !pagination.Cursor.IsFirstPage() {
+			sort, _ := esQuery["sort"].([]interface{})
+			searchAfter, err := buildSearchAfter(pagination.Cursor, sort)
+			if err != nil {
+				return nil, 0, err
+			}
+			esQuery["search_after"] = searchAfter
+
+			var buf bytes.Buffer
+			if err := json.NewEncoder(&buf).Encode(esQuery); err != nil {
+				return nil, 0, fmt.Errorf("error encoding query: %w", err)
+			}
+			body = &buf
+		}
+	}
+
 	// Log query trước khi gọi ES
 	if esQuery != nil {
 		jsonBytes, _ := json.MarshalIndent(esQuery, "", "  ")
 		fmt.Printf("\n========== ES QUERY (BEFORE) ==========\n%s\n", string(jsonBytes))
 		fmt.Printf("Pagination: page=%d, limit=%d, from=%d\n", page, limit, from)
-		fmt.Println("=======================================\n")
+		fmt.Println("=======================================")
 	}
 
 	// 4) Gọi ES
@@ -73,7 +101,7 @@ func (r *ElasticsearchStarterRepository) Search(
 		r.client.Search.WithContext(ctx),
 		r.client.Search.WithIndex(starterIndexName),
 		r.client.Search.WithBody(body),
-		r.client.Search.WithTrackTotalHits(true),
+		r.client.Search.WithTrackTotalHits(!pagination.IsCursorMode()),
 		r.client.Search.WithFrom(from),
 		r.client.Search.WithSize(limit),
 	)
@@ -95,7 +123,9 @@ func (r *ElasticsearchStarterRepository) Search(
 
 	// 5) Parse response có kiểu rõ ràng
 	type hitSrc struct {
-		ID int64 `json:"id"`
+		ID        int64     `json:"id"`
+		Domain    string    `json:"domain"`
+		CreatedAt time.Time `json:"created_at"`
 	}
 	type esResp struct {
 		Hits struct {
@@ -113,8 +143,10 @@ func (r *ElasticsearchStarterRepository) Search(
 		return nil, 0, fmt.Errorf("error parsing response: %w", err)
 	}
 
+	hits := make([]*model.StarterSearchHit, 0, len(out.Hits.Hits))
 	ids := make([]int64, 0, len(out.Hits.Hits))
 	for _, h := range out.Hits.Hits {
+		hits = append(hits, &model.StarterSearchHit{ID: h.Source.ID, Domain: h.Source.Domain, CreatedAt: h.Source.CreatedAt})
 		ids = append(ids, h.Source.ID)
 	}
 
@@ -123,9 +155,9 @@ func (r *ElasticsearchStarterRepository) Search(
 	fmt.Printf("Total hits: %d\n", out.Hits.Total.Value)
 	fmt.Printf("Returned IDs count: %d\n", len(ids))
 	fmt.Printf("IDs: %v\n", ids)
-	fmt.Println("=============================================\n")
+	fmt.Println("=============================================")
 
-	return ids, out.Hits.Total.Value, nil
+	return hits, out.Hits.Total.Value, nil
 }
 
 func (r *ElasticsearchStarterRepository) IndexStarter(ctx context.Context, starter *model.StarterESDoc) error {
@@ -193,12 +225,154 @@ func (r *ElasticsearchStarterRepository) DeleteFromIndex(ctx context.Context, do
 	return nil
 }
 
-// BulkIndex indexes multiple starters in bulk (for initial indexing or reindexing)
-func (r *ElasticsearchStarterRepository) BulkIndex(ctx context.Context, starters []*model.StarterESDoc) error {
-	if len(starters) == 0 {
-		return nil
+// BulkDelete removes documents by id with a single delete-by-query.
+func (r *ElasticsearchStarterRepository) BulkDelete(ctx context.Context, ids []int64) error {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = strconv.FormatInt(id, 10)
 	}
 
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"ids": map[string]interface{}{
+				"values": values,
+			},
+		},
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return fmt.Errorf("error marshaling bulk delete query: %w", err)
+	}
+
+	refresh := true
+	req := esapi.DeleteByQueryRequest{
+		Index:   []string{starterIndexName},
+		Body:    bytes.NewReader(body),
+		Refresh: &refresh,
+	}
+
+	res, err := req.Do(ctx, r.client)
+	if err != nil {
+		return fmt.Errorf("error deleting documents: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	if res.IsError() && res.StatusCode != 404 {
+		return fmt.Errorf("error deleting documents: %s", res.String())
+	}
+
+	return nil
+}
+
+const (
+	bulkMaxAttempts    = 4
+	bulkInitialBackoff = 200 * time.Millisecond
+	bulkMaxBackoff     = 5 * time.Second
+)
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID     string `json:"_id"`
+		Status int    `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error,omitempty"`
+	} `json:"items"`
+}
+
+// BulkIndex indexes multiple starters in bulk (for initial indexing or reindexing).
+// Items rejected with a retryable status (429, 503) are resent with exponential backoff;
+// whatever is still rejected afterwards is returned as a failure instead of an error.
+func (r *ElasticsearchStarterRepository) BulkIndex(ctx context.Context, starters []*model.StarterESDoc) (*model.BulkIndexResult, error) {
+	result := &model.BulkIndexResult{}
+	pending := starters
+	backoff := r.bulkBackoff
+
+	for attempt := 1; len(pending) > 0; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return result, ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, bulkMaxBackoff)
+		}
+
+		lastAttempt := attempt == bulkMaxAttempts
+		status, resp, err := r.executeBulk(ctx, pending)
+		if err != nil {
+			return result, err
+		}
+
+		if isRetryableStatus(status) && !lastAttempt {
+			log.Printf("Bulk request rejected with status %d, retrying %d documents", status, len(pending))
+			continue
+		}
+		if status >= 300 {
+			return result, fmt.Errorf("error in bulk response: status %d", status)
+		}
+
+		byID := make(map[string]*model.StarterESDoc, len(pending))
+		for _, starter := range pending {
+			byID[strconv.FormatInt(starter.ID(), 10)] = starter
+		}
+
+		var retry []*model.StarterESDoc
+		for _, item := range resp.Items {
+			for _, res := range item {
+				starter, ok := byID[res.ID]
+				if !ok {
+					continue
+				}
+				delete(byID, res.ID)
+
+				switch {
+				case res.Status < 300:
+					result.Indexed++
+				case isRetryableStatus(res.Status) && !lastAttempt:
+					retry = append(retry, starter)
+				default:
+					failure := model.BulkIndexFailure{
+						StarterID:  starter.ID(),
+						StatusCode: res.Status,
+						Attempts:   attempt,
+					}
+					if res.Error != nil {
+						failure.ErrorType = res.Error.Type
+						failure.Reason = res.Error.Reason
+					}
+					result.Failures = append(result.Failures, failure)
+				}
+			}
+		}
+
+		// Documents missing from the response were not acknowledged
+		for _, starter := range pending {
+			if _, ok := byID[strconv.FormatInt(starter.ID(), 10)]; !ok {
+				continue
+			}
+			result.Failures = append(result.Failures, model.BulkIndexFailure{
+				StarterID: starter.ID(),
+				Reason:    "no result in bulk response",
+				Attempts:  attempt,
+			})
+		}
+
+		if len(retry) > 0 {
+			log.Printf("Bulk indexing: %d documents rejected with a retryable status, retrying", len(retry))
+		}
+		pending = retry
+	}
+
+	return result, nil
+}
+
+func (r *ElasticsearchStarterRepository) executeBulk(ctx context.Context, starters []*model.StarterESDoc) (int, *bulkResponse, error) {
 	var buf bytes.Buffer
 
 	for _, starter := range starters {
@@ -213,11 +387,11 @@ func (r *ElasticsearchStarterRepository) BulkIndex(ctx context.Context, starters
 		}
 
 		if err := json.NewEncoder(&buf).Encode(meta); err != nil {
-			return fmt.Errorf("error encoding meta: %w", err)
+			return 0, nil, fmt.Errorf("error encoding meta: %w", err)
 		}
 
 		if err := json.NewEncoder(&buf).Encode(doc); err != nil {
-			return fmt.Errorf("error encoding document: %w", err)
+			return 0, nil, fmt.Errorf("error encoding document: %w", err)
 		}
 	}
 
@@ -229,26 +403,105 @@ func (r *ElasticsearchStarterRepository) BulkIndex(ctx context.Context, starters
 
 	res, err := req.Do(ctx, r.client)
 	if err != nil {
-		return fmt.Errorf("error executing bulk: %w", err)
+		return 0, nil, fmt.Errorf("error executing bulk: %w", err)
 	}
 	defer func(Body io.ReadCloser) {
 		_ = Body.Close()
 	}(res.Body)
 
 	if res.IsError() {
-		return fmt.Errorf("error in bulk response: %s", res.String())
+		return res.StatusCode, nil, nil
 	}
 
-	return nil
+	var resp bulkResponse
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return 0, nil, fmt.Errorf("error parsing bulk response: %w", err)
+	}
+
+	return res.StatusCode, &resp, nil
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// ListIndexStates pages through the index by id using search_after, fetching only the
+// fields needed to compare documents with MySQL.
+func (r *ElasticsearchStarterRepository) ListIndexStates(
+	ctx context.Context,
+	afterID int64,
+	limit int,
+) ([]*model.StarterIndexState, error) {
+	esQuery := map[string]interface{}{
+		"query":        map[string]interface{}{"match_all": map[string]interface{}{}},
+		"sort":         []interface{}{map[string]interface{}{"id": map[string]interface{}{"order": "asc"}}},
+		"search_after": []interface{}{afterID},
+		"_source":      []string{"id", "domain", "updated_at"},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(esQuery); err != nil {
+		return nil, fmt.Errorf("error encoding query: %w", err)
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithContext(ctx),
+		r.client.Search.WithIndex(starterIndexName),
+		r.client.Search.WithBody(&buf),
+		r.client.Search.WithSize(limit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error listing index states: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	if res.IsError() {
+		b, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("elasticsearch error: %s", string(b))
+	}
+
+	var out struct {
+		Hits struct {
+			Hits []struct {
+				Source struct {
+					ID        int64      `json:"id"`
+					Domain    string     `json:"domain"`
+					UpdatedAt *time.Time `json:"updated_at"`
+				} `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("error parsing response: %w", err)
+	}
+
+	states := make([]*model.StarterIndexState, 0, len(out.Hits.Hits))
+	for _, h := range out.Hits.Hits {
+		states = append(states, &model.StarterIndexState{
+			ID:        h.Source.ID,
+			Domain:    h.Source.Domain,
+			UpdatedAt: h.Source.UpdatedAt,
+		})
+	}
+
+	return states, nil
 }
 
 // toDocument converts domain Starter to ES document
 func (r *ElasticsearchStarterRepository) toDocument(starter *model.StarterESDoc) *StarterDocument {
+	// Phones are stored as digits only so that search is independent of formatting
+	mobile := utils.NormalizePhone(starter.Mobile())
+	workPhone := utils.NormalizePhone(starter.WorkPhone())
+
 	// Build full text for search
 	fullText := strings.Join([]string{
 		strconv.FormatInt(starter.ID(), 10),
 		starter.Domain(),
 		starter.Name(),
+		starter.Email(),
+		starter.JobTitle(),
 		starter.DepartmentName(),
 		starter.BusinessUnitName(),
 	}, " ")
@@ -258,6 +511,9 @@ func (r *ElasticsearchStarterRepository) toDocument(starter *model.StarterESDoc)
 		strconv.FormatInt(starter.ID(), 10),
 		starter.Domain(),
 		starter.Name(),
+		starter.Email(),
+		mobile,
+		starter.JobTitle(),
 		starter.DepartmentName(),
 		starter.BusinessUnitName(),
 	}
@@ -266,10 +522,19 @@ func (r *ElasticsearchStarterRepository) toDocument(starter *model.StarterESDoc)
 		ID(starter.ID()).
 		Domain(starter.Domain()).
 		Name(starter.Name()).
+		Email(starter.Email()).
+		Mobile(mobile).
+		WorkPhone(workPhone).
+		JobTitle(starter.JobTitle()).
 		DepartmentName(starter.DepartmentName()).
 		BusinessUnitName(starter.BusinessUnitName()).
+		DepartmentID(starter.DepartmentID()).
+		BusinessUnitID(starter.BusinessUnitID()).
+		LineManagerID(starter.LineManagerID()).
 		FullText(fullText).
 		SearchTokens(tokens).
+		CreatedAt(starter.CreatedAt()).
+		UpdatedAt(starter.UpdatedAt()).
 		BuildPtr()
 }
 
@@ -314,110 +579,35 @@ func extractInt64(m map[string]interface{}, key string) (int64, error) {
 	}
 }
 
-func buildSearchQuery(q *starterquery.ListStartersQuery) map[string]interface{} {
-	if q == nil {
-		return nil
+// buildSearchAfter turns a pagination cursor into the sort values of the last hit returned,
+// one for each clause of the query's sort.
+func buildSearchAfter(cursor *httputil.Cursor, sort []interface{}) ([]interface{}, error) {
+	if len(sort) == 0 {
+		return nil, fmt.Errorf("cursor pagination needs a sorted query")
 	}
-
-	kw := strings.TrimSpace(q.Keyword)
-	if kw == "" {
-		return nil
-	}
-
-	// Determine search fields
-	field := mapSearchByToFieldName(q.SearchBy)
-	searchFields := getSearchFields(field)
-
-	// Build query
-	must := []any{
-		map[string]any{
-			"multi_match": map[string]any{
-				"query":     kw,
-				"type":      "best_fields",
-				"fuzziness": "AUTO",
-				"operator":  "and",
-				"fields":    searchFields,
-			},
-		},
-	}
-
-	// Build final query structure
-	es := map[string]any{
-		"query": map[string]any{
-			"bool": map[string]any{
-				"must": must,
-			},
-		},
-	}
-
-	// Add sorting if specified
-	if sort := buildSortClause(q.SortBy, q.SortOrder); len(sort) > 0 {
-		es["sort"] = sort
-	}
-
-	return es
-}
-
-// getSearchFields returns the appropriate fields based on the search type
-func getSearchFields(field string) []string {
-	switch field {
-	case "domain":
-		return []string{"domain"}
-	case "name":
-		return []string{"name"}
-	case "business_unit_name":
-		return []string{"business_unit_name"}
-	case "department_name":
-		return []string{"department_name"}
-	default:
-		return []string{"full_text"}
-	}
-}
-
-func mapSearchByToFieldName(searchBy string) string {
-	switch strings.ToLower(strings.TrimSpace(searchBy)) {
-	case "domain":
-		return "domain"
-	case "fullname", "name":
-		return "name"
-	case "dept_name":
-		return "department_name"
-	case "bu_name":
-		return "business_unit_name"
-	default:
-		return "" // Multi-field search
-	}
-}
-
-func buildSortClause(sortBy, sortOrder string) []interface{} {
-	field := mapSortFieldToESField(sortBy)
-	if field == "" {
-		return nil
-	}
-	order := strings.ToLower(strings.TrimSpace(sortOrder))
-	if order != "desc" {
-		order = "asc"
-	}
-	return []interface{}{
-		map[string]interface{}{
-			field: map[string]interface{}{"order": order},
-		},
-	}
-}
-
-func mapSortFieldToESField(sortBy string) string {
-	switch strings.ToLower(strings.TrimSpace(sortBy)) {
-	case "id":
-		return "id"
-	case "domain":
-		return "domain"
-	case "name", "fullname":
-		return "name"
-	case "dept_name":
-		return "department_name"
-	case "bu_name":
-		return "business_unit_name"
-	default:
-		return "id" // Default sort by id
+	id, err := cursor.Int64ID()
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor id: %w", err)
+	}
+
+	values := make([]interface{}, 0, len(sort))
+	for _, clause := range sort {
+		clause, _ := clause.(map[string]interface{})
+		for field := range clause {
+			switch field {
+			case "id":
+				values = append(values, id)
+			case "created_at":
+				// Dates sort on epoch milliseconds
+				t, err := cursor.TimeValue()
+				if err != nil {
+					return nil, fmt.Errorf("invalid cursor value: %w", err)
+				}
+				values = append(values, t.UnixMilli())
+			default:
+				values = append(values, cursor.Value)
+			}
+		}
 	}
+	return values, nil
 }
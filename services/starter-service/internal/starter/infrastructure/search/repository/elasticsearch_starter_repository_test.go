@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/kiin21/go-rest/pkg/httputil"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+)
+
+func TestBuildSearchAfter(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	sortOn := func(fields ...string) []interface{} {
+		sort := make([]interface{}, 0, len(fields))
+		for _, field := range fields {
+			sort = append(sort, map[string]interface{}{field: map[string]interface{}{"order": "asc"}})
+		}
+		return sort
+	}
+
+	tests := []struct {
+		name     string
+		sort     []interface{}
+		cursor   httputil.Cursor
+		expected []interface{}
+	}{
+		{"by id", sortOn("id"), httputil.Cursor{Value: "42", ID: "42"}, []interface{}{int64(42)}},
+		{"by domain", sortOn("domain.keyword", "id"), httputil.Cursor{Value: "user", ID: "42"}, []interface{}{"user", int64(42)}},
+		{"by created_at", sortOn("created_at", "id"), httputil.Cursor{Value: httputil.FormatCursorTime(createdAt), ID: "42"}, []interface{}{createdAt.UnixMilli(), int64(42)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := buildSearchAfter(&tt.cursor, tt.sort)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result) != len(tt.expected) {
+				t.Fatalf("buildSearchAfter() = %v; want %v", result, tt.expected)
+			}
+			for i := range tt.expected {
+				if result[i] != tt.expected[i] {
+					t.Errorf("buildSearchAfter() = %v; want %v", result, tt.expected)
+				}
+			}
+		})
+	}
+
+	if _, err := buildSearchAfter(&httputil.Cursor{ID: "abc"}, sortOn("id")); err == nil {
+		t.Error("expected error for a non-numeric cursor id")
+	}
+	if _, err := buildSearchAfter(&httputil.Cursor{ID: "42"}, nil); err == nil {
+		t.Error("expected error for a query without sort")
+	}
+}
+
+func TestToDocument(t *testing.T) {
+	starter, err := model.Rehydrate(7, "user", "User", "user@vng.com.vn", "0912 345 678", "+84 28 1234 5678", "Developer", nil, nil, time.Now(), time.Now())
+	if err != nil {
+		t.Fatalf("failed to build starter: %v", err)
+	}
+
+	doc := (&ElasticsearchStarterRepository{}).toDocument(model.NewStarterESDocFromStarter(starter, nil))
+
+	if doc.Email != "user@vng.com.vn" || doc.JobTitle != "Developer" {
+		t.Errorf("unexpected email/job title: %q %q", doc.Email, doc.JobTitle)
+	}
+	if doc.Mobile != "0912345678" || doc.WorkPhone != "02812345678" {
+		t.Errorf("expected normalized phones, got mobile=%q work_phone=%q", doc.Mobile, doc.WorkPhone)
+	}
+}
+
+// newBulkTestRepository points the repository at a fake Elasticsearch whose bulk endpoint
+// answers each request with the next handler; it returns the ids sent in every request.
+func newBulkTestRepository(t *testing.T, handlers ...func(ids []string) (int, map[string]int)) (*ElasticsearchStarterRepository, *[][]string) {
+	t.Helper()
+	var requests [][]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		var ids []string
+		scanner := bufio.NewScanner(r.Body)
+		for line := 0; scanner.Scan(); line++ {
+			if line%2 != 0 {
+				continue
+			}
+			var meta struct {
+				Index struct {
+					ID string `json:"_id"`
+				} `json:"index"`
+			}
+			if err := json.Unmarshal(scanner.Bytes(), &meta); err != nil {
+				t.Errorf("invalid bulk action line: %v", err)
+			}
+			ids = append(ids, meta.Index.ID)
+		}
+		requests = append(requests, ids)
+
+		if len(requests) > len(handlers) {
+			t.Errorf("unexpected bulk request #%d", len(requests))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		status, itemStatuses := handlers[len(requests)-1](ids)
+		if status >= 300 {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error":"rejected"}`))
+			return
+		}
+
+		resp := map[string]interface{}{"errors": false}
+		var items []map[string]interface{}
+		for _, id := range ids {
+			itemStatus, ok := itemStatuses[id]
+			if !ok {
+				itemStatus = http.StatusCreated
+			}
+			res := map[string]interface{}{"_id": id, "status": itemStatus}
+			if itemStatus >= 300 {
+				resp["errors"] = true
+				res["error"] = map[string]string{"type": "mapper_parsing_exception", "reason": "bad document"}
+			}
+			items = append(items, map[string]interface{}{"index": res})
+		}
+		resp["items"] = items
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{server.URL}})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return &ElasticsearchStarterRepository{client: client, bulkBackoff: time.Millisecond}, &requests
+}
+
+func newBulkTestDocs(ids ...int64) []*model.StarterESDoc {
+	docs := make([]*model.StarterESDoc, len(ids))
+	for i, id := range ids {
+		docs[i] = model.NewStarterESDocFromStarter(&model.Starter{ID: id, Domain: "user", Name: "User"}, nil)
+	}
+	return docs
+}
+
+func TestBulkIndexPartialFailures(t *testing.T) {
+	repo, requests := newBulkTestRepository(t,
+		func(ids []string) (int, map[string]int) {
+			return http.StatusOK, map[string]int{"2": http.StatusTooManyRequests, "3": http.StatusBadRequest}
+		},
+		func(ids []string) (int, map[string]int) {
+			return http.StatusOK, nil
+		},
+	)
+
+	result, err := repo.BulkIndex(context.Background(), newBulkTestDocs(1, 2, 3, 4))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Indexed != 3 {
+		t.Errorf("expected 3 indexed, got %d", result.Indexed)
+	}
+	if len(result.Failures) != 1 {
+		t.Fatalf("expected 1 failure, got %d", len(result.Failures))
+	}
+	failure := result.Failures[0]
+	if failure.StarterID != 3 || failure.StatusCode != http.StatusBadRequest || failure.ErrorType != "mapper_parsing_exception" || failure.Attempts != 1 {
+		t.Errorf("unexpected failure: %+v", failure)
+	}
+
+	if len(*requests) != 2 || len((*requests)[1]) != 1 || (*requests)[1][0] != "2" {
+		t.Errorf("expected only the throttled document to be retried, got %v", *requests)
+	}
+}
+
+func TestBulkIndexRetriesExhausted(t *testing.T) {
+	throttled := func(ids []string) (int, map[string]int) {
+		return http.StatusOK, map[string]int{"1": http.StatusServiceUnavailable}
+	}
+	handlers := make([]func(ids []string) (int, map[string]int), bulkMaxAttempts)
+	for i := range handlers {
+		handlers[i] = throttled
+	}
+	repo, requests := newBulkTestRepository(t, handlers...)
+
+	result, err := repo.BulkIndex(context.Background(), newBulkTestDocs(1, 2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Indexed != 1 {
+		t.Errorf("expected 1 indexed, got %d", result.Indexed)
+	}
+	if len(result.Failures) != 1 || result.Failures[0].StarterID != 1 || result.Failures[0].Attempts != bulkMaxAttempts {
+		t.Errorf("expected starter 1 to fail after %d attempts, got %+v", bulkMaxAttempts, result.Failures)
+	}
+	if len(*requests) != bulkMaxAttempts {
+		t.Errorf("expected %d bulk requests, got %d", bulkMaxAttempts, len(*requests))
+	}
+}
+
+func TestBulkIndexRetriesWholeRequest(t *testing.T) {
+	repo, requests := newBulkTestRepository(t,
+		func(ids []string) (int, map[string]int) {
+			return http.StatusTooManyRequests, nil
+		},
+		func(ids []string) (int, map[string]int) {
+			return http.StatusOK, nil
+		},
+	)
+
+	result, err := repo.BulkIndex(context.Background(), newBulkTestDocs(1, 2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Indexed != 2 || len(result.Failures) != 0 {
+		t.Errorf("expected all documents indexed, got indexed=%d failures=%d", result.Indexed, len(result.Failures))
+	}
+	if len(*requests) != 2 {
+		t.Errorf("expected 2 bulk requests, got %d", len(*requests))
+	}
+}
+
+func TestBulkIndexNonRetryableStatus(t *testing.T) {
+	repo, _ := newBulkTestRepository(t, func(ids []string) (int, map[string]int) {
+		return http.StatusBadRequest, nil
+	})
+
+	if _, err := repo.BulkIndex(context.Background(), newBulkTestDocs(1)); err == nil {
+		t.Error("expected error but got nil")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/esapi"
@@ -15,8 +16,9 @@ import (
 const starterIndexName = "starters"
 
 type IndexManager struct {
-	client      *elasticsearch.Client
-	mappingData []byte
+	client         *elasticsearch.Client
+	mappingData    []byte
+	mappingVersion int
 }
 
 //go:embed starters_mapping.json
@@ -24,11 +26,76 @@ var startersMapping []byte
 
 func NewIndexManager(client *elasticsearch.Client) (*IndexManager, error) {
 	// validate JSON
-	var tmp map[string]any
+	var tmp indexMappingMeta
 	if err := json.Unmarshal(startersMapping, &tmp); err != nil {
 		return nil, fmt.Errorf("invalid mapping JSON: %w", err)
 	}
-	return &IndexManager{client: client, mappingData: startersMapping}, nil
+	return &IndexManager{
+		client:         client,
+		mappingData:    startersMapping,
+		mappingVersion: tmp.Mappings.Meta.Version,
+	}, nil
+}
+
+type indexMappingMeta struct {
+	Mappings struct {
+		Meta struct {
+			Version int `json:"version"`
+		} `json:"_meta"`
+	} `json:"mappings"`
+}
+
+// EnsureIndex creates the index when missing and recreates it when its mapping
+// version is older than the embedded one (analyzers cannot be changed in place).
+// It reports whether the index was recreated and therefore needs a full reindex.
+func (im *IndexManager) EnsureIndex(ctx context.Context) (bool, error) {
+	version, exists, err := im.currentMappingVersion(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if exists && version == im.mappingVersion {
+		return false, nil
+	}
+
+	if exists {
+		log.Printf("Elasticsearch mapping version changed (%d -> %d), recreating index", version, im.mappingVersion)
+		if err := im.DeleteIndex(ctx); err != nil {
+			return false, err
+		}
+	}
+
+	if err := im.CreateIndex(ctx); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (im *IndexManager) currentMappingVersion(ctx context.Context) (int, bool, error) {
+	req := esapi.IndicesGetMappingRequest{
+		Index: []string{starterIndexName},
+	}
+
+	res, err := req.Do(ctx, im.client)
+	if err != nil {
+		return 0, false, fmt.Errorf("error getting index mapping: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return 0, false, nil
+	}
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return 0, false, fmt.Errorf("error getting index mapping: %s", string(body))
+	}
+
+	var result map[string]indexMappingMeta
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return 0, false, fmt.Errorf("error parsing mapping response: %w", err)
+	}
+
+	return result[starterIndexName].Mappings.Meta.Version, true, nil
 }
 
 func (im *IndexManager) CreateIndex(ctx context.Context) error {
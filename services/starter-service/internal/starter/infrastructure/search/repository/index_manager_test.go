@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStartersMappingNameSynonyms(t *testing.T) {
+	var mapping struct {
+		Settings struct {
+			Analysis struct {
+				Analyzer map[string]struct {
+					Filter []string `json:"filter"`
+				} `json:"analyzer"`
+				Filter map[string]struct {
+					Type     string   `json:"type"`
+					Synonyms []string `json:"synonyms"`
+				} `json:"filter"`
+			} `json:"analysis"`
+		} `json:"settings"`
+	}
+	if err := json.Unmarshal(startersMapping, &mapping); err != nil {
+		t.Fatalf("invalid mapping JSON: %v", err)
+	}
+	analysis := mapping.Settings.Analysis
+
+	// Variants are expanded in queries only: indexed prefixes of one spelling would otherwise
+	// match prefixes of the other
+	filters := analysis.Analyzer["vi_name_search_analyzer"].Filter
+	folding := slices.Index(filters, "asciifolding")
+	if synonyms := slices.Index(filters, "vi_name_synonyms"); folding < 0 || synonyms < folding {
+		t.Errorf("vi_name_search_analyzer filters = %v; want vi_name_synonyms after asciifolding", filters)
+	}
+	for name, analyzer := range analysis.Analyzer {
+		if name != "vi_name_search_analyzer" && slices.Contains(analyzer.Filter, "vi_name_synonyms") {
+			t.Errorf("%s filters = %v; want vi_name_synonyms in vi_name_search_analyzer only", name, analyzer.Filter)
+		}
+	}
+
+	rules := analysis.Filter["vi_name_synonyms"].Synonyms
+	if len(rules) == 0 {
+		t.Fatal("vi_name_synonyms has no rules")
+	}
+	seen := map[string]string{}
+	for _, rule := range rules {
+		terms := strings.Split(rule, ",")
+		if len(terms) < 2 {
+			t.Errorf("rule %q has a single spelling", rule)
+		}
+		for _, term := range terms {
+			term = strings.TrimSpace(term)
+			// Rules see tokens after lowercase and asciifolding, so they are written the same way
+			if term == "" || strings.ToLower(term) != term || strings.ContainsFunc(term, func(r rune) bool { return r > 'z' || r < 'a' }) {
+				t.Errorf("rule %q: %q is not a folded single word", rule, term)
+			}
+			// A spelling in two rules would chain unrelated names together
+			if other, ok := seen[term]; ok {
+				t.Errorf("%q is in both %q and %q", term, other, rule)
+			}
+			seen[term] = rule
+		}
+	}
+}
+
+// TestStartersMappingNameMatches runs folded names through the filters of the name field's
+// analyzers, as configured in the mapping, and checks which queries find which names.
+func TestStartersMappingNameMatches(t *testing.T) {
+	var mapping struct {
+		Settings struct {
+			Analysis struct {
+				Analyzer map[string]struct {
+					Filter []string `json:"filter"`
+				} `json:"analyzer"`
+				Filter map[string]struct {
+					MinGram  int      `json:"min_gram"`
+					MaxGram  int      `json:"max_gram"`
+					Synonyms []string `json:"synonyms"`
+				} `json:"filter"`
+			} `json:"analysis"`
+		} `json:"settings"`
+		Mappings struct {
+			Properties map[string]struct {
+				Analyzer       string `json:"analyzer"`
+				SearchAnalyzer string `json:"search_analyzer"`
+			} `json:"properties"`
+		} `json:"mappings"`
+	}
+	if err := json.Unmarshal(startersMapping, &mapping); err != nil {
+		t.Fatalf("invalid mapping JSON: %v", err)
+	}
+	analysis := mapping.Settings.Analysis
+	name := mapping.Mappings.Properties["name"]
+
+	// analyze supports the filters the name analyzers use; text is already lowercase and folded
+	analyze := func(analyzer, text string) []string {
+		tokens := strings.Fields(text)
+		for _, filter := range analysis.Analyzer[analyzer].Filter {
+			var out []string
+			switch filter {
+			case "lowercase", "asciifolding":
+				out = tokens
+			case "vi_name_synonyms":
+				for _, token := range tokens {
+					out = append(out, token)
+					for _, rule := range analysis.Filter[filter].Synonyms {
+						terms := strings.Split(rule, ",")
+						for i := range terms {
+							terms[i] = strings.TrimSpace(terms[i])
+						}
+						if slices.Contains(terms, token) {
+							out = append(out, slices.DeleteFunc(terms, func(term string) bool { return term == token })...)
+						}
+					}
+				}
+			case "starter_edge_ngram":
+				gram := analysis.Filter[filter]
+				for _, token := range tokens {
+					for n := gram.MinGram; n <= min(gram.MaxGram, len(token)); n++ {
+						out = append(out, token[:n])
+					}
+				}
+			default:
+				t.Fatalf("%s: filter %s is not supported by the test", analyzer, filter)
+			}
+			tokens = out
+		}
+		return tokens
+	}
+
+	tests := []struct {
+		query   string
+		indexed string
+		want    bool
+	}{
+		{"huynh", "hoang van an", true},
+		{"hoang", "huynh van an", true},
+		{"vo", "vu thi mai", true},
+		{"hoa", "hoang van an", true},
+		// A prefix is matched as typed, not through the variants of the names it starts
+		{"hu", "hoang van an", false},
+		{"ho", "huynh van an", false},
+		{"phuo", "phuc nguyen", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query+" in "+tt.indexed, func(t *testing.T) {
+			indexed := analyze(name.Analyzer, tt.indexed)
+			matched := slices.ContainsFunc(analyze(name.SearchAnalyzer, tt.query), func(token string) bool {
+				return slices.Contains(indexed, token)
+			})
+			if matched != tt.want {
+				t.Errorf("matched = %v; want %v", matched, tt.want)
+			}
+		})
+	}
+}
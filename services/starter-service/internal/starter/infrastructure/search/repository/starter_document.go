@@ -1,15 +1,29 @@
 package repository
 
+import "time"
+
 // StarterDocument mirrors the Elasticsearch document structure optimized for search.
 type StarterDocument struct {
 	ID               int64  `json:"id"`
 	Domain           string `json:"domain"`
 	Name             string `json:"name"`
+	Email            string `json:"email,omitempty"`
+	Mobile           string `json:"mobile,omitempty"`
+	WorkPhone        string `json:"work_phone,omitempty"`
+	JobTitle         string `json:"job_title,omitempty"`
 	DepartmentName   string `json:"department_name,omitempty"`
 	BusinessUnitName string `json:"business_unit_name,omitempty"`
 
+	DepartmentID   *int64 `json:"department_id,omitempty"`
+	BusinessUnitID *int64 `json:"business_unit_id,omitempty"`
+	LineManagerID  *int64 `json:"line_manager_id,omitempty"`
+
 	FullText     string   `json:"full_text"`
 	SearchTokens []string `json:"search_tokens"`
+
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt mirrors starters.updated_at so drift against MySQL can be detected.
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // StarterDocumentBuilder ==========================Builder==========================
@@ -42,6 +56,30 @@ func (b *StarterDocumentBuilder) Name(name string) *StarterDocumentBuilder {
 	return b
 }
 
+// Email sets the Email field.
+func (b *StarterDocumentBuilder) Email(email string) *StarterDocumentBuilder {
+	b.doc.Email = email
+	return b
+}
+
+// Mobile sets the Mobile field.
+func (b *StarterDocumentBuilder) Mobile(mobile string) *StarterDocumentBuilder {
+	b.doc.Mobile = mobile
+	return b
+}
+
+// WorkPhone sets the WorkPhone field.
+func (b *StarterDocumentBuilder) WorkPhone(workPhone string) *StarterDocumentBuilder {
+	b.doc.WorkPhone = workPhone
+	return b
+}
+
+// JobTitle sets the JobTitle field.
+func (b *StarterDocumentBuilder) JobTitle(jobTitle string) *StarterDocumentBuilder {
+	b.doc.JobTitle = jobTitle
+	return b
+}
+
 func (b *StarterDocumentBuilder) DepartmentName(departmentName string) *StarterDocumentBuilder {
 	b.doc.DepartmentName = departmentName
 	return b
@@ -53,6 +91,24 @@ func (b *StarterDocumentBuilder) BusinessUnitName(businessUnitName string) *Star
 	return b
 }
 
+// DepartmentID sets the DepartmentID field.
+func (b *StarterDocumentBuilder) DepartmentID(departmentID *int64) *StarterDocumentBuilder {
+	b.doc.DepartmentID = departmentID
+	return b
+}
+
+// BusinessUnitID sets the BusinessUnitID field.
+func (b *StarterDocumentBuilder) BusinessUnitID(businessUnitID *int64) *StarterDocumentBuilder {
+	b.doc.BusinessUnitID = businessUnitID
+	return b
+}
+
+// LineManagerID sets the LineManagerID field.
+func (b *StarterDocumentBuilder) LineManagerID(lineManagerID *int64) *StarterDocumentBuilder {
+	b.doc.LineManagerID = lineManagerID
+	return b
+}
+
 // FullText sets the FullText field.
 func (b *StarterDocumentBuilder) FullText(fullText string) *StarterDocumentBuilder {
 	b.doc.FullText = fullText
@@ -71,6 +127,18 @@ func (b *StarterDocumentBuilder) AddSearchToken(token string) *StarterDocumentBu
 	return b
 }
 
+// CreatedAt sets the CreatedAt field.
+func (b *StarterDocumentBuilder) CreatedAt(createdAt time.Time) *StarterDocumentBuilder {
+	b.doc.CreatedAt = createdAt
+	return b
+}
+
+// UpdatedAt sets the UpdatedAt field.
+func (b *StarterDocumentBuilder) UpdatedAt(updatedAt time.Time) *StarterDocumentBuilder {
+	b.doc.UpdatedAt = updatedAt
+	return b
+}
+
 // Build returns the constructed StarterDocument.
 func (b *StarterDocumentBuilder) Build() StarterDocument {
 	return b.doc
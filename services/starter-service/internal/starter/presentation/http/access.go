@@ -0,0 +1,102 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
+	"github.com/kiin21/go-rest/pkg/httputil"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/application/service"
+	sharedDomain "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/error"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+	domainService "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/service"
+	starterdto "github.com/kiin21/go-rest/services/starter-service/internal/starter/presentation/http/dto/starter"
+)
+
+// authorizeDepartments lets org admins through and limits leaders to departments in their
+// own subtree. Routes decide who gets this far; this only narrows what leaders may touch.
+func authorizeDepartments(ctx *gin.Context, access *domainService.DepartmentAccessService, departmentIDs ...int64) error {
+	principal := auth.PrincipalFrom(ctx)
+	if principal.HasRole(auth.RoleOrgAdmin) {
+		return nil
+	}
+	if !principal.HasRole(auth.RoleLeader) {
+		return errForbidden("you do not have permission to perform this action")
+	}
+
+	for _, id := range departmentIDs {
+		allowed, err := access.CanManageDepartment(ctx, principal.Subject, id)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return errForbidden("leaders can only manage departments they lead and the departments below them")
+		}
+	}
+	return nil
+}
+
+// authorizeStarter lets HR admins through and limits leaders to starters in their own subtree.
+// A leader moving the starter to departmentID must manage that department too.
+func authorizeStarter(ctx *gin.Context, access *domainService.DepartmentAccessService, domain string, departmentID *int64) error {
+	principal := auth.PrincipalFrom(ctx)
+	if principal.HasRole(auth.RoleHRAdmin) {
+		return nil
+	}
+	if !principal.HasRole(auth.RoleLeader) {
+		return errForbidden("you do not have permission to perform this action")
+	}
+
+	allowed, err := access.CanManageStarter(ctx, principal.Subject, domain)
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		// Leave the not found answer to the service, as for any other caller
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errForbidden("leaders can only manage starters in the departments they lead")
+	}
+
+	if departmentID != nil {
+		return authorizeDepartments(ctx, access, *departmentID)
+	}
+	return nil
+}
+
+// authorizeContactSearch keeps searching and filtering on contact details to HR admins, the only
+// callers who see them in full for everyone; the matches would otherwise reveal masked values.
+func authorizeContactSearch(ctx *gin.Context, searchBy string, filter *model.StarterListFilter) error {
+	if auth.PrincipalFrom(ctx).HasRole(auth.RoleHRAdmin) {
+		return nil
+	}
+	if searchBy == "email" || searchBy == "phone" {
+		return errForbidden("only HR admins can search by email or phone")
+	}
+	if filter.Uses(model.PIIFieldEmail) {
+		return errForbidden("only HR admins can filter on email")
+	}
+	return nil
+}
+
+func errForbidden(message string) error {
+	return httputil.NewAPIError(http.StatusForbidden, "Forbidden", message)
+}
+
+// newPIIFilter prepares the masking of contact details for the caller of this request;
+// fields is the response field selection, nil for every field.
+func newPIIFilter(ctx *gin.Context, piiAccess *service.PIIAccessService, fields []string) (*starterdto.PIIFilter, error) {
+	principal := auth.PrincipalFrom(ctx)
+	if principal == nil {
+		// Without a caller there is nobody to show contact details to
+		return nil, nil
+	}
+
+	viewer, err := piiAccess.ViewerFor(ctx, principal.Subject, principal.HasRole(auth.RoleHRAdmin))
+	if err != nil {
+		return nil, err
+	}
+	return starterdto.NewPIIFilter(viewer, ctx.Request.Method+" "+ctx.FullPath(), fields), nil
+}
@@ -30,7 +30,8 @@ type BusinessUnitDetailResponse struct {
 }
 
 // FromBusinessUnitWithDetails converts a detailed domain document to a detailed response DTO.
-func FromBusinessUnitWithDetails(unit *model.BusinessUnitWithDetails) *BusinessUnitDetailResponse {
+// The leader's contact details are masked or left out according to pii.
+func FromBusinessUnitWithDetails(unit *model.BusinessUnitWithDetails, pii shared.ContactFilter) *BusinessUnitDetailResponse {
 	if unit == nil {
 		return nil
 	}
@@ -50,24 +51,16 @@ func FromBusinessUnitWithDetails(unit *model.BusinessUnitWithDetails) *BusinessU
 		}
 	}
 
-	if unit.Leader != nil {
-		resp.Leader = &shared.LineManagerNested{
-			ID:       unit.Leader.ID,
-			Domain:   unit.Leader.Domain,
-			Name:     unit.Leader.Name,
-			Email:    unit.Leader.Email,
-			JobTitle: unit.Leader.JobTitle,
-		}
-	}
+	resp.Leader = shared.NewLineManagerNested(unit.Leader, pii)
 
 	return resp
 }
 
 // FromBusinessUnitsWithDetails converts a slice of detailed business units to DTOs.
-func FromBusinessUnitsWithDetails(units []*model.BusinessUnitWithDetails) []*BusinessUnitDetailResponse {
+func FromBusinessUnitsWithDetails(units []*model.BusinessUnitWithDetails, pii shared.ContactFilter) []*BusinessUnitDetailResponse {
 	responses := make([]*BusinessUnitDetailResponse, len(units))
 	for i, unit := range units {
-		responses[i] = FromBusinessUnitWithDetails(unit)
+		responses[i] = FromBusinessUnitWithDetails(unit, pii)
 	}
 	return responses
 }
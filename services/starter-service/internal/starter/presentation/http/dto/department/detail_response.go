@@ -19,7 +19,9 @@ type DepartmentDetailResponse struct {
 	UpdatedAt        time.Time                  `json:"updated_at"`
 }
 
-func FromDomainWithDetails(dept *model.DepartmentWithDetails) *DepartmentDetailResponse {
+// FromDomainWithDetails converts a department with its relations; the leader's contact
+// details are masked or left out according to pii.
+func FromDomainWithDetails(dept *model.DepartmentWithDetails, pii shared.ContactFilter) *DepartmentDetailResponse {
 	response := &DepartmentDetailResponse{
 		ID:        dept.ID,
 		FullName:  dept.FullName,
@@ -36,15 +38,7 @@ func FromDomainWithDetails(dept *model.DepartmentWithDetails) *DepartmentDetailR
 		}
 	}
 
-	if dept.Leader != nil {
-		response.Leader = &shared.LineManagerNested{
-			ID:       dept.Leader.ID,
-			Domain:   dept.Leader.Domain,
-			Name:     dept.Leader.Name,
-			Email:    dept.Leader.Email,
-			JobTitle: dept.Leader.JobTitle,
-		}
-	}
+	response.Leader = shared.NewLineManagerNested(dept.Leader, pii)
 
 	if dept.ParentDepartment != nil {
 		response.ParentDepartment = &shared.DepartmentNested{
@@ -68,10 +62,10 @@ func FromDomainWithDetails(dept *model.DepartmentWithDetails) *DepartmentDetailR
 	return response
 }
 
-func FromDomainsWithDetails(depts []*model.DepartmentWithDetails) []*DepartmentDetailResponse {
+func FromDomainsWithDetails(depts []*model.DepartmentWithDetails, pii shared.ContactFilter) []*DepartmentDetailResponse {
 	responses := make([]*DepartmentDetailResponse, len(depts))
 	for i, dept := range depts {
-		responses[i] = FromDomainWithDetails(dept)
+		responses[i] = FromDomainWithDetails(dept, pii)
 	}
 	return responses
 }
@@ -9,6 +9,9 @@ type ListDepartmentsRequest struct {
 	BusinessUnitID *int64 `form:"business_unit_id" binding:"omitempty,gt=0"`
 	Page           int    `form:"page" binding:"omitempty,min=1"`
 	Limit          int    `form:"limit" binding:"omitempty,min=1,max=100"`
+
+	// Cursor selects keyset pagination; send it empty for the first page
+	Cursor *string `form:"cursor"`
 }
 
 func (r *ListDepartmentsRequest) SetDefaults() {
@@ -23,12 +26,25 @@ func (r *ListDepartmentsRequest) SetDefaults() {
 	}
 }
 
-func (r *ListDepartmentsRequest) ToQuery() *query.ListDepartmentsQuery {
+func (r *ListDepartmentsRequest) ToQuery() (*query.ListDepartmentsQuery, error) {
+	var cursor *httputil.Cursor
+	if r.Cursor != nil {
+		// Departments are always listed by id
+		var err error
+		if cursor, err = httputil.ParseCursor(*r.Cursor, "id", "asc"); err != nil {
+			return nil, err
+		}
+		if _, err := cursor.Int64ID(); !cursor.IsFirstPage() && err != nil {
+			return nil, httputil.InvalidCursorError("cursor is malformed")
+		}
+	}
+
 	return &query.ListDepartmentsQuery{
 		BusinessUnitID: r.BusinessUnitID,
 		Pagination: httputil.ReqPagination{
-			Page:  &r.Page,
-			Limit: &r.Limit,
+			Page:   &r.Page,
+			Limit:  &r.Limit,
+			Cursor: cursor,
 		},
-	}
+	}, nil
 }
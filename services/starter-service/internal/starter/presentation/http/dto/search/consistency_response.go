@@ -0,0 +1,58 @@
+package search
+
+import (
+	"time"
+
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+)
+
+// ConsistencyResponse reports drift between MySQL starters and the search index.
+type ConsistencyResponse struct {
+	Consistent     bool      `json:"consistent"`
+	StartedAt      time.Time `json:"started_at"`
+	FinishedAt     time.Time `json:"finished_at"`
+	DurationMs     int64     `json:"duration_ms"`
+	DatabaseCount  int64     `json:"database_count"`
+	IndexCount     int64     `json:"index_count"`
+	DriftCount     int64     `json:"drift_count"`
+	Missing        int64     `json:"missing"`
+	Stale          int64     `json:"stale"`
+	Orphaned       int64     `json:"orphaned"`
+	RepairsEmitted int64     `json:"repairs_emitted"`
+	RepairsFailed  int64     `json:"repairs_failed"`
+	MissingIDs     []int64   `json:"missing_ids,omitempty"`
+	StaleIDs       []int64   `json:"stale_ids,omitempty"`
+	OrphanedIDs    []int64   `json:"orphaned_ids,omitempty"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// ConsistencyRequest selects between the cached report and a fresh run.
+type ConsistencyRequest struct {
+	Refresh bool `form:"refresh"`
+}
+
+// FromConsistencyReport converts a domain report to its response DTO.
+func FromConsistencyReport(report *model.SearchConsistencyReport) *ConsistencyResponse {
+	if report == nil {
+		return nil
+	}
+
+	return &ConsistencyResponse{
+		Consistent:     report.IsConsistent(),
+		StartedAt:      report.StartedAt,
+		FinishedAt:     report.FinishedAt,
+		DurationMs:     report.FinishedAt.Sub(report.StartedAt).Milliseconds(),
+		DatabaseCount:  report.DatabaseCount,
+		IndexCount:     report.IndexCount,
+		DriftCount:     report.DriftCount(),
+		Missing:        report.Missing,
+		Stale:          report.Stale,
+		Orphaned:       report.Orphaned,
+		RepairsEmitted: report.RepairsEmitted,
+		RepairsFailed:  report.RepairsFailed,
+		MissingIDs:     report.MissingIDs,
+		StaleIDs:       report.StaleIDs,
+		OrphanedIDs:    report.OrphanedIDs,
+		Error:          report.Error,
+	}
+}
@@ -0,0 +1,62 @@
+package search
+
+import (
+	"time"
+
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+)
+
+// ReindexJobResponse reports the progress of a background reindex job.
+type ReindexJobResponse struct {
+	ID         string     `json:"id"`
+	Status     string     `json:"status"`
+	Trigger    string     `json:"trigger"`
+	Total      int64      `json:"total"`
+	Indexed    int64      `json:"indexed"`
+	Failed     int64      `json:"failed"`
+	Progress   float64    `json:"progress"`
+	DocsPerSec float64    `json:"docs_per_sec"`
+	Errors     []string   `json:"errors,omitempty"`
+	FailedIDs  []int64    `json:"failed_ids,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// ReindexJobURI identifies a job in the request path.
+type ReindexJobURI struct {
+	JobID string `uri:"jobId" binding:"required,uuid"`
+}
+
+// FromReindexJob converts a domain job to its response DTO.
+func FromReindexJob(job *model.ReindexJob, now time.Time) *ReindexJobResponse {
+	if job == nil {
+		return nil
+	}
+
+	progress := 0.0
+	if job.Total > 0 {
+		progress = float64(job.Indexed+job.Failed) / float64(job.Total)
+		if progress > 1 {
+			progress = 1
+		}
+	} else if job.Status == model.ReindexJobCompleted {
+		progress = 1
+	}
+
+	return &ReindexJobResponse{
+		ID:         job.ID,
+		Status:     string(job.Status),
+		Trigger:    job.Trigger,
+		Total:      job.Total,
+		Indexed:    job.Indexed,
+		Failed:     job.Failed,
+		Progress:   progress,
+		DocsPerSec: job.Throughput(now),
+		Errors:     job.Errors,
+		FailedIDs:  job.FailedIDs,
+		CreatedAt:  job.CreatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+	}
+}
@@ -1,5 +1,7 @@
 package shared
 
+import "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+
 // CompanyNested represents a nested company object in a response payload.
 type CompanyNested struct {
 	ID   int64  `json:"id"`
@@ -18,10 +20,37 @@ type LineManagerNested struct {
 	ID       int64  `json:"id"`
 	Domain   string `json:"domain"`
 	Name     string `json:"name"`
-	Email    string `json:"email"`
+	Email    string `json:"email,omitempty"`
 	JobTitle string `json:"job_title"`
 }
 
+// ContactFilter masks the contact details of a person nested in a response according to
+// the caller's access to them.
+type ContactFilter interface {
+	ApplyContact(person *model.LineManagerNested, contact *LineManagerNested)
+}
+
+// NewLineManagerNested builds the nested person with their contact details passed through
+// pii; without a filter they are left out.
+func NewLineManagerNested(person *model.LineManagerNested, pii ContactFilter) *LineManagerNested {
+	if person == nil {
+		return nil
+	}
+	contact := &LineManagerNested{
+		ID:       person.ID,
+		Domain:   person.Domain,
+		Name:     person.Name,
+		Email:    person.Email,
+		JobTitle: person.JobTitle,
+	}
+	if pii == nil {
+		contact.Email = ""
+	} else {
+		pii.ApplyContact(person, contact)
+	}
+	return contact
+}
+
 // GroupDepartmentNested represents a nested group department object in a httputil.
 type GroupDepartmentNested struct {
 	ID        int64  `json:"id"`
@@ -0,0 +1,49 @@
+package starter
+
+import (
+	"net/http"
+
+	"github.com/kiin21/go-rest/pkg/httputil"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/application/dto/starter/query"
+)
+
+// BatchGetStartersRequest resolves up to 500 starters by domain or by id in one call.
+type BatchGetStartersRequest struct {
+	Domains []string `json:"domains" binding:"omitempty,max=500,dive,min=1,max=25"`
+	IDs     []int64  `json:"ids" binding:"omitempty,max=500,dive,gt=0"`
+}
+
+func (r *BatchGetStartersRequest) ToQuery() (*query.BatchGetStartersQuery, error) {
+	if (len(r.Domains) == 0) == (len(r.IDs) == 0) {
+		return nil, httputil.NewAPIError(http.StatusBadRequest, "Validation failed", map[string]string{
+			"field":   "domains",
+			"message": "exactly one of domains or ids is required",
+		})
+	}
+
+	return &query.BatchGetStartersQuery{
+		Domains: r.Domains,
+		IDs:     r.IDs,
+	}, nil
+}
+
+type BatchGetStartersResponse struct {
+	Starters []any `json:"starters"`
+	// NotFound lists the requested domains or ids that matched no live starter
+	NotFound []any `json:"not_found"`
+}
+
+func FromBatchGetResult(result *query.BatchGetStartersResult, starters []any) *BatchGetStartersResponse {
+	notFound := make([]any, 0, len(result.NotFoundDomains)+len(result.NotFoundIDs))
+	for _, domain := range result.NotFoundDomains {
+		notFound = append(notFound, domain)
+	}
+	for _, id := range result.NotFoundIDs {
+		notFound = append(notFound, id)
+	}
+
+	return &BatchGetStartersResponse{
+		Starters: starters,
+		NotFound: notFound,
+	}
+}
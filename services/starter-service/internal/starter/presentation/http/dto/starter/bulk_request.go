@@ -0,0 +1,94 @@
+package starter
+
+import (
+	"net/http"
+
+	"github.com/kiin21/go-rest/pkg/httputil"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/application/dto/starter/command"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+)
+
+// BulkTargetRequest selects up to 500 starters by domain, or by a filter expression
+// with the same syntax as the filter parameter of GET /starters.
+type BulkTargetRequest struct {
+	Domains []string `json:"domains" binding:"omitempty,max=500,dive,min=1,max=25"`
+	Filter  string   `json:"filter" binding:"omitempty,max=2000"`
+}
+
+// BulkStarterChanges holds the fields a bulk update may change; domains stay as they are.
+type BulkStarterChanges struct {
+	Name          *string `json:"name" binding:"omitempty,min=2,max=255"`
+	Email         *string `json:"email" binding:"omitempty,email,max=100"`
+	Mobile        *string `json:"mobile" binding:"omitempty,min=10,max=20"`
+	WorkPhone     *string `json:"work_phone" binding:"omitempty,max=20"`
+	JobTitle      *string `json:"job_title" binding:"omitempty,min=2,max=100"`
+	DepartmentID  *int64  `json:"department_id" binding:"omitempty,gt=0"`
+	LineManagerID *int64  `json:"line_manager_id" binding:"omitempty,gt=0"`
+}
+
+type BulkUpdateStartersRequest struct {
+	BulkTargetRequest
+	Changes BulkStarterChanges `json:"changes"`
+}
+
+type BulkDeleteStartersRequest struct {
+	BulkTargetRequest
+}
+
+func (r *BulkUpdateStartersRequest) ToCommand() (*command.BulkUpdateStartersCommand, error) {
+	target, err := r.toTarget()
+	if err != nil {
+		return nil, err
+	}
+
+	changes := r.Changes
+	if changes == (BulkStarterChanges{}) {
+		return nil, bulkValidationError("changes", "at least one field to change is required")
+	}
+
+	return &command.BulkUpdateStartersCommand{
+		Target: target,
+		Changes: command.UpdateStarterCommand{
+			Name:          changes.Name,
+			Email:         changes.Email,
+			Mobile:        changes.Mobile,
+			WorkPhone:     changes.WorkPhone,
+			JobTitle:      changes.JobTitle,
+			DepartmentID:  changes.DepartmentID,
+			LineManagerID: changes.LineManagerID,
+		},
+	}, nil
+}
+
+func (r *BulkDeleteStartersRequest) ToCommand() (*command.BulkDeleteStartersCommand, error) {
+	target, err := r.toTarget()
+	if err != nil {
+		return nil, err
+	}
+	return &command.BulkDeleteStartersCommand{Target: target}, nil
+}
+
+func (r *BulkTargetRequest) toTarget() (command.BulkTarget, error) {
+	if (len(r.Domains) == 0) == (r.Filter == "") {
+		return command.BulkTarget{}, bulkValidationError("domains", "exactly one of domains or filter is required")
+	}
+	if len(r.Domains) > 0 {
+		return command.BulkTarget{Domains: r.Domains}, nil
+	}
+
+	filter, err := model.ParseStarterListFilter(r.Filter)
+	if err != nil {
+		return command.BulkTarget{}, bulkValidationError("filter", err.Error())
+	}
+	if filter.IsEmpty() {
+		return command.BulkTarget{}, bulkValidationError("filter", "filter must have at least one condition")
+	}
+	return command.BulkTarget{Filter: filter}, nil
+}
+
+func bulkValidationError(field, message string) error {
+	return httputil.NewAPIError(http.StatusBadRequest, "Validation failed", map[string]string{
+		"field":   field,
+		"message": message,
+	})
+}
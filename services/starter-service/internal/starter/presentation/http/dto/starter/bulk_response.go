@@ -0,0 +1,38 @@
+package starter
+
+import "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+
+type BulkItemResponse struct {
+	ID     int64  `json:"id,omitempty"`
+	Domain string `json:"domain"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BulkChangeResponse struct {
+	// Applied is false when an invalid item stopped the whole change
+	Applied bool `json:"applied"`
+	// Summary counts the items per status
+	Summary map[string]int      `json:"summary"`
+	Items   []*BulkItemResponse `json:"items"`
+}
+
+func FromBulkChangeResult(result *model.BulkChangeResult) *BulkChangeResponse {
+	response := &BulkChangeResponse{
+		Applied: result.Applied,
+		Summary: make(map[string]int),
+		Items:   make([]*BulkItemResponse, len(result.Items)),
+	}
+
+	for i, item := range result.Items {
+		response.Summary[string(item.Status)]++
+		response.Items[i] = &BulkItemResponse{
+			ID:     item.ID,
+			Domain: item.Domain,
+			Status: string(item.Status),
+			Error:  item.Error,
+		}
+	}
+
+	return response
+}
@@ -1,19 +1,30 @@
 package starter
 
 import (
+	"net/http"
+
 	"github.com/kiin21/go-rest/pkg/httputil"
 	"github.com/kiin21/go-rest/services/starter-service/internal/starter/application/dto/starter/query"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
 )
 
 type ListStartersRequest struct {
 	Query    *string `form:"q"`
-	SearchBy string  `form:"search_by" binding:"omitempty,oneof=fullname domain dept_name bu_name"`
+	SearchBy string  `form:"search_by" binding:"omitempty,oneof=fullname domain email phone job_title dept_name bu_name"`
+
+	// Filter is a list of field:operator:value conditions, e.g. department_id:in:3,4;created_at:gte:2026-01-01
+	Filter string `form:"filter" binding:"omitempty,max=2000"`
 
 	SortBy    string `form:"sort_by" binding:"omitempty,oneof=id domain created_at"`
 	SortOrder string `form:"sort_order" binding:"omitempty,oneof=asc desc"`
 
 	Page  int `form:"page" binding:"omitempty,min=1"`
 	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+
+	// Cursor selects keyset pagination; send it empty for the first page
+	Cursor *string `form:"cursor"`
+
+	ResponseOptionsRequest
 }
 
 func (r *ListStartersRequest) SetDefaults() {
@@ -31,20 +42,56 @@ func (r *ListStartersRequest) SetDefaults() {
 	}
 }
 
-func (r *ListStartersRequest) ToQuery() *query.ListStartersQuery {
+func (r *ListStartersRequest) ToQuery() (*query.ListStartersQuery, error) {
 	var keyword string
 	if r.Query != nil {
 		keyword = *r.Query
 	}
 
+	filter, err := model.ParseStarterListFilter(r.Filter)
+	if err != nil {
+		return nil, httputil.NewAPIError(http.StatusBadRequest, "Validation failed", map[string]string{
+			"field":   "filter",
+			"message": err.Error(),
+		})
+	}
+
+	var cursor *httputil.Cursor
+	if r.Cursor != nil {
+		if cursor, err = r.parseCursor(); err != nil {
+			return nil, err
+		}
+	}
+
 	return &query.ListStartersQuery{
 		Pagination: httputil.ReqPagination{
-			Page:  &r.Page,
-			Limit: &r.Limit,
+			Page:   &r.Page,
+			Limit:  &r.Limit,
+			Cursor: cursor,
 		},
 		Keyword:   keyword,
 		SearchBy:  r.SearchBy,
 		SortBy:    r.SortBy,
 		SortOrder: r.SortOrder,
+		Filter:    filter,
+	}, nil
+}
+
+// parseCursor decodes the cursor and checks that its sort key has the type of the sort column.
+func (r *ListStartersRequest) parseCursor() (*httputil.Cursor, error) {
+	cursor, err := httputil.ParseCursor(*r.Cursor, r.SortBy, r.SortOrder)
+	if err != nil || cursor.IsFirstPage() {
+		return cursor, err
 	}
+
+	if _, err := cursor.Int64ID(); err != nil {
+		return nil, httputil.InvalidCursorError("cursor is malformed")
+	}
+	if r.SortBy == "created_at" {
+		if _, err := cursor.TimeValue(); err != nil {
+			return nil, httputil.InvalidCursorError("cursor is malformed")
+		}
+	}
+
+	return cursor, nil
 }
@@ -0,0 +1,135 @@
+package starter
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/presentation/http/dto/shared"
+)
+
+// PIIFilter masks the contact details of the starter responses built for one caller, and
+// collects the reads it left unmasked so that they can be written to the access log.
+type PIIFilter struct {
+	viewer *model.PIIViewer
+	route  string
+	// fields is the client's field selection, nil when every field is returned
+	fields []string
+	reads  []*model.PIIAccessLog
+}
+
+// NewPIIFilter builds the filter for viewer. Route names the endpoint in the access log, and
+// fields is the response field selection, so that fields left out are not logged as read.
+func NewPIIFilter(viewer *model.PIIViewer, route string, fields []string) *PIIFilter {
+	return &PIIFilter{viewer: viewer, route: route, fields: fields}
+}
+
+// Reads returns the unmasked reads of the responses filtered so far.
+func (f *PIIFilter) Reads() []*model.PIIAccessLog {
+	if f == nil {
+		return nil
+	}
+	return f.reads
+}
+
+// apply masks or clears the contact details of response according to the viewer's access to
+// starter. Without a filter nothing is shown.
+func (f *PIIFilter) apply(starter *model.Starter, response *StarterResponse) {
+	var viewer *model.PIIViewer
+	if f != nil {
+		viewer = f.viewer
+	}
+
+	access, reason := viewer.AccessTo(starter)
+	switch access {
+	case model.PIIFull:
+		f.recordRead(starter, response, reason)
+	case model.PIIMasked:
+		response.Email = maskEmail(response.Email)
+		response.Mobile = maskPhone(response.Mobile)
+		response.WorkPhone = maskPhone(response.WorkPhone)
+	default:
+		response.Email = ""
+		response.Mobile = ""
+		response.WorkPhone = ""
+	}
+}
+
+// ApplyContact masks or clears the email of a line manager or leader nested in a response,
+// as for the person's own starter response, keyed on their starter record.
+func (f *PIIFilter) ApplyContact(person *model.LineManagerNested, contact *shared.LineManagerNested) {
+	var viewer *model.PIIViewer
+	if f != nil {
+		viewer = f.viewer
+	}
+
+	access, reason := viewer.AccessTo(person.AsStarter())
+	switch access {
+	case model.PIIFull:
+		if contact.Email != "" && f.selects(model.RelationLineManager) {
+			f.reads = append(f.reads, f.accessLog(person.AsStarter(), []string{model.PIIFieldEmail}, reason))
+		}
+	case model.PIIMasked:
+		contact.Email = maskEmail(contact.Email)
+	default:
+		contact.Email = ""
+	}
+}
+
+func (f *PIIFilter) recordRead(starter *model.Starter, response *StarterResponse, reason model.PIIAccessReason) {
+	fields := make([]string, 0, 3)
+	for _, field := range []string{model.PIIFieldEmail, model.PIIFieldMobile, model.PIIFieldWorkPhone} {
+		if f.selects(field) && response.fieldValue(field) != "" {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+
+	f.reads = append(f.reads, f.accessLog(starter, fields, reason))
+}
+
+func (f *PIIFilter) accessLog(starter *model.Starter, fields []string, reason model.PIIAccessReason) *model.PIIAccessLog {
+	return &model.PIIAccessLog{
+		ViewerDomain:  f.viewer.Domain,
+		StarterID:     starter.ID,
+		StarterDomain: starter.Domain,
+		Fields:        fields,
+		Reason:        reason,
+		Route:         f.route,
+		AccessedAt:    time.Now(),
+	}
+}
+
+func (f *PIIFilter) selects(field string) bool {
+	if f.fields == nil {
+		return true
+	}
+	for _, selected := range f.fields {
+		if selected == field {
+			return true
+		}
+	}
+	return false
+}
+
+// maskEmail keeps the first character of the local part and the domain: a***@vng.com.vn.
+func maskEmail(email string) string {
+	local, domain, found := strings.Cut(email, "@")
+	if !found || local == "" {
+		return maskPhone(email)
+	}
+	_, size := utf8.DecodeRuneInString(local)
+	return local[:size] + "***@" + domain
+}
+
+// maskPhone keeps the last three characters: *******567.
+func maskPhone(phone string) string {
+	const visible = 3
+	if len(phone) <= visible {
+		return strings.Repeat("*", len(phone))
+	}
+	return strings.Repeat("*", len(phone)-visible) + phone[len(phone)-visible:]
+}
@@ -0,0 +1,154 @@
+package starter
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kiin21/go-rest/pkg/httputil"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+)
+
+// starterResponseFields are the names accepted by fields=, in response order.
+var starterResponseFields = []string{
+	"id", "domain", "name", "email", "mobile", "work_phone", "job_title",
+	model.RelationDepartment, model.RelationLineManager, model.RelationBusinessUnit,
+	"created_at", "updated_at",
+}
+
+// ResponseOptionsRequest holds the query parameters that shape starter responses.
+type ResponseOptionsRequest struct {
+	// Fields is a comma separated list of response fields, e.g. domain,name; id is always returned
+	Fields *string `form:"fields" binding:"omitempty,max=500"`
+	// Expand is a comma separated list of relations to load: department, line_manager, business_unit
+	Expand *string `form:"expand" binding:"omitempty,max=100"`
+}
+
+// ResponseOptions is the parsed form of ResponseOptionsRequest.
+type ResponseOptions struct {
+	// Fields is nil when every field is returned
+	Fields []string
+	Expand model.StarterExpand
+}
+
+// ToOptions parses fields and expand. Without expand, the relations named in fields are
+// loaded, or every relation when fields is absent too, so existing clients see no change.
+func (r *ResponseOptionsRequest) ToOptions() (*ResponseOptions, error) {
+	options := &ResponseOptions{Expand: model.ExpandAll()}
+
+	if r.Fields != nil {
+		fields, err := parseResponseFields(*r.Fields)
+		if err != nil {
+			return nil, responseOptionError("fields", err)
+		}
+		options.Fields = fields
+
+		options.Expand = model.StarterExpand{}
+		for _, field := range fields {
+			options.Expand.Set(field)
+		}
+	}
+
+	if r.Expand != nil {
+		expand, err := model.ParseStarterExpand(*r.Expand)
+		if err != nil {
+			return nil, responseOptionError("expand", err)
+		}
+		options.Expand = expand
+	}
+
+	return options, nil
+}
+
+// Shape applies the field selection to a response; the full response is returned as is.
+func (o *ResponseOptions) Shape(response *StarterResponse) any {
+	if o == nil || o.Fields == nil || response == nil {
+		return response
+	}
+
+	shaped := make(map[string]any, len(o.Fields)+1)
+	shaped["id"] = response.ID
+	for _, field := range o.Fields {
+		value := response.fieldValue(field)
+		if value == "" && isPIIField(field) {
+			// Contact details the caller may not see are left out, as in full responses
+			continue
+		}
+		shaped[field] = value
+	}
+	return shaped
+}
+
+func (o *ResponseOptions) ShapeAll(responses []*StarterResponse) []any {
+	shaped := make([]any, len(responses))
+	for i, response := range responses {
+		shaped[i] = o.Shape(response)
+	}
+	return shaped
+}
+
+func parseResponseFields(raw string) ([]string, error) {
+	seen := make(map[string]bool)
+	fields := make([]string, 0)
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" || seen[field] {
+			continue
+		}
+		if !isResponseField(field) {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func isPIIField(name string) bool {
+	return name == model.PIIFieldEmail || name == model.PIIFieldMobile || name == model.PIIFieldWorkPhone
+}
+
+func isResponseField(name string) bool {
+	for _, field := range starterResponseFields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *StarterResponse) fieldValue(name string) any {
+	switch name {
+	case "domain":
+		return r.Domain
+	case "name":
+		return r.Name
+	case "email":
+		return r.Email
+	case "mobile":
+		return r.Mobile
+	case "work_phone":
+		return r.WorkPhone
+	case "job_title":
+		return r.JobTitle
+	case model.RelationDepartment:
+		return r.Department
+	case model.RelationLineManager:
+		return r.LineManager
+	case model.RelationBusinessUnit:
+		return r.BusinessUnit
+	case "created_at":
+		return r.CreatedAt
+	case "updated_at":
+		return r.UpdatedAt
+	default:
+		return r.ID
+	}
+}
+
+func responseOptionError(field string, err error) error {
+	return httputil.NewAPIError(http.StatusBadRequest, "Validation failed", map[string]string{
+		"field":   field,
+		"message": err.Error(),
+	})
+}
@@ -11,9 +11,9 @@ type StarterResponse struct {
 	ID           int64                      `json:"id"`
 	Domain       string                     `json:"domain"`
 	Name         string                     `json:"name"`
-	Email        string                     `json:"email"`
-	Mobile       string                     `json:"mobile"`
-	WorkPhone    string                     `json:"work_phone"`
+	Email        string                     `json:"email,omitempty"`
+	Mobile       string                     `json:"mobile,omitempty"`
+	WorkPhone    string                     `json:"work_phone,omitempty"`
 	JobTitle     string                     `json:"job_title"`
 	Department   *shared.DepartmentNested   `json:"department,omitempty"`
 	LineManager  *shared.LineManagerNested  `json:"line_manager,omitempty"`
@@ -29,8 +29,10 @@ type EnrichedData struct {
 	BusinessUnits map[int64]*shared.BusinessUnitNested
 }
 
-// FromDomainEnrichment adapts domain enrichment data to HTTP DTO structures.
-func FromDomainEnrichment(enriched *model.EnrichedData) *EnrichedData {
+// FromDomainEnrichment adapts domain enrichment data to HTTP DTO structures. The line
+// managers' contact details are masked or left out according to pii, once for every response
+// that nests them.
+func FromDomainEnrichment(enriched *model.EnrichedData, pii *PIIFilter) *EnrichedData {
 	result := &EnrichedData{
 		Departments:   make(map[int64]*shared.DepartmentNested),
 		LineManagers:  make(map[int64]*shared.LineManagerNested),
@@ -68,13 +70,7 @@ func FromDomainEnrichment(enriched *model.EnrichedData) *EnrichedData {
 		if manager == nil {
 			continue
 		}
-		result.LineManagers[id] = &shared.LineManagerNested{
-			ID:       manager.ID,
-			Domain:   manager.Domain,
-			Name:     manager.Name,
-			Email:    manager.Email,
-			JobTitle: manager.JobTitle,
-		}
+		result.LineManagers[id] = shared.NewLineManagerNested(manager, pii)
 	}
 
 	for id, bu := range enriched.BusinessUnits {
@@ -91,8 +87,9 @@ func FromDomainEnrichment(enriched *model.EnrichedData) *EnrichedData {
 	return result
 }
 
-// FromDomainEnriched converts domain document to enriched response with related data
-func FromDomainEnriched(starter *model.Starter, enriched *EnrichedData) *StarterResponse {
+// FromDomainEnriched converts domain document to enriched response with related data.
+// Contact details are masked or left out according to pii; a nil filter leaves them out.
+func FromDomainEnriched(starter *model.Starter, enriched *EnrichedData, pii *PIIFilter) *StarterResponse {
 	if starter == nil {
 		return nil
 	}
@@ -109,15 +106,14 @@ func FromDomainEnriched(starter *model.Starter, enriched *EnrichedData) *Starter
 		UpdatedAt: starter.UpdatedAt,
 	}
 
-	// Map DepartmentName
+	// Map department and its business unit; either may be left out by expand
 	if starter.DepartmentID != nil && enriched != nil {
 		deptID := *starter.DepartmentID
 		if dept, ok := enriched.Departments[deptID]; ok {
 			response.Department = dept
-
-			if bu, ok := enriched.BusinessUnits[deptID]; ok {
-				response.BusinessUnit = bu
-			}
+		}
+		if bu, ok := enriched.BusinessUnits[deptID]; ok {
+			response.BusinessUnit = bu
 		}
 	} // Add LM if exists
 	if starter.LineManagerID != nil && enriched != nil && enriched.LineManagers != nil {
@@ -126,14 +122,16 @@ func FromDomainEnriched(starter *model.Starter, enriched *EnrichedData) *Starter
 		}
 	}
 
+	pii.apply(starter, response)
+
 	return response
 }
 
 // FromStartersEnriched converts multiple domain entities to enriched responses
-func FromStartersEnriched(starters []*model.Starter, enriched *EnrichedData) []*StarterResponse {
+func FromStartersEnriched(starters []*model.Starter, enriched *EnrichedData, pii *PIIFilter) []*StarterResponse {
 	responses := make([]*StarterResponse, len(starters))
 	for i, starter := range starters {
-		responses[i] = FromDomainEnriched(starter, enriched)
+		responses[i] = FromDomainEnriched(starter, enriched, pii)
 	}
 	return responses
 }
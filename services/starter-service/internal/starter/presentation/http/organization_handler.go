@@ -4,19 +4,27 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/kiin21/go-rest/pkg/httputil"
 	"github.com/kiin21/go-rest/services/starter-service/internal/starter/application/service"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+	domainService "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/service"
 	budto "github.com/kiin21/go-rest/services/starter-service/internal/starter/presentation/http/dto/businessunit"
 	departmentdto "github.com/kiin21/go-rest/services/starter-service/internal/starter/presentation/http/dto/department"
 )
 
 type OrganizationHandler struct {
-	orgSvc *service.OrganizationApplicationService
+	orgSvc    *service.OrganizationApplicationService
+	access    *domainService.DepartmentAccessService
+	piiAccess *service.PIIAccessService
 }
 
 func NewOrganizationHandler(
 	orgSvc *service.OrganizationApplicationService,
+	access *domainService.DepartmentAccessService,
+	piiAccess *service.PIIAccessService,
 ) *OrganizationHandler {
 	return &OrganizationHandler{
-		orgSvc: orgSvc,
+		orgSvc:    orgSvc,
+		access:    access,
+		piiAccess: piiAccess,
 	}
 }
 
@@ -29,9 +37,13 @@ func NewOrganizationHandler(
 // @Param business_unit_id query int false "Filter by business unit ID" minimum(1)
 // @Param page query int false "Page number" default(1) minimum(1)
 // @Param limit query int false "Page size" default(10) minimum(1) maximum(100)
+// @Param cursor query string false "Opaque cursor from the previous page; send empty to start cursor pagination"
 // @Success 200 {object} httputil.APIResponse
 // @Failure 400 {object} httputil.APIResponse
+// @Failure 401 {object} httputil.APIResponse
+// @Failure 403 {object} httputil.APIResponse
 // @Failure 500 {object} httputil.APIResponse
+// @Security BearerAuth
 // @Router /organization/departments [get]
 func (h *OrganizationHandler) ListDepartments(ctx *gin.Context) {
 	httputil.Wrap(h.listDepartments)(ctx)
@@ -45,12 +57,24 @@ func (h *OrganizationHandler) listDepartments(ctx *gin.Context) (res interface{}
 	}
 	req.SetDefaults()
 
-	result, err := h.orgSvc.GetAllDepartments(ctx, req.ToQuery())
+	query, err := req.ToQuery()
 	if err != nil {
 		return nil, err
 	}
 
-	data := departmentdto.FromDomainsWithDetails(result.Data)
+	result, err := h.orgSvc.GetAllDepartments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	pii, err := newPIIFilter(ctx, h.piiAccess, nil)
+	if err != nil {
+		return nil, err
+	}
+	data := departmentdto.FromDomainsWithDetails(result.Data, pii)
+	if err := h.piiAccess.Record(ctx, pii.Reads()); err != nil {
+		return nil, err
+	}
 	pagination := httputil.CursorPagination(ctx, result.Pagination)
 	return &httputil.PaginatedResult[*departmentdto.DepartmentDetailResponse]{
 		Data:       data,
@@ -67,8 +91,11 @@ func (h *OrganizationHandler) listDepartments(ctx *gin.Context) (res interface{}
 // @Param id path int true "Department ID" minimum(1)
 // @Success 200 {object} httputil.APIResponse
 // @Failure 400 {object} httputil.APIResponse
+// @Failure 401 {object} httputil.APIResponse
+// @Failure 403 {object} httputil.APIResponse
 // @Failure 404 {object} httputil.APIResponse
 // @Failure 500 {object} httputil.APIResponse
+// @Security BearerAuth
 // @Router /organization/departments/{id} [get]
 func (h *OrganizationHandler) GetDepartmentDetail(ctx *gin.Context) {
 	httputil.Wrap(h.getDepartmentDetail)(ctx)
@@ -87,7 +114,7 @@ func (h *OrganizationHandler) getDepartmentDetail(ctx *gin.Context) (res interfa
 		return nil, err
 	}
 
-	return departmentdto.FromDomainWithDetails(result), nil
+	return h.departmentResponse(ctx, result)
 }
 
 // ListBusinessUnits godoc
@@ -100,7 +127,10 @@ func (h *OrganizationHandler) getDepartmentDetail(ctx *gin.Context) (res interfa
 // @Param limit query int false "Page size" default(10) minimum(1) maximum(100)
 // @Success 200 {object} httputil.APIResponse
 // @Failure 400 {object} httputil.APIResponse
+// @Failure 401 {object} httputil.APIResponse
+// @Failure 403 {object} httputil.APIResponse
 // @Failure 500 {object} httputil.APIResponse
+// @Security BearerAuth
 // @Router /organization/business-units [get]
 func (h *OrganizationHandler) ListBusinessUnits(ctx *gin.Context) {
 	httputil.Wrap(h.listBusinessUnits)(ctx)
@@ -119,7 +149,14 @@ func (h *OrganizationHandler) listBusinessUnits(ctx *gin.Context) (res interface
 		return nil, err
 	}
 
-	data := budto.FromBusinessUnitsWithDetails(result.Data)
+	pii, err := newPIIFilter(ctx, h.piiAccess, nil)
+	if err != nil {
+		return nil, err
+	}
+	data := budto.FromBusinessUnitsWithDetails(result.Data, pii)
+	if err := h.piiAccess.Record(ctx, pii.Reads()); err != nil {
+		return nil, err
+	}
 	pagination := httputil.CursorPagination(ctx, result.Pagination)
 
 	return &httputil.PaginatedResult[*budto.BusinessUnitDetailResponse]{
@@ -137,8 +174,11 @@ func (h *OrganizationHandler) listBusinessUnits(ctx *gin.Context) (res interface
 // @Param id path int true "Business unit ID" minimum(1)
 // @Success 200 {object} httputil.APIResponse
 // @Failure 400 {object} httputil.APIResponse
+// @Failure 401 {object} httputil.APIResponse
+// @Failure 403 {object} httputil.APIResponse
 // @Failure 404 {object} httputil.APIResponse
 // @Failure 500 {object} httputil.APIResponse
+// @Security BearerAuth
 // @Router /organization/business-units/{id} [get]
 func (h *OrganizationHandler) GetBusinessUnit(ctx *gin.Context) {
 	httputil.Wrap(h.getBusinessUnit)(ctx)
@@ -157,7 +197,15 @@ func (h *OrganizationHandler) getBusinessUnit(ctx *gin.Context) (res interface{}
 		return nil, err
 	}
 
-	return budto.FromBusinessUnitWithDetails(unit), nil
+	pii, err := newPIIFilter(ctx, h.piiAccess, nil)
+	if err != nil {
+		return nil, err
+	}
+	response := budto.FromBusinessUnitWithDetails(unit, pii)
+	if err := h.piiAccess.Record(ctx, pii.Reads()); err != nil {
+		return nil, err
+	}
+	return response, nil
 }
 
 // CreateDepartment godoc
@@ -167,9 +215,15 @@ func (h *OrganizationHandler) getBusinessUnit(ctx *gin.Context) (res interface{}
 // @Accept json
 // @Produce json
 // @Param request body department.CreateDepartmentRequest true "Department payload"
+// @Param Idempotency-Key header string false "Retries with the same key replay the first response"
 // @Success 200 {object} httputil.APIResponse
 // @Failure 400 {object} httputil.APIResponse
+// @Failure 401 {object} httputil.APIResponse
+// @Failure 403 {object} httputil.APIResponse
+// @Failure 409 {object} httputil.APIResponse
+// @Failure 422 {object} httputil.APIResponse
 // @Failure 500 {object} httputil.APIResponse
+// @Security BearerAuth
 // @Router /organization/departments [post]
 func (h *OrganizationHandler) CreateDepartment(ctx *gin.Context) {
 	httputil.Wrap(h.createDepartment)(ctx)
@@ -186,7 +240,7 @@ func (h *OrganizationHandler) createDepartment(ctx *gin.Context) (res interface{
 		return nil, err
 	}
 
-	return departmentdto.FromDomainWithDetails(result), nil
+	return h.departmentResponse(ctx, result)
 }
 
 // UpdateDepartment godoc
@@ -199,8 +253,11 @@ func (h *OrganizationHandler) createDepartment(ctx *gin.Context) (res interface{
 // @Param request body department.UpdateDepartmentRequest true "Update payload"
 // @Success 200 {object} httputil.APIResponse
 // @Failure 400 {object} httputil.APIResponse
+// @Failure 401 {object} httputil.APIResponse
+// @Failure 403 {object} httputil.APIResponse
 // @Failure 404 {object} httputil.APIResponse
 // @Failure 500 {object} httputil.APIResponse
+// @Security BearerAuth
 // @Router /organization/departments/{id} [patch]
 func (h *OrganizationHandler) UpdateDepartment(ctx *gin.Context) {
 	httputil.Wrap(h.updateDepartment)(ctx)
@@ -218,12 +275,21 @@ func (h *OrganizationHandler) updateDepartment(ctx *gin.Context) (res interface{
 		return nil, err
 	}
 
-	result, err := h.orgSvc.UpdateDepartment(ctx, req.ToCommand(uriReq.DeptId))
+	command := req.ToCommand(uriReq.DeptId)
+	departmentIDs := []int64{uriReq.DeptId}
+	if command.GroupDepartmentID != nil {
+		departmentIDs = append(departmentIDs, *command.GroupDepartmentID)
+	}
+	if err := authorizeDepartments(ctx, h.access, departmentIDs...); err != nil {
+		return nil, err
+	}
+
+	result, err := h.orgSvc.UpdateDepartment(ctx, command)
 	if err != nil {
 		return nil, err
 	}
 
-	return departmentdto.FromDomainWithDetails(result), nil
+	return h.departmentResponse(ctx, result)
 }
 
 // AssignLeaderToDepartment godoc
@@ -236,8 +302,11 @@ func (h *OrganizationHandler) updateDepartment(ctx *gin.Context) (res interface{
 // @Param request body department.AssignLeaderRequest true "Leader assignment payload"
 // @Success 200 {object} httputil.APIResponse
 // @Failure 400 {object} httputil.APIResponse
+// @Failure 401 {object} httputil.APIResponse
+// @Failure 403 {object} httputil.APIResponse
 // @Failure 404 {object} httputil.APIResponse
 // @Failure 500 {object} httputil.APIResponse
+// @Security BearerAuth
 // @Router /organization/departments/{id}/leader [patch]
 func (h *OrganizationHandler) AssignLeaderToDepartment(ctx *gin.Context) {
 	httputil.Wrap(h.assignLeaderToDepartment)(ctx)
@@ -255,12 +324,16 @@ func (h *OrganizationHandler) assignLeaderToDepartment(ctx *gin.Context) (res in
 		return nil, err
 	}
 
+	if err := authorizeDepartments(ctx, h.access, uriReq.DeptId); err != nil {
+		return nil, err
+	}
+
 	result, err := h.orgSvc.AssignLeader(ctx, req.ToCommand(uriReq.DeptId))
 	if err != nil {
 		return nil, err
 	}
 
-	return departmentdto.FromDomainWithDetails(result), nil
+	return h.departmentResponse(ctx, result)
 }
 
 // DeleteDepartment godoc
@@ -272,8 +345,11 @@ func (h *OrganizationHandler) assignLeaderToDepartment(ctx *gin.Context) (res in
 // @Param id path int true "Department ID" minimum(1)
 // @Success 200 {object} httputil.APIResponse
 // @Failure 400 {object} httputil.APIResponse
+// @Failure 401 {object} httputil.APIResponse
+// @Failure 403 {object} httputil.APIResponse
 // @Failure 404 {object} httputil.APIResponse
 // @Failure 500 {object} httputil.APIResponse
+// @Security BearerAuth
 // @Router /organization/departments/{id} [delete]
 func (h *OrganizationHandler) DeleteDepartment(ctx *gin.Context) {
 	httputil.Wrap(h.deleteDepartment)(ctx)
@@ -297,3 +373,18 @@ func (h *OrganizationHandler) deleteDepartment(ctx *gin.Context) (res interface{
 		"id":      uriReq.Id,
 	}, nil
 }
+
+// departmentResponse builds the response for one department, with its leader's contact
+// details masked for the caller and unmasked reads logged.
+func (h *OrganizationHandler) departmentResponse(ctx *gin.Context, dept *model.DepartmentWithDetails) (interface{}, error) {
+	pii, err := newPIIFilter(ctx, h.piiAccess, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := departmentdto.FromDomainWithDetails(dept, pii)
+	if err := h.piiAccess.Record(ctx, pii.Reads()); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
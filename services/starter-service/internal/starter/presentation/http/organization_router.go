@@ -2,20 +2,27 @@ package http
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
 )
 
-func RegisterOrganizationRoutes(rg *gin.RouterGroup, handler *OrganizationHandler) {
+// RegisterOrganizationRoutes registers the organization routes; idempotent guards the create route.
+// Any role may read, org admins make changes, and leaders may edit departments in their subtree.
+func RegisterOrganizationRoutes(rg *gin.RouterGroup, handler *OrganizationHandler, idempotent gin.HandlerFunc) {
+	readers := auth.RequireRoles(auth.AllRoles...)
+	orgAdmins := auth.RequireRoles(auth.RoleOrgAdmin)
+	departmentEditors := auth.RequireRoles(auth.RoleOrgAdmin, auth.RoleLeader)
+
 	org := rg.Group("/organization")
-	
+
 	departments := org.Group("/departments")
-	departments.GET("", handler.ListDepartments)
-	departments.GET("/:id", handler.GetDepartmentDetail)
-	departments.POST("", handler.CreateDepartment)
-	departments.PATCH("/:id", handler.UpdateDepartment)
-	departments.PATCH("/:id/leader", handler.AssignLeaderToDepartment)
-	departments.DELETE("/:id", handler.DeleteDepartment)
-	
+	departments.GET("", readers, handler.ListDepartments)
+	departments.GET("/:id", readers, handler.GetDepartmentDetail)
+	departments.POST("", orgAdmins, idempotent, handler.CreateDepartment)
+	departments.PATCH("/:id", departmentEditors, handler.UpdateDepartment)
+	departments.PATCH("/:id/leader", departmentEditors, handler.AssignLeaderToDepartment)
+	departments.DELETE("/:id", orgAdmins, handler.DeleteDepartment)
+
 	businessUnits := org.Group("/business-units")
-	businessUnits.GET("", handler.ListBusinessUnits)
-	businessUnits.GET("/:id", handler.GetBusinessUnit)
+	businessUnits.GET("", readers, handler.ListBusinessUnits)
+	businessUnits.GET("/:id", readers, handler.GetBusinessUnit)
 }
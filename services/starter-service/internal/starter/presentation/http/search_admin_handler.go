@@ -0,0 +1,183 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/httputil"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/application/service"
+	sharedDomain "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/error"
+	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
+	domainService "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/service"
+	searchdto "github.com/kiin21/go-rest/services/starter-service/internal/starter/presentation/http/dto/search"
+)
+
+type SearchAdminHandler struct {
+	reconciler  *domainService.SearchReconciler
+	reindexJobs *service.ReindexJobService
+}
+
+func NewSearchAdminHandler(
+	reconciler *domainService.SearchReconciler,
+	reindexJobs *service.ReindexJobService,
+) *SearchAdminHandler {
+	return &SearchAdminHandler{
+		reconciler:  reconciler,
+		reindexJobs: reindexJobs,
+	}
+}
+
+// GetConsistency godoc
+// @Summary Search index consistency
+// @Description Report drift between MySQL starters and the Elasticsearch index. Returns the latest reconciliation unless refresh is set, in which case a new run is performed and its repairs emitted.
+// @Tags Admin
+// @Produce json
+// @Param refresh query bool false "Run a reconciliation now instead of returning the latest report"
+// @Success 200 {object} httputil.APIResponse
+// @Failure 400 {object} httputil.APIResponse
+// @Failure 401 {object} httputil.APIResponse
+// @Failure 403 {object} httputil.APIResponse
+// @Failure 500 {object} httputil.APIResponse
+// @Failure 503 {object} httputil.APIResponse
+// @Security BearerAuth
+// @Router /admin/search/consistency [get]
+func (h *SearchAdminHandler) GetConsistency(ctx *gin.Context) {
+	httputil.Wrap(h.getConsistency)(ctx)
+}
+
+func (h *SearchAdminHandler) getConsistency(ctx *gin.Context) (res interface{}, err error) {
+	if h.reconciler == nil {
+		return nil, errSearchDisabled
+	}
+
+	var req searchdto.ConsistencyRequest
+	if err := httputil.ValidateQuery(ctx, &req); err != nil {
+		return nil, err
+	}
+
+	report := h.reconciler.LastReport()
+	if req.Refresh || report == nil {
+		report, err = h.reconciler.Reconcile(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return searchdto.FromConsistencyReport(report), nil
+}
+
+// StartReindex godoc
+// @Summary Start search reindex
+// @Description Start a background job that rebuilds the Elasticsearch index from MySQL. Only one job runs at a time.
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} httputil.APIResponse
+// @Failure 401 {object} httputil.APIResponse
+// @Failure 403 {object} httputil.APIResponse
+// @Failure 409 {object} httputil.APIResponse
+// @Failure 503 {object} httputil.APIResponse
+// @Security BearerAuth
+// @Router /admin/search/reindex [post]
+func (h *SearchAdminHandler) StartReindex(ctx *gin.Context) {
+	httputil.Wrap(h.startReindex)(ctx)
+}
+
+func (h *SearchAdminHandler) startReindex(ctx *gin.Context) (res interface{}, err error) {
+	if h.reindexJobs == nil {
+		return nil, errSearchDisabled
+	}
+
+	job, err := h.reindexJobs.Start(model.ReindexTriggerAPI)
+	if err != nil {
+		return nil, mapReindexJobError(err)
+	}
+
+	return searchdto.FromReindexJob(job, time.Now()), nil
+}
+
+// GetReindexJob godoc
+// @Summary Get search reindex job
+// @Description Retrieve progress, errors and throughput of a reindex job
+// @Tags Admin
+// @Produce json
+// @Param jobId path string true "Reindex job ID"
+// @Success 200 {object} httputil.APIResponse
+// @Failure 400 {object} httputil.APIResponse
+// @Failure 401 {object} httputil.APIResponse
+// @Failure 403 {object} httputil.APIResponse
+// @Failure 404 {object} httputil.APIResponse
+// @Failure 503 {object} httputil.APIResponse
+// @Security BearerAuth
+// @Router /admin/search/reindex/{jobId} [get]
+func (h *SearchAdminHandler) GetReindexJob(ctx *gin.Context) {
+	httputil.Wrap(h.getReindexJob)(ctx)
+}
+
+func (h *SearchAdminHandler) getReindexJob(ctx *gin.Context) (res interface{}, err error) {
+	if h.reindexJobs == nil {
+		return nil, errSearchDisabled
+	}
+
+	var uriReq searchdto.ReindexJobURI
+	if err := httputil.ValidateURI(ctx, &uriReq); err != nil {
+		return nil, err
+	}
+
+	job, err := h.reindexJobs.Get(uriReq.JobID)
+	if err != nil {
+		return nil, mapReindexJobError(err)
+	}
+
+	return searchdto.FromReindexJob(job, time.Now()), nil
+}
+
+// CancelReindexJob godoc
+// @Summary Cancel search reindex job
+// @Description Cancel a running reindex job. Documents already indexed are kept.
+// @Tags Admin
+// @Produce json
+// @Param jobId path string true "Reindex job ID"
+// @Success 200 {object} httputil.APIResponse
+// @Failure 400 {object} httputil.APIResponse
+// @Failure 401 {object} httputil.APIResponse
+// @Failure 403 {object} httputil.APIResponse
+// @Failure 404 {object} httputil.APIResponse
+// @Failure 503 {object} httputil.APIResponse
+// @Security BearerAuth
+// @Router /admin/search/reindex/{jobId}/cancel [post]
+func (h *SearchAdminHandler) CancelReindexJob(ctx *gin.Context) {
+	httputil.Wrap(h.cancelReindexJob)(ctx)
+}
+
+func (h *SearchAdminHandler) cancelReindexJob(ctx *gin.Context) (res interface{}, err error) {
+	if h.reindexJobs == nil {
+		return nil, errSearchDisabled
+	}
+
+	var uriReq searchdto.ReindexJobURI
+	if err := httputil.ValidateURI(ctx, &uriReq); err != nil {
+		return nil, err
+	}
+
+	job, err := h.reindexJobs.Cancel(uriReq.JobID)
+	if err != nil {
+		return nil, mapReindexJobError(err)
+	}
+
+	return searchdto.FromReindexJob(job, time.Now()), nil
+}
+
+var errSearchDisabled = httputil.NewAPIError(http.StatusServiceUnavailable, "Search is disabled", "elasticsearch is not configured")
+
+func mapReindexJobError(err error) error {
+	switch {
+	case errors.Is(err, sharedDomain.ErrNotFound):
+		return httputil.NewAPIError(http.StatusNotFound, "Reindex job not found", err.Error())
+	case errors.Is(err, sharedDomain.ErrReindexInProgress):
+		return httputil.NewAPIError(http.StatusConflict, "Reindex already running", err.Error())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,14 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
+)
+
+func RegisterSearchAdminRoutes(rg *gin.RouterGroup, handler *SearchAdminHandler) {
+	route := rg.Group("/admin/search", auth.RequireRoles(auth.RoleHRAdmin))
+	route.GET("/consistency", handler.GetConsistency)
+	route.POST("/reindex", handler.StartReindex)
+	route.GET("/reindex/:jobId", handler.GetReindexJob)
+	route.POST("/reindex/:jobId/cancel", handler.CancelReindexJob)
+}
@@ -1,9 +1,13 @@
 package http
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/kiin21/go-rest/pkg/httputil"
 	"github.com/kiin21/go-rest/services/starter-service/internal/starter/application/service"
+	sharedDomain "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/error"
 	"github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/model"
 	domainService "github.com/kiin21/go-rest/services/starter-service/internal/starter/domain/service"
 	starterdto "github.com/kiin21/go-rest/services/starter-service/internal/starter/presentation/http/dto/starter"
@@ -12,15 +16,21 @@ import (
 type StarterHandler struct {
 	starterSvc        *service.StarterApplicationService
 	enrichmentService *domainService.StarterEnrichmentService
+	access            *domainService.DepartmentAccessService
+	piiAccess         *service.PIIAccessService
 }
 
 func NewStarterHandler(
 	starterSvc *service.StarterApplicationService,
 	enrichmentService *domainService.StarterEnrichmentService,
+	access *domainService.DepartmentAccessService,
+	piiAccess *service.PIIAccessService,
 ) *StarterHandler {
 	return &StarterHandler{
 		starterSvc:        starterSvc,
 		enrichmentService: enrichmentService,
+		access:            access,
+		piiAccess:         piiAccess,
 	}
 }
 
@@ -36,21 +46,41 @@ func (sh *StarterHandler) listStarters(ctx *gin.Context) (res interface{}, err e
 	}
 	req.SetDefaults()
 
-	rawResult, err := sh.starterSvc.ListStarters(ctx, req.ToQuery())
+	query, err := req.ToQuery()
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeContactSearch(ctx, query.SearchBy, query.Filter); err != nil {
+		return nil, err
+	}
+	options, err := req.ToOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	rawResult, err := sh.starterSvc.ListStarters(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	enrichedDomain, err := sh.enrichmentService.EnrichStartersWith(ctx, rawResult.Data, options.Expand)
 	if err != nil {
 		return nil, err
 	}
 
-	enrichedDomain, err := sh.enrichmentService.EnrichStarters(ctx, rawResult.Data)
+	pii, err := newPIIFilter(ctx, sh.piiAccess, options.Fields)
 	if err != nil {
 		return nil, err
 	}
 
-	enrichedDTO := starterdto.FromDomainEnrichment(enrichedDomain)
-	responseData := starterdto.FromStartersEnriched(rawResult.Data, enrichedDTO)
+	enrichedDTO := starterdto.FromDomainEnrichment(enrichedDomain, pii)
+	responseData := starterdto.FromStartersEnriched(rawResult.Data, enrichedDTO, pii)
+	if err := sh.piiAccess.Record(ctx, pii.Reads()); err != nil {
+		return nil, err
+	}
 
-	return &httputil.PaginatedResult[*starterdto.StarterResponse]{
-		Data:       responseData,
+	return &httputil.PaginatedResult[any]{
+		Data:       options.ShapeAll(responseData),
 		Pagination: httputil.CursorPagination(ctx, rawResult.Pagination),
 	}, nil
 }
@@ -76,8 +106,7 @@ func (sh *StarterHandler) createStarter(ctx *gin.Context) (res interface{}, err
 		return nil, err
 	}
 
-	enrichedDTO := starterdto.FromDomainEnrichment(enrichedDomain)
-	return starterdto.FromDomainEnriched(starter, enrichedDTO), nil
+	return sh.toResponse(ctx, starter, enrichedDomain)
 }
 
 // Find GET /api/v1/starters/{domain}
@@ -92,18 +121,146 @@ func (sh *StarterHandler) find(ctx *gin.Context) (res interface{}, err error) {
 	if err := httputil.ValidateURI(ctx, &uriReq); err != nil {
 		return nil, err
 	}
+	var optionsReq starterdto.ResponseOptionsRequest
+	if err := httputil.ValidateQuery(ctx, &optionsReq); err != nil {
+		return nil, err
+	}
+	options, err := optionsReq.ToOptions()
+	if err != nil {
+		return nil, err
+	}
 
 	starter, err := sh.starterSvc.GetStarterByDomain(ctx, uriReq.Domain)
 	if err != nil {
 		return nil, err
 	}
-	enrichedDomain, err := sh.enrichmentService.EnrichStarters(ctx, []*model.Starter{starter})
+	enrichedDomain, err := sh.enrichmentService.EnrichStartersWith(ctx, []*model.Starter{starter}, options.Expand)
+	if err != nil {
+		return nil, err
+	}
+
+	pii, err := newPIIFilter(ctx, sh.piiAccess, options.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	enrichedDTO := starterdto.FromDomainEnrichment(enrichedDomain, pii)
+	response := starterdto.FromDomainEnriched(starter, enrichedDTO, pii)
+	if err := sh.piiAccess.Record(ctx, pii.Reads()); err != nil {
+		return nil, err
+	}
+	return options.Shape(response), nil
+}
+
+// BatchGet POST /api/v1/starters/batch-get
+func (sh *StarterHandler) BatchGet(ctx *gin.Context) {
+	httputil.Wrap(sh.batchGet)(ctx)
+}
+
+func (sh *StarterHandler) batchGet(ctx *gin.Context) (res interface{}, err error) {
+	var req starterdto.BatchGetStartersRequest
+	if err := httputil.ValidateBody(ctx, &req); err != nil {
+		return nil, err
+	}
+	query, err := req.ToQuery()
+	if err != nil {
+		return nil, err
+	}
+	var optionsReq starterdto.ResponseOptionsRequest
+	if err := httputil.ValidateQuery(ctx, &optionsReq); err != nil {
+		return nil, err
+	}
+	options, err := optionsReq.ToOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := sh.starterSvc.BatchGetStarters(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	enrichedDomain, err := sh.enrichmentService.EnrichStartersWith(ctx, result.Starters, options.Expand)
+	if err != nil {
+		return nil, err
+	}
+
+	pii, err := newPIIFilter(ctx, sh.piiAccess, options.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	enrichedDTO := starterdto.FromDomainEnrichment(enrichedDomain, pii)
+	responseData := starterdto.FromStartersEnriched(result.Starters, enrichedDTO, pii)
+	if err := sh.piiAccess.Record(ctx, pii.Reads()); err != nil {
+		return nil, err
+	}
+
+	return starterdto.FromBatchGetResult(result, options.ShapeAll(responseData)), nil
+}
+
+// BulkUpdate PATCH /api/v1/starters/bulk
+func (sh *StarterHandler) BulkUpdate(ctx *gin.Context) {
+	httputil.Wrap(sh.bulkUpdate)(ctx)
+}
+
+func (sh *StarterHandler) bulkUpdate(ctx *gin.Context) (res interface{}, err error) {
+	var req starterdto.BulkUpdateStartersRequest
+	if err := httputil.ValidateBody(ctx, &req); err != nil {
+		return nil, err
+	}
+	command, err := req.ToCommand()
 	if err != nil {
 		return nil, err
 	}
 
-	enrichedDTO := starterdto.FromDomainEnrichment(enrichedDomain)
-	return starterdto.FromDomainEnriched(starter, enrichedDTO), nil
+	result, err := sh.starterSvc.BulkUpdateStarters(ctx, command)
+	if err != nil {
+		return nil, mapBulkError(err)
+	}
+
+	response := starterdto.FromBulkChangeResult(result)
+	if !result.Applied {
+		return nil, httputil.NewAPIError(http.StatusUnprocessableEntity, "Bulk update rejected", response)
+	}
+	return response, nil
+}
+
+// BulkDelete DELETE /api/v1/starters/bulk
+func (sh *StarterHandler) BulkDelete(ctx *gin.Context) {
+	httputil.Wrap(sh.bulkDelete)(ctx)
+}
+
+func (sh *StarterHandler) bulkDelete(ctx *gin.Context) (res interface{}, err error) {
+	var req starterdto.BulkDeleteStartersRequest
+	if err := httputil.ValidateBody(ctx, &req); err != nil {
+		return nil, err
+	}
+	command, err := req.ToCommand()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := sh.starterSvc.BulkDeleteStarters(ctx, command)
+	if err != nil {
+		return nil, mapBulkError(err)
+	}
+
+	return starterdto.FromBulkChangeResult(result), nil
+}
+
+func mapBulkError(err error) error {
+	switch {
+	case errors.Is(err, sharedDomain.ErrBulkTargetTooLarge):
+		return httputil.NewAPIError(http.StatusBadRequest, "Validation failed", map[string]string{
+			"field":   "filter",
+			"message": err.Error(),
+		})
+	case errors.Is(err, sharedDomain.ErrInvalidInput):
+		return httputil.NewAPIError(http.StatusBadRequest, "Validation failed", err.Error())
+	default:
+		return err
+	}
 }
 
 // UpdateStarter PATCH /api/v1/starters/{domain}
@@ -123,7 +280,12 @@ func (sh *StarterHandler) updateStarter(ctx *gin.Context) (res interface{}, err
 		return nil, err
 	}
 
-	starter, err := sh.starterSvc.UpdateStarter(ctx, req.ToCommand(uriReq.Domain))
+	command := req.ToCommand(uriReq.Domain)
+	if err := authorizeStarter(ctx, sh.access, uriReq.Domain, command.DepartmentID); err != nil {
+		return nil, err
+	}
+
+	starter, err := sh.starterSvc.UpdateStarter(ctx, command)
 	if err != nil {
 		return nil, err
 	}
@@ -132,8 +294,7 @@ func (sh *StarterHandler) updateStarter(ctx *gin.Context) (res interface{}, err
 		return nil, err
 	}
 
-	enrichedDTO := starterdto.FromDomainEnrichment(enrichedDomain)
-	return starterdto.FromDomainEnriched(starter, enrichedDTO), nil
+	return sh.toResponse(ctx, starter, enrichedDomain)
 }
 
 // SoftDeleteStarter DELETE /api/v1/starters/{domain}
@@ -158,3 +319,17 @@ func (sh *StarterHandler) softDeleteStarter(ctx *gin.Context) (res interface{},
 		"domain":  uriReq.Domain,
 	}, nil
 }
+
+// toResponse builds the full response for a starter that was just written.
+func (sh *StarterHandler) toResponse(ctx *gin.Context, starter *model.Starter, enriched *model.EnrichedData) (interface{}, error) {
+	pii, err := newPIIFilter(ctx, sh.piiAccess, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := starterdto.FromDomainEnriched(starter, starterdto.FromDomainEnrichment(enriched, pii), pii)
+	if err := sh.piiAccess.Record(ctx, pii.Reads()); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -2,13 +2,22 @@ package http
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/kiin21/go-rest/pkg/auth"
 )
 
-func RegisterStarterRoutes(rg *gin.RouterGroup, handler *StarterHandler) {
+// RegisterStarterRoutes registers the starter routes; idempotent guards the create route.
+// Any role may read, HR admins make changes, and leaders may update starters in their subtree.
+func RegisterStarterRoutes(rg *gin.RouterGroup, handler *StarterHandler, idempotent gin.HandlerFunc) {
+	readers := auth.RequireRoles(auth.AllRoles...)
+	hrAdmins := auth.RequireRoles(auth.RoleHRAdmin)
+
 	route := rg.Group("/starters")
-	route.POST("", handler.CreateStarter)
-	route.GET("", handler.ListStarters)
-	route.GET("/:domain", handler.Find)
-	route.PATCH("/:domain", handler.UpdateStarter)
-	route.DELETE("/:domain", handler.SoftDeleteStarter)
+	route.POST("", hrAdmins, idempotent, handler.CreateStarter)
+	route.GET("", readers, handler.ListStarters)
+	route.POST("/batch-get", readers, handler.BatchGet)
+	route.PATCH("/bulk", hrAdmins, handler.BulkUpdate)
+	route.DELETE("/bulk", hrAdmins, handler.BulkDelete)
+	route.GET("/:domain", readers, handler.Find)
+	route.PATCH("/:domain", auth.RequireRoles(auth.RoleHRAdmin, auth.RoleLeader), handler.UpdateStarter)
+	route.DELETE("/:domain", hrAdmins, handler.SoftDeleteStarter)
 }
@@ -0,0 +1,15 @@
+-- =============================================
+-- ACCENT-INSENSITIVE NAME SEARCH
+-- =============================================
+-- name_search holds the folded form of name (lowercase, no diacritics, đ -> d).
+-- The application keeps it in sync on every write. The backfill below only folds
+-- đ/Đ and lowercases; finish it by running the backfill command once after this
+-- migration (make db-backfill-name-search), which folds the remaining diacritics.
+
+ALTER TABLE `starters`
+    ADD COLUMN `name_search` VARCHAR(255) NOT NULL DEFAULT '' AFTER `name`;
+
+UPDATE starters
+SET name_search = LOWER(REPLACE(REPLACE(name, 'Đ', 'D'), 'đ', 'd'));
+
+CREATE INDEX `idx_starters_name_search` ON `starters` (`name_search`);
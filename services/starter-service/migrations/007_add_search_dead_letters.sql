@@ -0,0 +1,20 @@
+-- =============================================
+-- SEARCH INDEX DEAD LETTERS
+-- =============================================
+-- Starters whose documents Elasticsearch permanently rejected during bulk indexing
+-- (mapping errors, or retryable errors that outlived every retry).
+
+CREATE TABLE IF NOT EXISTS `search_index_dead_letters`
+(
+    `id`          BIGINT AUTO_INCREMENT PRIMARY KEY,
+    `starter_id`  BIGINT       NOT NULL,
+    `status_code` INT          NOT NULL,
+    `error_type`  VARCHAR(100) NOT NULL DEFAULT '',
+    `reason`      TEXT         NOT NULL,
+    `attempts`    INT          NOT NULL DEFAULT 1,
+    `created_at`  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX `idx_search_index_dead_letters_starter_id` (`starter_id`),
+    INDEX `idx_search_index_dead_letters_created_at` (`created_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
@@ -0,0 +1,17 @@
+-- =============================================
+-- PHONE SEARCH ON NORMALIZED DIGITS
+-- =============================================
+-- mobile_search and work_phone_search hold the digits of the phone numbers
+-- (spaces, dashes, dots and brackets removed, +84 rewritten to 0) so a search for
+-- "0912 345 678" matches "0912-345-678". The application keeps them in sync on
+-- every write.
+
+ALTER TABLE `starters`
+    ADD COLUMN `mobile_search` VARCHAR(20) NOT NULL DEFAULT '' AFTER `mobile`,
+    ADD COLUMN `work_phone_search` VARCHAR(20) NOT NULL DEFAULT '' AFTER `work_phone`;
+
+UPDATE starters
+SET mobile_search     = REGEXP_REPLACE(REGEXP_REPLACE(mobile, '^[[:space:]]*\\+84', '0'), '[^0-9]', ''),
+    work_phone_search = REGEXP_REPLACE(REGEXP_REPLACE(COALESCE(work_phone, ''), '^[[:space:]]*\\+84', '0'), '[^0-9]', '');
+
+CREATE INDEX `idx_starters_mobile_search` ON `starters` (`mobile_search`);
@@ -0,0 +1,25 @@
+-- =============================================
+-- IDEMPOTENCY KEYS
+-- =============================================
+-- Responses of create requests sent with an Idempotency-Key header, replayed when the
+-- client retries with the same key. Keys are scoped to the authenticated subject that sent
+-- them. Rows past expires_at are taken over by the next request that uses the key, and so
+-- are reservations still in progress (status_code 0) past locked_until, which were left
+-- behind by a request that never finished.
+
+CREATE TABLE IF NOT EXISTS `idempotency_keys`
+(
+    `subject`         VARCHAR(255) NOT NULL,
+    `idempotency_key` VARCHAR(255) NOT NULL,
+    `scope`           VARCHAR(255) NOT NULL,
+    `request_hash`    CHAR(64)     NOT NULL,
+    `status_code`     INT          NOT NULL DEFAULT 0,
+    `response_body`   MEDIUMBLOB   NULL,
+    `created_at`      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `expires_at`      TIMESTAMP    NOT NULL,
+    `locked_until`    TIMESTAMP    NOT NULL,
+    PRIMARY KEY (`subject`, `idempotency_key`),
+    INDEX `idx_idempotency_keys_expires_at` (`expires_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_bin;
@@ -0,0 +1,21 @@
+-- =============================================
+-- PII ACCESS LOGS
+-- =============================================
+-- One row per starter whose email or phone numbers were returned unmasked, with who
+-- read them, why they were allowed to and through which route.
+
+CREATE TABLE IF NOT EXISTS `pii_access_logs`
+(
+    `id`             BIGINT AUTO_INCREMENT PRIMARY KEY,
+    `viewer_domain`  VARCHAR(255) NOT NULL,
+    `starter_id`     BIGINT       NOT NULL,
+    `starter_domain` VARCHAR(255) NOT NULL,
+    `fields`         VARCHAR(255) NOT NULL,
+    `reason`         VARCHAR(50)  NOT NULL,
+    `route`          VARCHAR(255) NOT NULL,
+    `accessed_at`    TIMESTAMP(3) NOT NULL,
+    INDEX `idx_pii_access_logs_starter_id` (`starter_id`, `accessed_at`),
+    INDEX `idx_pii_access_logs_viewer_domain` (`viewer_domain`, `accessed_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
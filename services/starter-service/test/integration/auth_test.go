@@ -0,0 +1,168 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiin21/go-rest/pkg/auth"
+)
+
+// makeRequestAs sends a request with the given bearer token
+func makeRequestAs(t *testing.T, env *TestEnv, token, method, path string, body interface{}) *httptest.ResponseRecorder {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		reqBody, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(method, path, bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	env.Router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestAuthentication_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	env := SetupTestEnvironment(t)
+	defer env.Cleanup()
+
+	t.Run("Missing token is rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/starters", nil)
+		w := httptest.NewRecorder()
+		env.Engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), "Bearer")
+	})
+
+	t.Run("Invalid token is rejected", func(t *testing.T) {
+		w := makeRequestAs(t, env, "not-a-token", http.MethodGet, "/api/v1/starters", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Health check stays public", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		w := httptest.NewRecorder()
+		env.Engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Viewer can read but not write", func(t *testing.T) {
+		viewer := SignTestToken(t, "viewer.user", auth.RoleViewer)
+
+		w := makeRequestAs(t, env, viewer, http.MethodGet, "/api/v1/starters", nil)
+		AssertSuccess(t, w)
+
+		w = makeRequestAs(t, env, viewer, http.MethodPost, "/api/v1/starters", map[string]interface{}{
+			"domain": "viewer.created",
+			"name":   "Viewer Created",
+			"email":  "viewer.created@vng.com.vn",
+		})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = makeRequestAs(t, env, viewer, http.MethodDelete, "/api/v1/organization/departments/9", nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Token without a known role is forbidden", func(t *testing.T) {
+		w := makeRequestAs(t, env, SignTestToken(t, "nobody"), http.MethodGet, "/api/v1/starters", nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestLeaderSubtree_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	env := SetupTestEnvironment(t)
+	defer env.Cleanup()
+	CleanupDatabase(t, env.DB)
+
+	leader := createStarter(t, env, "subtree.leader", "Subtree Leader", "subtree.leader@vng.com.vn")
+	leaderID := int64(leader["id"].(float64))
+
+	parent := createDepartment(t, env, "Leader Parent", "LP", 1)
+	parentID := int64(parent["id"].(float64))
+	w := MakeRequest(t, env, http.MethodPatch, fmt.Sprintf("/api/v1/organization/departments/%d/leader", parentID),
+		map[string]interface{}{"leader_id": leaderID})
+	AssertSuccess(t, w)
+
+	child := createDepartment(t, env, "Leader Child", "LC", 1)
+	childID := int64(child["id"].(float64))
+	w = MakeRequest(t, env, http.MethodPatch, fmt.Sprintf("/api/v1/organization/departments/%d", childID),
+		map[string]interface{}{"group_department_id": parentID})
+	AssertSuccess(t, w)
+
+	other := createDepartment(t, env, "Other Department", "OD", 1)
+	otherID := int64(other["id"].(float64))
+
+	createStarter(t, env, "subtree.member", "Subtree Member", "subtree.member@vng.com.vn")
+	w = MakeRequest(t, env, http.MethodPatch, "/api/v1/starters/subtree.member",
+		map[string]interface{}{"department_id": childID})
+	AssertSuccess(t, w)
+
+	createStarter(t, env, "subtree.outsider", "Subtree Outsider", "subtree.outsider@vng.com.vn")
+	w = MakeRequest(t, env, http.MethodPatch, "/api/v1/starters/subtree.outsider",
+		map[string]interface{}{"department_id": otherID})
+	AssertSuccess(t, w)
+
+	token := SignTestToken(t, "subtree.leader", auth.RoleLeader)
+
+	t.Run("Leader edits a department in the subtree", func(t *testing.T) {
+		w := makeRequestAs(t, env, token, http.MethodPatch, fmt.Sprintf("/api/v1/organization/departments/%d", childID),
+			map[string]interface{}{"full_name": "Leader Child Renamed"})
+		AssertSuccess(t, w)
+	})
+
+	t.Run("Leader cannot edit a department outside the subtree", func(t *testing.T) {
+		w := makeRequestAs(t, env, token, http.MethodPatch, fmt.Sprintf("/api/v1/organization/departments/%d", otherID),
+			map[string]interface{}{"full_name": "Not Yours"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Leader cannot move a department out of the subtree", func(t *testing.T) {
+		w := makeRequestAs(t, env, token, http.MethodPatch, fmt.Sprintf("/api/v1/organization/departments/%d", childID),
+			map[string]interface{}{"group_department_id": otherID})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Leader cannot delete departments", func(t *testing.T) {
+		w := makeRequestAs(t, env, token, http.MethodDelete, fmt.Sprintf("/api/v1/organization/departments/%d", childID), nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Leader updates a starter in the subtree", func(t *testing.T) {
+		w := makeRequestAs(t, env, token, http.MethodPatch, "/api/v1/starters/subtree.member",
+			map[string]interface{}{"job_title": "Team Lead"})
+		AssertSuccess(t, w)
+	})
+
+	t.Run("Leader cannot update a starter outside the subtree", func(t *testing.T) {
+		w := makeRequestAs(t, env, token, http.MethodPatch, "/api/v1/starters/subtree.outsider",
+			map[string]interface{}{"job_title": "Team Lead"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Leader cannot move a starter out of the subtree", func(t *testing.T) {
+		w := makeRequestAs(t, env, token, http.MethodPatch, "/api/v1/starters/subtree.member",
+			map[string]interface{}{"department_id": otherID})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,77 @@
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORSAndSecurityHeaders_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	env := SetupTestEnvironment(t)
+	defer env.Cleanup()
+
+	preflight := func(origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/starters", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "Authorization, Content-Type")
+		w := httptest.NewRecorder()
+		env.Engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Preflight from an allowed origin", func(t *testing.T) {
+		w := preflight(testAllowedOrigin)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, testAllowedOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), http.MethodPost)
+		assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "Idempotency-Key")
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("Preflight from another origin is refused", func(t *testing.T) {
+		w := preflight("http://evil.example.test")
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("Requests from another origin get no CORS headers", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/starters", nil)
+		req.Header.Set("Origin", "http://evil.example.test")
+		w := httptest.NewRecorder()
+		env.Router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Contains(t, w.Header().Values("Vary"), "Origin")
+	})
+
+	t.Run("HEAD is routed instead of answered by CORS", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodHead, "/api/v1/starters", nil)
+		req.Header.Set("Origin", testAllowedOrigin)
+		w := httptest.NewRecorder()
+		env.Router.ServeHTTP(w, req)
+
+		assert.NotEqual(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Security headers are set", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.Header.Set("X-Forwarded-Proto", "https")
+		w := httptest.NewRecorder()
+		env.Engine.ServeHTTP(w, req)
+
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+		assert.Equal(t, "no-referrer", w.Header().Get("Referrer-Policy"))
+		assert.Contains(t, w.Header().Get("Strict-Transport-Security"), "max-age=")
+	})
+}
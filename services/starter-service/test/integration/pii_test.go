@@ -0,0 +1,174 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiin21/go-rest/pkg/auth"
+)
+
+func TestPIIMasking_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	env := SetupTestEnvironment(t)
+	defer env.Cleanup()
+	CleanupDatabase(t, env.DB)
+
+	team := createDepartment(t, env, "PII Team", "PT", 1)
+	teamID := int64(team["id"].(float64))
+	elsewhere := createDepartment(t, env, "PII Elsewhere", "PE", 1)
+	elsewhereID := int64(elsewhere["id"].(float64))
+
+	viewer := createStarter(t, env, "pii.viewer", "PII Viewer", "pii.viewer@vng.com.vn")
+	viewerID := int64(viewer["id"].(float64))
+	colleague := createStarter(t, env, "pii.colleague", "PII Colleague", "pii.colleague@vng.com.vn")
+	colleagueID := int64(colleague["id"].(float64))
+	createStarter(t, env, "pii.other", "PII Other", "pii.other@vng.com.vn")
+	createStarter(t, env, "pii.report", "PII Report", "pii.report@vng.com.vn")
+	outsider := createStarter(t, env, "pii.outsider", "PII Outsider", "pii.outsider@vng.com.vn")
+	outsiderID := int64(outsider["id"].(float64))
+
+	moves := map[string]map[string]interface{}{
+		"pii.viewer":    {"department_id": teamID},
+		"pii.colleague": {"department_id": teamID, "line_manager_id": outsiderID},
+		"pii.other":     {"department_id": elsewhereID, "line_manager_id": colleagueID},
+		"pii.report":    {"department_id": elsewhereID, "line_manager_id": viewerID},
+		"pii.outsider":  {"department_id": elsewhereID},
+	}
+	for domain, payload := range moves {
+		w := MakeRequest(t, env, http.MethodPatch, "/api/v1/starters/"+domain, payload)
+		AssertSuccess(t, w)
+	}
+	leaders := map[int64]int64{teamID: colleagueID, elsewhereID: outsiderID}
+	for departmentID, leaderID := range leaders {
+		w := MakeRequest(t, env, http.MethodPatch, fmt.Sprintf("/api/v1/organization/departments/%d/leader", departmentID),
+			map[string]interface{}{"leader_id": leaderID})
+		AssertSuccess(t, w)
+	}
+	CleanupPIIAccessLogs(t, env)
+
+	token := SignTestToken(t, "pii.viewer", auth.RoleViewer)
+	find := func(t *testing.T, domain string) map[string]interface{} {
+		w := makeRequestAs(t, env, token, http.MethodGet, "/api/v1/starters/"+domain, nil)
+		AssertSuccess(t, w)
+		return ExtractSingleData(t, w.Body.Bytes())
+	}
+
+	t.Run("Own record is unmasked", func(t *testing.T) {
+		data := find(t, "pii.viewer")
+		assert.Equal(t, "pii.viewer@vng.com.vn", data["email"])
+		assert.Equal(t, "+84901234567", data["mobile"])
+	})
+
+	t.Run("Direct report is unmasked", func(t *testing.T) {
+		data := find(t, "pii.report")
+		assert.Equal(t, "pii.report@vng.com.vn", data["email"])
+	})
+
+	t.Run("Same department is masked", func(t *testing.T) {
+		data := find(t, "pii.colleague")
+		assert.Equal(t, "p***@vng.com.vn", data["email"])
+		assert.Equal(t, "*********567", data["mobile"])
+		assert.Equal(t, "****567", data["work_phone"])
+	})
+
+	t.Run("Other departments are omitted", func(t *testing.T) {
+		data := find(t, "pii.other")
+		assert.NotContains(t, data, "email")
+		assert.NotContains(t, data, "mobile")
+		assert.NotContains(t, data, "work_phone")
+		assert.Equal(t, "PII Other", data["name"])
+	})
+
+	t.Run("Omitted fields stay out of sparse fieldsets", func(t *testing.T) {
+		w := makeRequestAs(t, env, token, http.MethodGet, "/api/v1/starters/pii.other?fields=name,email", nil)
+		AssertSuccess(t, w)
+		data := ExtractSingleData(t, w.Body.Bytes())
+		assert.NotContains(t, data, "email")
+	})
+
+	t.Run("Nested line managers are masked as their own records", func(t *testing.T) {
+		own := find(t, "pii.report")["line_manager"].(map[string]interface{})
+		assert.Equal(t, "pii.viewer@vng.com.vn", own["email"])
+
+		colleague := find(t, "pii.other")["line_manager"].(map[string]interface{})
+		assert.Equal(t, "pii.colleague", colleague["domain"])
+		assert.Equal(t, "p***@vng.com.vn", colleague["email"])
+
+		outsider := find(t, "pii.colleague")["line_manager"].(map[string]interface{})
+		assert.Equal(t, "pii.outsider", outsider["domain"])
+		assert.NotContains(t, outsider, "email")
+	})
+
+	t.Run("Department leaders are masked as their own records", func(t *testing.T) {
+		w := makeRequestAs(t, env, token, http.MethodGet, fmt.Sprintf("/api/v1/organization/departments/%d", teamID), nil)
+		AssertSuccess(t, w)
+		leader := ExtractSingleData(t, w.Body.Bytes())["leader"].(map[string]interface{})
+		assert.Equal(t, "p***@vng.com.vn", leader["email"])
+
+		w = makeRequestAs(t, env, token, http.MethodGet, fmt.Sprintf("/api/v1/organization/departments/%d", elsewhereID), nil)
+		AssertSuccess(t, w)
+		leader = ExtractSingleData(t, w.Body.Bytes())["leader"].(map[string]interface{})
+		assert.NotContains(t, leader, "email")
+	})
+
+	t.Run("Unmasked reads are logged", func(t *testing.T) {
+		var rows []struct {
+			StarterDomain string
+			Reason        string
+			Fields        string
+			Route         string
+		}
+		err := env.DB.Raw(`SELECT starter_domain, reason, fields, route FROM pii_access_logs
+			WHERE viewer_domain = ? ORDER BY id`, "pii.viewer").Scan(&rows).Error
+		require.NoError(t, err)
+
+		// The viewer's own record, then their email as pii.report's line manager, once for each
+		// of the two reads of pii.report
+		require.Len(t, rows, 5)
+		assert.Equal(t, "pii.viewer", rows[0].StarterDomain)
+		assert.Equal(t, "self", rows[0].Reason)
+		assert.Equal(t, "email,mobile,work_phone", rows[0].Fields)
+		assert.Equal(t, "GET /api/v1/starters/:domain", rows[0].Route)
+		assert.Equal(t, "pii.viewer", rows[1].StarterDomain)
+		assert.Equal(t, "self", rows[1].Reason)
+		assert.Equal(t, "email", rows[1].Fields)
+		assert.Equal(t, "pii.report", rows[2].StarterDomain)
+		assert.Equal(t, "line_manager", rows[2].Reason)
+		assert.Equal(t, "pii.viewer", rows[3].StarterDomain)
+		assert.Equal(t, "pii.report", rows[4].StarterDomain)
+	})
+
+	t.Run("Searching contact details is for HR admins", func(t *testing.T) {
+		for _, query := range []string{
+			"q=pii.other%40vng.com.vn&search_by=email",
+			"q=0901234567&search_by=phone",
+			"filter=" + url.QueryEscape("email:eq:pii.other@vng.com.vn"),
+		} {
+			w := makeRequestAs(t, env, token, http.MethodGet, "/api/v1/starters?"+query, nil)
+			assert.Equal(t, http.StatusForbidden, w.Code, query)
+
+			w = MakeRequest(t, env, http.MethodGet, "/api/v1/starters?"+query, nil)
+			assert.Equal(t, http.StatusOK, w.Code, query)
+		}
+	})
+
+	t.Run("HR admins see everything", func(t *testing.T) {
+		w := MakeRequest(t, env, http.MethodGet, "/api/v1/starters/pii.other", nil)
+		AssertSuccess(t, w)
+		data := ExtractSingleData(t, w.Body.Bytes())
+		assert.Equal(t, "pii.other@vng.com.vn", data["email"])
+	})
+}
+
+// CleanupPIIAccessLogs drops the access log rows written while setting up a test
+func CleanupPIIAccessLogs(t *testing.T, env *TestEnv) {
+	require.NoError(t, env.DB.Exec("DELETE FROM pii_access_logs").Error)
+}
@@ -0,0 +1,56 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiin21/go-rest/pkg/auth"
+)
+
+func TestRateLimit_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	env := SetupTestEnvironment(t)
+	defer env.Cleanup()
+
+	// testRateLimitedRoute allows 3 requests a minute per caller
+	const path = "/api/v1/organization/business-units/1"
+
+	t.Run("Requests over the limit get 429", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			w := MakeRequest(t, env, http.MethodGet, path, nil)
+			AssertSuccess(t, w)
+		}
+
+		w := MakeRequest(t, env, http.MethodGet, path, nil)
+		require.Equal(t, http.StatusTooManyRequests, w.Code)
+
+		retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+		require.NoError(t, err)
+		assert.Greater(t, retryAfter, 0)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, float64(http.StatusTooManyRequests), response["code"])
+		assert.Equal(t, "Too many requests", response["message"])
+		assert.NotEmpty(t, response["error"])
+	})
+
+	t.Run("Other callers have their own bucket", func(t *testing.T) {
+		token := SignTestToken(t, "rate.limited.viewer", auth.RoleViewer)
+		w := makeRequestAs(t, env, token, http.MethodGet, path, nil)
+		AssertSuccess(t, w)
+	})
+
+	t.Run("Other routes are not affected", func(t *testing.T) {
+		w := MakeRequest(t, env, http.MethodGet, "/api/v1/organization/business-units?limit=1", nil)
+		AssertSuccess(t, w)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,22 +13,37 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/mysql"
 	"github.com/testcontainers/testcontainers-go/wait"
 	mysqlDriver "gorm.io/driver/mysql"
 	"gorm.io/gorm"
 
+	"github.com/kiin21/go-rest/pkg/auth"
 	"github.com/kiin21/go-rest/pkg/events"
+	"github.com/kiin21/go-rest/pkg/httpmw"
 	"github.com/kiin21/go-rest/pkg/httputil"
 	"github.com/kiin21/go-rest/services/starter-service/internal/initialize"
 	initStarter "github.com/kiin21/go-rest/services/starter-service/internal/initialize/starter"
 	persistentMySQL "github.com/kiin21/go-rest/services/starter-service/internal/starter/infrastructure/persistence/repository/mysql"
 )
 
+// testJWTSecret signs the bearer tokens of integration tests
+const testJWTSecret = "integration-test-secret"
+
+// testAllowedOrigin is the only browser origin the test router accepts
+const testAllowedOrigin = "http://app.example.test"
+
+// testRateLimitedRoute is the only route with a limit low enough to be reached in tests
+const testRateLimitedRoute = "GET /api/v1/organization/business-units/:id"
+
 // TestEnv holds all test infrastructure
 type TestEnv struct {
-	Router         *gin.Engine
+	// Router sends requests without an Authorization header as an HR and org admin
+	Router http.Handler
+	// Engine is the bare router, for requests that must not be authenticated
+	Engine         *gin.Engine
 	DB             *gorm.DB
 	MySQLContainer testcontainers.Container
 	MySQLConnStr   string
@@ -81,10 +97,14 @@ func SetupTestEnvironment(t *testing.T) *TestEnv {
 	}
 
 	// Initialize test router and dependencies
-	router := setupRouter(db)
+	router := setupRouter(t, db)
 
 	env := &TestEnv{
-		Router:         router,
+		Router: &authenticatedRouter{
+			engine: router,
+			token:  SignTestToken(t, "admin", auth.RoleHRAdmin, auth.RoleOrgAdmin),
+		},
+		Engine:         router,
 		DB:             db,
 		MySQLContainer: mysqlContainer,
 		MySQLConnStr:   connStr,
@@ -99,7 +119,7 @@ func SetupTestEnvironment(t *testing.T) *TestEnv {
 }
 
 // setupRouter creates a Gin router with all dependencies for testing
-func setupRouter(db *gorm.DB) *gin.Engine {
+func setupRouter(t *testing.T, db *gorm.DB) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 
 	// Create mock/no-op producers for testing
@@ -111,34 +131,87 @@ func setupRouter(db *gorm.DB) *gin.Engine {
 	starterRepo := persistentMySQL.NewStarterRepository(db)
 	businessUnitRepo := persistentMySQL.NewBusinessUnitRepository(db)
 	departmentRepo := persistentMySQL.NewDepartmentRepository(db)
+	piiAccessLogRepo := persistentMySQL.NewPIIAccessLogRepository(db)
 
 	// Initialize handlers
 	orgHandler := initStarter.InitOrganization(
 		starterRepo,
 		departmentRepo,
 		businessUnitRepo,
+		piiAccessLogRepo,
 		notificationProducer,
 	)
 
-	starterHandler, _, _ := initStarter.InitStarter(
+	starterHandler, _, _, _ := initStarter.InitStarter(
 		starterRepo,
 		departmentRepo,
 		businessUnitRepo,
+		persistentMySQL.NewSearchDeadLetterRepository(db),
+		piiAccessLogRepo,
 		nil, // No Elasticsearch for basic tests
 		syncProducer,
+		notificationProducer,
 	)
 
+	searchAdminHandler, _ := initStarter.InitSearchAdmin(starterRepo, nil, syncProducer, nil, 0)
+
+	verifier, err := auth.NewVerifier(auth.Config{HMACSecret: testJWTSecret})
+	if err != nil {
+		t.Fatalf("Failed to create token verifier: %v", err)
+	}
+
 	// Initialize router
 	router := initialize.InitRouter(
 		"debug",
 		requestURLResolver,
 		orgHandler,
 		starterHandler,
+		searchAdminHandler,
+		persistentMySQL.NewIdempotencyStore(db),
+		verifier,
+		httpmw.CORSConfig{
+			AllowedOrigins: []string{testAllowedOrigin},
+			AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodDelete},
+			AllowedHeaders: []string{"Authorization", "Content-Type"},
+		},
+		httpmw.NewMemoryRateLimitStore(),
+		httpmw.RateLimitConfig{
+			Default: httpmw.RateLimit{Requests: 10000, Per: time.Minute},
+			Routes:  map[string]httpmw.RateLimit{testRateLimitedRoute: {Requests: 3, Per: time.Minute}},
+		},
 	)
 
 	return router
 }
 
+// SignTestToken issues a bearer token for subject, a starter domain, with the given roles
+func SignTestToken(t *testing.T, subject string, roles ...auth.Role) string {
+	claims := jwt.MapClaims{
+		"sub":   subject,
+		"roles": roles,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatalf("Failed to sign test token: %v", err)
+	}
+	return token
+}
+
+// authenticatedRouter adds an admin token to requests that carry none, so that tests which
+// are not about access control do not have to deal with tokens
+type authenticatedRouter struct {
+	engine *gin.Engine
+	token  string
+}
+
+func (r *authenticatedRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	r.engine.ServeHTTP(w, req)
+}
+
 // runMigrations executes all SQL migration files
 func runMigrations(db *gorm.DB, connStr string) error {
 	sqlDB, err := db.DB()
@@ -342,6 +415,14 @@ func CleanupDatabase(t *testing.T, db *gorm.DB) {
 		t.Logf("Warning: failed to clean departments: %v", err)
 	}
 
+	if err := db.Exec("DELETE FROM idempotency_keys").Error; err != nil {
+		t.Logf("Warning: failed to clean idempotency keys: %v", err)
+	}
+
+	if err := db.Exec("DELETE FROM pii_access_logs").Error; err != nil {
+		t.Logf("Warning: failed to clean PII access logs: %v", err)
+	}
+
 	// Don't delete business_units or companies - they are needed by tests
 	// Tests reference business_unit_id = 1, 2, 3, 4 from migrations
 
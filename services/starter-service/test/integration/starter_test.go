@@ -2,14 +2,20 @@ package integration
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/kiin21/go-rest/pkg/auth"
+	persistentMySQL "github.com/kiin21/go-rest/services/starter-service/internal/starter/infrastructure/persistence/repository/mysql"
 )
 
 func TestStarter_Integration(t *testing.T) {
@@ -219,6 +225,27 @@ func TestStarter_Integration(t *testing.T) {
 
 		assert.NotEqual(t, http.StatusOK, w.Code)
 	})
+
+	t.Run("Backfill Name Search", func(t *testing.T) {
+		CleanupDatabase(t, env.DB)
+
+		createStarter(t, env, "danganh", "Đặng Thị Ánh", "danganh@vng.com.vn")
+		// What migration 006 leaves behind for a row that existed before it
+		require.NoError(t, env.DB.Exec("UPDATE starters SET name_search = ? WHERE domain = ?", "dặng thị ánh", "danganh").Error)
+
+		updated, err := persistentMySQL.BackfillNameSearch(context.Background(), env.DB)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, updated, 1)
+
+		var nameSearch string
+		require.NoError(t, env.DB.Raw("SELECT name_search FROM starters WHERE domain = ?", "danganh").Scan(&nameSearch).Error)
+		assert.Equal(t, "dang thi anh", nameSearch)
+
+		// Every row is in sync now
+		updated, err = persistentMySQL.BackfillNameSearch(context.Background(), env.DB)
+		require.NoError(t, err)
+		assert.Equal(t, 0, updated)
+	})
 }
 
 // Helper function to create a starter
@@ -370,5 +397,274 @@ func TestStarter_WithDepartmentAndManager_Integration(t *testing.T) {
 		data := response["data"].([]interface{})
 		assert.GreaterOrEqual(t, len(data), 3)
 	})
-}
 
+	t.Run("List Starters with Structured Filter", func(t *testing.T) {
+		CleanupDatabase(t, env.DB)
+
+		departmentID := int64(9)
+		jobTitles := []string{"Filter Engineer", "Filter Engineer", "Filter Analyst"}
+
+		for i, jobTitle := range jobTitles {
+			payload := map[string]interface{}{
+				"domain":        fmt.Sprintf("structuser%d", i),
+				"name":          fmt.Sprintf("Struct User %d", i),
+				"email":         fmt.Sprintf("structuser%d@vng.com.vn", i),
+				"mobile":        "+84901234567",
+				"work_phone":    "1234567",
+				"job_title":     jobTitle,
+				"department_id": departmentID,
+			}
+
+			body, _ := json.Marshal(payload)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/starters", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			env.Router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+		}
+
+		filter := url.QueryEscape(fmt.Sprintf("department_id:in:%d;job_title:eq:Filter Engineer", departmentID))
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/starters?limit=10&filter="+filter, nil)
+		w := httptest.NewRecorder()
+
+		env.Router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		data := response["data"].([]interface{})
+		assert.Len(t, data, 2)
+		for _, item := range data {
+			assert.Equal(t, "Filter Engineer", item.(map[string]interface{})["job_title"])
+		}
+	})
+
+	t.Run("List Starters with Invalid Filter", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/starters?filter="+url.QueryEscape("salary:gt:100"), nil)
+		w := httptest.NewRecorder()
+
+		env.Router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		detail := response["error"].(map[string]interface{})
+		assert.Equal(t, "filter", detail["field"])
+	})
+	t.Run("Get Starter with Sparse Fields", func(t *testing.T) {
+		CleanupDatabase(t, env.DB)
+
+		manager := createStarter(t, env, "sparsemgr", "Sparse Manager", "sparsemgr@vng.com.vn")
+		payload := map[string]interface{}{
+			"domain":          "sparseuser",
+			"name":            "Sparse User",
+			"email":           "sparseuser@vng.com.vn",
+			"mobile":          "+84901234567",
+			"job_title":       "Engineer",
+			"line_manager_id": int64(manager["id"].(float64)),
+		}
+		body, _ := json.Marshal(payload)
+		createReq := httptest.NewRequest(http.MethodPost, "/api/v1/starters", bytes.NewBuffer(body))
+		createReq.Header.Set("Content-Type", "application/json")
+		env.Router.ServeHTTP(httptest.NewRecorder(), createReq)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/starters/sparseuser?fields=domain,name,line_manager", nil)
+		w := httptest.NewRecorder()
+
+		env.Router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		data := response["data"].(map[string]interface{})
+		assert.Len(t, data, 4)
+		assert.Equal(t, "sparseuser", data["domain"])
+		assert.Equal(t, "Sparse User", data["name"])
+		lineManager := data["line_manager"].(map[string]interface{})
+		assert.Equal(t, "sparsemgr", lineManager["domain"])
+	})
+
+	t.Run("List Starters with Invalid Expand", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/starters?expand=salary", nil)
+		w := httptest.NewRecorder()
+
+		env.Router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		detail := response["error"].(map[string]interface{})
+		assert.Equal(t, "expand", detail["field"])
+	})
+	t.Run("Batch Get Starters", func(t *testing.T) {
+		CleanupDatabase(t, env.DB)
+
+		createStarter(t, env, "batchone", "Batch One", "batchone@vng.com.vn")
+		createStarter(t, env, "batchtwo", "Batch Two", "batchtwo@vng.com.vn")
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"domains": []string{"batchtwo", "missing", "batchone"},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/starters/batch-get", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		env.Router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		data := response["data"].(map[string]interface{})
+		starters := data["starters"].([]interface{})
+		require.Len(t, starters, 2)
+		assert.Equal(t, "batchtwo", starters[0].(map[string]interface{})["domain"])
+		assert.Equal(t, "batchone", starters[1].(map[string]interface{})["domain"])
+		assert.Equal(t, []interface{}{"missing"}, data["not_found"])
+	})
+
+	t.Run("Batch Get Starters - Validation Errors", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]interface{}{
+			"domains": []string{"one"},
+			"ids":     []int64{1},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/starters/batch-get", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		env.Router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("Bulk Update and Delete Starters", func(t *testing.T) {
+		CleanupDatabase(t, env.DB)
+
+		createStarter(t, env, "bulkone", "Bulk One", "bulkone@vng.com.vn")
+		createStarter(t, env, "bulktwo", "Bulk Two", "bulktwo@vng.com.vn")
+
+		w := MakeRequest(t, env, http.MethodPatch, "/api/v1/starters/bulk", map[string]interface{}{
+			"domains": []string{"bulkone", "bulktwo", "missing"},
+			"changes": map[string]interface{}{"job_title": "Staff Engineer"},
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		data := ExtractSingleData(t, w.Body.Bytes())
+		assert.Equal(t, true, data["applied"])
+		summary := data["summary"].(map[string]interface{})
+		assert.Equal(t, float64(2), summary["updated"])
+		assert.Equal(t, float64(1), summary["not_found"])
+
+		w = MakeRequest(t, env, http.MethodGet, "/api/v1/starters/bulkone", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "Staff Engineer", ExtractSingleData(t, w.Body.Bytes())["job_title"])
+
+		w = MakeRequest(t, env, http.MethodDelete, "/api/v1/starters/bulk", map[string]interface{}{
+			"domains": []string{"bulkone", "bulktwo"},
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = MakeRequest(t, env, http.MethodGet, "/api/v1/starters/bulktwo", nil)
+		assert.NotEqual(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Bulk Update Starters - Validation Errors", func(t *testing.T) {
+		w := MakeRequest(t, env, http.MethodPatch, "/api/v1/starters/bulk", map[string]interface{}{
+			"domains": []string{"bulkone"},
+			"filter":  "department_id:eq:1",
+			"changes": map[string]interface{}{"job_title": "Staff Engineer"},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("Create Starter with Idempotency Key", func(t *testing.T) {
+		CleanupDatabase(t, env.DB)
+
+		payload := map[string]interface{}{
+			"domain":        "idemuser",
+			"name":          "Idem User",
+			"email":         "idemuser@vng.com.vn",
+			"mobile":        "+84901234567",
+			"work_phone":    "1234567",
+			"job_title":     "Engineer",
+			"department_id": 1,
+		}
+		post := func(body map[string]interface{}) *httptest.ResponseRecorder {
+			raw, _ := json.Marshal(body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/starters", bytes.NewBuffer(raw))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Idempotency-Key", "create-idemuser-1")
+			w := httptest.NewRecorder()
+			env.Router.ServeHTTP(w, req)
+			return w
+		}
+
+		first := post(payload)
+		require.Equal(t, http.StatusOK, first.Code)
+
+		retry := post(payload)
+		assert.Equal(t, http.StatusOK, retry.Code)
+		assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+		assert.JSONEq(t, first.Body.String(), retry.Body.String())
+
+		payload["name"] = "Someone Else"
+		reused := post(payload)
+		assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+
+		// Another caller's key of the same name is not replayed to them
+		payload["domain"] = "idemother"
+		payload["email"] = "idemother@vng.com.vn"
+		raw, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/starters", bytes.NewBuffer(raw))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "create-idemuser-1")
+		req.Header.Set("Authorization", "Bearer "+SignTestToken(t, "hr.other", auth.RoleHRAdmin))
+		other := httptest.NewRecorder()
+		env.Router.ServeHTTP(other, req)
+		require.Equal(t, http.StatusOK, other.Code, other.Body.String())
+		assert.Empty(t, other.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, "idemother", ExtractSingleData(t, other.Body.Bytes())["domain"])
+	})
+	t.Run("Create Starter over a lapsed Idempotency Reservation", func(t *testing.T) {
+		CleanupDatabase(t, env.DB)
+
+		// A reservation left behind by a request that never finished, still far from expiry
+		err := env.DB.Exec(
+			"INSERT INTO idempotency_keys (subject, idempotency_key, scope, request_hash, status_code, expires_at, locked_until) VALUES (?, ?, ?, ?, 0, ?, ?)",
+			"admin", "create-lapsed-1", "POST /api/v1/starters", "stale", time.Now().Add(time.Hour), time.Now().Add(-time.Minute),
+		).Error
+		require.NoError(t, err)
+
+		raw, _ := json.Marshal(map[string]interface{}{
+			"domain":        "lapseduser",
+			"name":          "Lapsed User",
+			"email":         "lapseduser@vng.com.vn",
+			"mobile":        "+84901234568",
+			"work_phone":    "1234568",
+			"job_title":     "Engineer",
+			"department_id": 1,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/starters", bytes.NewBuffer(raw))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "create-lapsed-1")
+		w := httptest.NewRecorder()
+		env.Router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, "lapseduser", ExtractSingleData(t, w.Body.Bytes())["domain"])
+	})
+}